@@ -0,0 +1,1197 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+const (
+	codecTemplate = "template/codec.tmpl"
+	codecFileName = "codec.go"
+
+	oxygenPath = "github.com/gromey/oxygen"
+)
+
+type codecData struct {
+	LCName  string
+	Std     []string // imports of the standard library
+	Imports []string
+	Tags    []codecTag
+	Types   []string          // ids of the requested types
+	Exprs   map[string]string // type expressions by id
+	Names   map[string]string // type names by id
+	Encode  map[string]string // names of the encode methods by id, they run the hooks of the type
+	Decode  map[string]string // names of the decode methods by id
+	Funcs   string
+}
+
+type codecTag struct {
+	Var, Field, Value string
+	Counts            string // expression of the names of the fields that can hold the length of a slice
+	Before            string // expression of the names of the fields declared before the field
+	Def               string // expression of the pointer the default value of the field is parsed into
+}
+
+// Interfaces a type can implement, the indices of the interface table of codecGen.
+const (
+	marshallerIface = iota
+	unmarshalerIface
+	textMarshalerIface
+	textUnmarshalerIface
+	beforeMarshalIface
+	afterUnmarshalIface
+	validateIface
+	ifaceCount
+)
+
+// codecGen generates encode and decode functions for struct types
+// repeating what the engine does with reflection.
+type codecGen struct {
+	name, ucName string
+	src          *types.Package // package declaring the requested types
+	local        *types.Package // package the codec is generated into, nil if it isn't the source package
+
+	imports map[*types.Package]string
+	aliases map[string]bool
+
+	ids     map[*types.Named]string
+	queue   []*types.Named
+	tags    []codecTag
+	funcs   codecWriter
+	tmp     int
+	ifaces  [ifaceCount]*types.Interface
+	genErrs []string
+}
+
+// generateCodec writes codec.go into the formatter package with the functions
+// MarshalX and UnmarshalX for every type X from typeNames declared in the package in the src directory.
+func generateCodec(name, src string, typeNames []string) error {
+	out, err := codecSource(name, src, typeNames)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(name, codecFileName), out, 0660)
+}
+
+// codecSource returns the formatted source of the codec generateCodec writes.
+func codecSource(name, src string, typeNames []string) ([]byte, error) {
+	dir := name
+	if src == "" {
+		src = dir
+	}
+
+	sameDir, err := samePath(dir, src)
+	if err != nil {
+		return nil, err
+	}
+
+	path := name
+	if !sameDir {
+		if path, err = importPath(src); err != nil {
+			return nil, err
+		}
+	}
+
+	pkg, err := loadPackage(src, path, sameDir)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &codecGen{
+		name:    name,
+		ucName:  strings.ToUpper(name),
+		imports: make(map[*types.Package]string),
+		aliases: map[string]bool{"bytes": true, "errors": true, "fmt": true, "reflect": true, "strconv": true, "sync": true, "binary": true, "io": true, "math": true, "utf8": true, "oxygen": true},
+		ids:     make(map[*types.Named]string),
+		src:     pkg,
+	}
+	g.initInterfaces()
+
+	if sameDir {
+		g.local = pkg
+	}
+
+	result := codecData{
+		LCName: name,
+		Exprs:  make(map[string]string),
+		Names:  make(map[string]string),
+		Encode: make(map[string]string),
+		Decode: make(map[string]string),
+	}
+
+	for _, typeName := range typeNames {
+		obj, ok := pkg.Scope().Lookup(typeName).(*types.TypeName)
+		if !ok {
+			return nil, fmt.Errorf("type %s is not declared in package %s", typeName, pkg.Name())
+		}
+
+		named, ok := obj.Type().(*types.Named)
+		if !ok || named.TypeParams().Len() != 0 {
+			return nil, fmt.Errorf("type %s must be a non-generic named struct type", typeName)
+		}
+		if _, ok = named.Underlying().(*types.Struct); !ok {
+			return nil, fmt.Errorf("type %s must be a struct type", typeName)
+		}
+
+		id := g.require(named)
+		result.Types = append(result.Types, id)
+		result.Exprs[id] = g.typeExpr(named)
+		result.Names[id] = typeName
+		result.Encode[id], result.Decode[id] = g.encoderOf(named), g.decoderOf(named)
+	}
+
+	for len(g.queue) != 0 {
+		named := g.queue[0]
+		g.queue = g.queue[1:]
+		g.structFuncs(named)
+	}
+
+	if len(g.genErrs) != 0 {
+		return nil, fmt.Errorf("cannot generate codec:\n\t%s", strings.Join(g.genErrs, "\n\t"))
+	}
+
+	result.Tags = g.tags
+	result.Funcs = g.funcs.String()
+	for p, alias := range g.imports {
+		spec := strconv.Quote(p.Path())
+		if alias != p.Name() {
+			spec = alias + " " + spec
+		}
+		if strings.Contains(strings.Split(p.Path(), "/")[0], ".") {
+			result.Imports = append(result.Imports, spec)
+		} else {
+			result.Std = append(result.Std, spec)
+		}
+	}
+	sort.Strings(result.Std)
+	sort.Strings(result.Imports)
+
+	temp, err := template.ParseFS(content, codecTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err = temp.Execute(&buf, result); err != nil {
+		return nil, err
+	}
+
+	out, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("cannot format generated codec: %w", err)
+	}
+	return out, nil
+}
+
+// loadPackage parses and type-checks the package with the import path in the directory dir.
+// A previously generated codec is skipped if the codec is generated into the same package.
+func loadPackage(dir, path string, skipCodec bool) (*types.Package, error) {
+	bp, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, name := range bp.GoFiles {
+		if skipCodec && name == codecFileName {
+			continue
+		}
+		f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	return conf.Check(path, fset, files, nil)
+}
+
+// importPath returns the import path of the package in the directory dir.
+func importPath(dir string) (string, error) {
+	cmd := exec.Command("go", "list", "-f", "{{.ImportPath}}")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("cannot resolve the import path of %s: %w", dir, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+func samePath(a, b string) (bool, error) {
+	a, err := filepath.Abs(a)
+	if err != nil {
+		return false, err
+	}
+	if b, err = filepath.Abs(b); err != nil {
+		return false, err
+	}
+	return a == b, nil
+}
+
+func (g *codecGen) initInterfaces() {
+	bytesVar := types.NewVar(token.NoPos, nil, "", types.NewSlice(types.Typ[types.Byte]))
+	errVar := types.NewVar(token.NoPos, nil, "", types.Universe.Lookup("error").Type())
+
+	marshal := types.NewSignatureType(nil, nil, nil, nil, types.NewTuple(bytesVar, errVar), false)
+	unmarshal := types.NewSignatureType(nil, nil, nil, types.NewTuple(bytesVar), types.NewTuple(errVar), false)
+	hook := types.NewSignatureType(nil, nil, nil, nil, types.NewTuple(errVar), false)
+
+	for i, m := range [ifaceCount]struct {
+		name string
+		sig  *types.Signature
+	}{
+		marshallerIface:      {"Marshal" + g.ucName, marshal},
+		unmarshalerIface:     {"Unmarshal" + g.ucName, unmarshal},
+		textMarshalerIface:   {"MarshalText", marshal},
+		textUnmarshalerIface: {"UnmarshalText", unmarshal},
+		beforeMarshalIface:   {"BeforeMarshal", hook},
+		afterUnmarshalIface:  {"AfterUnmarshal", hook},
+		validateIface:        {"Validate", hook},
+	} {
+		g.ifaces[i] = types.NewInterfaceType([]*types.Func{types.NewFunc(token.NoPos, nil, m.name, m.sig)}, nil).Complete()
+	}
+}
+
+// implements reports whether a pointer to the non-pointer type t implements the interface i.
+func (g *codecGen) implements(t types.Type, i int) bool {
+	if _, ok := t.Underlying().(*types.Pointer); ok {
+		return false
+	}
+	return types.Implements(types.NewPointer(t), g.ifaces[i])
+}
+
+func (g *codecGen) errorf(format string, args ...any) {
+	msg := fmt.Sprintf(format, args...)
+	for _, e := range g.genErrs {
+		if e == msg {
+			return
+		}
+	}
+	g.genErrs = append(g.genErrs, msg)
+}
+
+// require returns the id of the struct type, queuing its functions for generation.
+func (g *codecGen) require(named *types.Named) string {
+	if id, ok := g.ids[named]; ok {
+		return id
+	}
+
+	id := named.Obj().Name()
+	if p := named.Obj().Pkg(); p != g.src {
+		alias := g.qualifier(p)
+		id = strings.ToUpper(alias[:1]) + alias[1:] + id
+	}
+	for _, other := range g.ids {
+		if other == id {
+			id += strconv.Itoa(len(g.ids))
+		}
+	}
+
+	g.ids[named] = id
+	g.queue = append(g.queue, named)
+	return id
+}
+
+func (g *codecGen) qualifier(p *types.Package) string {
+	if p == g.local {
+		return ""
+	}
+	if alias, ok := g.imports[p]; ok {
+		return alias
+	}
+	if p.Path() == oxygenPath {
+		return "oxygen"
+	}
+
+	alias := p.Name()
+	for i := 2; g.aliases[alias]; i++ {
+		alias = p.Name() + strconv.Itoa(i)
+	}
+	g.aliases[alias] = true
+	g.imports[p] = alias
+	return alias
+}
+
+func (g *codecGen) typeExpr(t types.Type) string {
+	return types.TypeString(t, g.qualifier)
+}
+
+func (g *codecGen) temp(prefix string) string {
+	g.tmp++
+	return prefix + strconv.Itoa(g.tmp)
+}
+
+// accessible reports whether the named types used in t can be referred to from the generated code.
+func (g *codecGen) accessible(t types.Type) bool {
+	switch t := t.(type) {
+	case *types.Named:
+		return t.Obj().Pkg() == nil || t.Obj().Pkg() == g.local || t.Obj().Exported()
+	case *types.Pointer:
+		return g.accessible(t.Elem())
+	case *types.Slice:
+		return g.accessible(t.Elem())
+	case *types.Array:
+		return g.accessible(t.Elem())
+	default:
+		return true
+	}
+}
+
+// isEmpty returns the expression reporting whether the value x is empty the same way as isEmptyValue does.
+func isEmpty(x string, t types.Type) string {
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsBoolean != 0:
+			return "!" + x
+		case u.Info()&types.IsString != 0:
+			return "len(" + x + ") == 0"
+		case u.Info()&(types.IsInteger|types.IsFloat) != 0:
+			return x + " == 0"
+		}
+	case *types.Array, *types.Slice, *types.Map:
+		return "len(" + x + ") == 0"
+	case *types.Pointer, *types.Interface:
+		return x + " == nil"
+	}
+	return ""
+}
+
+// defaultable reports whether oxygen.ParseDefault can parse a default value of the type t,
+// or of the type a pointer of the type t points to.
+func (g *codecGen) defaultable(t types.Type) bool {
+	if ptr, ok := t.Underlying().(*types.Pointer); ok {
+		t = ptr.Elem()
+		if _, ok = t.Underlying().(*types.Pointer); ok {
+			return false
+		}
+	}
+	if b, ok := t.Underlying().(*types.Basic); ok {
+		return b.Info()&(types.IsBoolean|types.IsInteger|types.IsFloat|types.IsString) != 0
+	}
+	return isDecimal(t) || g.implements(t, textUnmarshalerIface)
+}
+
+// isZero returns the expression reporting whether x of the type t is its zero value.
+func (g *codecGen) isZero(x string, t types.Type) string {
+	switch t.Underlying().(type) {
+	case *types.Basic, *types.Pointer:
+		return isEmpty(x, t)
+	}
+	if !types.Comparable(t) {
+		return x + " == nil"
+	}
+	return fmt.Sprintf("%s == *new(%s)", x, g.typeExpr(t))
+}
+
+// codecField describes the field whose value is being generated.
+type codecField struct {
+	owner  string // name of the enclosing struct type
+	path   string // Go path of the field from the enclosing struct
+	name   string // name of the field passed to the Tag methods
+	tag    string // expression of the parsed tag
+	format string // expression of the format of numbers
+	order  string // expression of the byte order of binary primitives
+	typ    string // type expression of the field
+	def    string // tag variable of the field if it can have a default value, empty for elements and pointed values
+	defPtr string // type expression of the value a pointer field with a default value points to
+	index  string // variable of the index of the list element, empty if the value isn't an element
+}
+
+// value returns the field for the elements or the pointed value of the field, they have no default value.
+func (f *codecField) value() *codecField {
+	v := *f
+	v.def, v.defPtr = "", ""
+	return &v
+}
+
+// label returns the expression of the name of the field, or of the index of the element, in the paths of missing fields.
+func (f *codecField) label() string {
+	if f.index != "" {
+		return fmt.Sprintf("\"[\" + strconv.Itoa(%s) + \"]\"", f.index)
+	}
+	return strconv.Quote(f.name)
+}
+
+func (f *codecField) marshalError(err string) string {
+	return fmt.Sprintf("return codecMarshalError(%s, %q, codecTypeOf[%s])", err, f.name, f.typ)
+}
+
+func (f *codecField) unmarshalError(err string) string {
+	return fmt.Sprintf("return codecUnmarshalError(%s, %q, codecTypeOf[%s])", err, f.name, f.typ)
+}
+
+type codecWriter struct {
+	bytes.Buffer
+}
+
+func (w *codecWriter) p(format string, args ...any) {
+	fmt.Fprintf(w, format, args...)
+	w.WriteByte('\n')
+}
+
+// structFuncs generates the encode and decode functions of the struct type named.
+func (g *codecGen) structFuncs(named *types.Named) {
+	id, expr := g.ids[named], g.typeExpr(named)
+	st := named.Underlying().(*types.Struct)
+
+	counts, slices := g.countFields(st)
+	lengths := make(map[string]string) // local copies of the count fields by name
+	before := []string{}               // names of the fields declared before the current field
+	tagged := false
+
+	var enc, cases, remaining codecWriter
+	var fields int
+	for i := 0; i < st.NumFields(); i++ {
+		sf := st.Field(i)
+		ft := sf.Type()
+
+		if sf.Embedded() {
+			et := ft
+			ptr, isPtr := et.Underlying().(*types.Pointer)
+			if isPtr {
+				et = ptr.Elem()
+			}
+			en, ok := et.(*types.Named)
+			if ok {
+				_, ok = en.Underlying().(*types.Struct)
+			}
+			if !ok {
+				if sf.Exported() {
+					g.errorf("%s.%s: embedded field of type %s is not supported", named.Obj().Name(), sf.Name(), g.typeExpr(ft))
+				}
+				continue
+			}
+			if !g.accessible(ft) || (!sf.Exported() && en.Obj().Pkg() != g.local) {
+				g.errorf("%s.%s: embedded field is not accessible from the generated code", named.Obj().Name(), sf.Name())
+				continue
+			}
+
+			eid := g.require(en)
+			fields++
+
+			remaining.p("case %d: // %s", fields-1, sf.Name())
+			if isPtr {
+				p := g.temp("p")
+				remaining.p("%s := v.%s\nif %s == nil {\n%s = new(%s)\n}", p, sf.Name(), p, p, g.typeExpr(et))
+				remaining.p("if !codecRemaining%s(%s, 0, fn) {\nreturn false\n}", eid, p)
+			} else {
+				remaining.p("if !codecRemaining%s(&v.%s, 0, fn) {\nreturn false\n}", eid, sf.Name())
+			}
+
+			enc.p("// %s", sf.Name())
+			enc.p("if sep {\nenc.Write(codecValueSeparator)\n}\nsep = codecSeparate")
+			if isPtr {
+				p := g.temp("p")
+				enc.p("%s := v.%s\nif %s == nil {\n%s = new(%s)\n}", p, sf.Name(), p, p, g.typeExpr(et))
+				enc.p("if err := enc.encode%s(%s, false); err != nil {\nreturn err\n}", eid, p)
+			} else {
+				enc.p("if err := enc.encode%s(&v.%s, false); err != nil {\nreturn err\n}", eid, sf.Name())
+			}
+
+			cases.p("case %d: // %s", fields-1, sf.Name())
+			cases.p("if sep {\nif err := dec.removePrefix(codecValueSeparator); err != nil {\nreturn err\n}\n}\nsep = codecRemoveSeparator")
+			if isPtr {
+				cases.p("if v.%s == nil {", sf.Name())
+				cases.p("return fmt.Errorf(\"%%s: %%w: %%s\", cfg.Name, oxygen.ErrPointerToUnexported, codecTypeOf[%s]())", g.typeExpr(et))
+				cases.p("}\nif err := dec.decode%s(v.%s, false); err != nil {\nreturn err\n}", eid, sf.Name())
+			} else {
+				cases.p("if err := dec.decode%s(&v.%s, false); err != nil {\nreturn err\n}", eid, sf.Name())
+			}
+			continue
+		}
+
+		if !sf.Exported() {
+			continue
+		}
+
+		tagValue, hasTag := reflect.StructTag(st.Tag(i)).Lookup(g.name)
+		if tagValue == "-" {
+			continue
+		}
+
+		if !g.accessible(ft) {
+			g.errorf("%s.%s: type %s is not accessible from the generated code", named.Obj().Name(), sf.Name(), g.typeExpr(ft))
+			continue
+		}
+
+		f := &codecField{owner: named.Obj().Name(), path: "v." + sf.Name(), name: sf.Name(), tag: "nil", format: "cfg.NumberFormat", order: "codecOrder", typ: g.typeExpr(ft)}
+		fields++
+
+		var tagErr, omit, present string
+		if hasTag {
+			t := codecTag{Var: "codec" + id + "_" + sf.Name(), Field: strconv.Quote(sf.Name()), Value: strconv.Quote(tagValue), Counts: "nil", Before: fmt.Sprintf("%#v", before), Def: "new(" + f.typ + ")"}
+			if ptr, ok := ft.Underlying().(*types.Pointer); ok {
+				// The default value of a pointer field is parsed into the value it points to.
+				t.Def = "new(" + g.typeExpr(ptr.Elem()) + ")"
+				if g.defaultable(ft) {
+					f.defPtr = g.typeExpr(ptr.Elem())
+				}
+			}
+			if names, ok := counts[i]; ok {
+				t.Counts = fmt.Sprintf("%#v", names)
+			}
+			g.tags = append(g.tags, t)
+			f.tag, f.format, f.order, omit = t.Var+".tag", t.Var+".format", t.Var+".order", t.Var+".omit"
+			tagErr = fmt.Sprintf("if %s.err != nil {\nreturn &oxygen.TagError{Name: cfg.Name, Tag: %s, Field: %q, Err: %s.err}\n}", t.Var, t.Value, sf.Name(), t.Var)
+			present = fmt.Sprintf("if ok, err := codecPresent(&%s, v, codecSibling%s); err != nil {\nreturn &oxygen.TagError{Name: cfg.Name, Tag: %s, Field: %q, Err: err}\n} else if ", t.Var, id, t.Value, sf.Name())
+			if g.defaultable(ft) {
+				f.def = t.Var
+			}
+			tagged = true
+			remaining.p("case %d: // %s", fields-1, sf.Name())
+			nested := g.nested(&remaining, ft, sf.Name())
+			remaining.p("if ok, err := codecPresent(&%s, v, codecSibling%s); (ok || err != nil) && !fn(%q, &%s, %s) {\nreturn false\n}", t.Var, id, sf.Name(), t.Var, nested)
+		} else {
+			remaining.p("case %d: // %s", fields-1, sf.Name())
+			nested := g.nested(&remaining, ft, sf.Name())
+			remaining.p("if !fn(%q, nil, %s) {\nreturn false\n}", sf.Name(), nested)
+		}
+		before = append(before, sf.Name())
+
+		enc.p("// %s", sf.Name())
+		x := f.path
+		if binders := slices[i]; len(binders) != 0 {
+			// The count field is written from the length of the first slice bound to it.
+			x = g.temp("c")
+			lengths[sf.Name()] = x
+			enc.p("%s := %s\nswitch %q {", x, f.path, sf.Name())
+			for _, b := range binders {
+				enc.p("case codec%s_%s.count:", id, b)
+				enc.p("n, err := codecLength[%s](len(v.%s))\nif err != nil {\n%s\n}\n%s = n", f.typ, b, f.marshalError("err"), x)
+			}
+			enc.p("}")
+		}
+		if names := counts[i]; hasTag && len(names) != 0 {
+			enc.p("switch codec%s_%s.count {", id, sf.Name())
+			for _, name := range names {
+				enc.p("case %q:", name)
+				enc.p("if len(%s) != int(%s) {\n%s\n}", f.path, lengths[name],
+					f.marshalError(fmt.Sprintf("fmt.Errorf(\"length %%d of the slice differs from the count %%d\", len(%s), int(%s))", f.path, lengths[name])))
+			}
+			enc.p("}")
+		}
+		if f.def != "" {
+			// The default value is written instead of the zero value.
+			if x == f.path {
+				x = g.temp("d")
+				enc.p("%s := %s", x, f.path)
+			}
+			def := fmt.Sprintf("*%s.def.(*%s)", f.def, f.typ)
+			if f.defPtr != "" {
+				def = fmt.Sprintf("%s.def.(*%s)", f.def, f.defPtr)
+			}
+			enc.p("if %s.encodeDef && %s {\n%s = %s\n}", f.def, g.isZero(x, ft), x, def)
+		}
+		switch empty := isEmpty(x, ft); {
+		case hasTag && empty != "":
+			enc.p("%sok && !(%s && %s) {", present, omit, empty)
+		case hasTag:
+			enc.p("%sok {", present)
+		default:
+			enc.p("{")
+		}
+		enc.p("if sep {\nenc.Write(codecValueSeparator)\n}\nsep = codecSeparate")
+		if tagErr != "" {
+			enc.p("%s", tagErr)
+		}
+		g.encValue(&enc, x, ft, f)
+		enc.p("}")
+
+		cases.p("case %d: // %s", fields-1, sf.Name())
+		if hasTag {
+			// An absent field is skipped with its separator.
+			cases.p("%s!ok {\ncontinue\n}", present)
+		}
+		cases.p("if sep {\nif err := dec.removePrefix(codecValueSeparator); err != nil {\n%s\n}\n}\nsep = codecRemoveSeparator", f.unmarshalError("err"))
+		if tagErr != "" {
+			cases.p("%s", tagErr)
+		}
+		if names := counts[i]; hasTag && len(names) != 0 {
+			// The number of elements of the slice is held by a count field declared before it.
+			cases.p("if codec%s_%s.count == \"\" {", id, sf.Name())
+			g.decValue(&cases, f.path, ft, f)
+			cases.p("} else {\noff := dec.offset()\nvar want uint64\nvar err error\nswitch codec%s_%s.count {", id, sf.Name())
+			for _, name := range names {
+				cases.p("case %q:\nwant, err = codecCount(v.%s)", name, name)
+			}
+			cases.p("}\nif err != nil {\n%s\n}", f.unmarshalError("dec.typeError(off, err)"))
+			g.decList(&cases, f.path, ft.Underlying().(*types.Slice).Elem(), false, "want", f)
+			cases.p("}")
+		} else {
+			g.decValue(&cases, f.path, ft, f)
+		}
+	}
+
+	w := &g.funcs
+	if tagged {
+		w.p("func codecSibling%s(v *%s, name string) any {\nswitch name {", id, expr)
+		for _, name := range before {
+			w.p("case %q:\nreturn v.%s", name, name)
+		}
+		w.p("}\nreturn nil\n}\n")
+	}
+	// codecRemaining calls fn for the present fields starting with the field from, like each of the engine does.
+	w.p("func codecRemaining%s(v *%s, from int, fn func(string, *codecTag, codecFields) bool) bool {", id, expr)
+	if fields != 0 {
+		w.p("for i := from; i < %d; i++ {\nswitch i {\n%s}\n}", fields, remaining.String())
+	}
+	w.p("return true\n}\n")
+	w.p("func (enc *codecEncoder) encode%s(v *%s, wrap bool) error {", id, expr)
+	if fields != 0 {
+		w.p("sep := false")
+	}
+	w.p("if wrap {\nenc.Write(codecStructOpener)\n}")
+	w.Write(enc.Bytes())
+	w.p("if wrap {\nenc.Write(codecStructCloser)\n}\nreturn nil\n}\n")
+
+	w.p("func (dec *codecDecoder) decode%s(v *%s, unwrap bool) error {", id, expr)
+	w.p("if unwrap {\nif err := dec.removePrefix(codecStructOpener); err != nil {\nreturn err\n}\n}")
+	if fields != 0 {
+		w.p("sep := false")
+		w.p("for i := 0; i < %d; i++ {", fields)
+		w.p("if len(dec.data) == 0 || unwrap && bytes.HasPrefix(dec.data, codecStructCloser) {")
+		w.p("remaining := func(fn func(string, *codecTag, codecFields) bool) bool {\nreturn codecRemaining%s(v, i, fn)\n}", id)
+		w.p("if codecStrict {\nif name := codecTruncated(remaining); name != \"\" {")
+		w.p("return &oxygen.SyntaxError{Name: cfg.Name, Field: name, Offset: dec.offset(), Err: oxygen.ErrTruncated}\n}\n}")
+		w.p("remaining(dec.required)\nbreak\n}")
+		w.p("switch i {\n%s}\n}", cases.String())
+	}
+	w.p("if unwrap {\nif codecStrict && len(dec.data) != 0 && !bytes.HasPrefix(dec.data, codecStructCloser) {")
+	w.p("return &oxygen.SyntaxError{Name: cfg.Name, Offset: dec.offset(), Err: oxygen.ErrTrailingData}\n}")
+	w.p("if i := bytes.Index(dec.data, codecStructCloser); i > 0 {\ndec.data = dec.data[i:]\n}")
+	w.p("if err := dec.removePrefix(codecStructCloser); err != nil {\nreturn err\n}\n}\nreturn nil\n}\n")
+
+	// A struct that isn't embedded is nested deeper and the hooks run around its fields.
+	w.p("func (enc *codecEncoder) encodeNested%s(v *%s, wrap bool) error {", id, expr)
+	if g.implements(named, beforeMarshalIface) {
+		w.p("c := *v\nv = &c\nif err := c.BeforeMarshal(); err != nil {")
+		w.p("return &oxygen.MarshalTypeError{Name: cfg.Name, Type: codecTypeOf[%s](), Err: fmt.Errorf(\"BeforeMarshal: %%w\", err)}\n}", expr)
+	}
+	w.p("if err := enc.enter(); err != nil {\nreturn err\n}\nerr := enc.encode%s(v, wrap)\nenc.leave()\nreturn err\n}\n", id)
+
+	w.p("func (dec *codecDecoder) decodeNested%s(v *%s, unwrap bool) error {", id, expr)
+	hooked := g.implements(named, afterUnmarshalIface) || g.implements(named, validateIface)
+	if hooked {
+		w.p("off := dec.offset()")
+	}
+	w.p("if err := dec.enter(); err != nil {\nreturn dec.typeError(dec.offset(), err)\n}\nerr := dec.decode%s(v, unwrap)\ndec.leave()", id)
+	if !hooked {
+		w.p("return err\n}\n")
+		return
+	}
+	w.p("if err != nil {\nreturn err\n}")
+	for _, hook := range []struct {
+		iface  int
+		method string
+	}{{afterUnmarshalIface, "AfterUnmarshal"}, {validateIface, "Validate"}} {
+		if g.implements(named, hook.iface) {
+			w.p("if err := v.%s(); err != nil {", hook.method)
+			w.p("return &oxygen.UnmarshalTypeError{Name: cfg.Name, Type: codecTypeOf[%s](), Offset: off, Err: fmt.Errorf(\"%s: %%w\", err)}\n}", expr, hook.method)
+		}
+	}
+	w.p("return nil\n}\n")
+}
+
+// nested returns the expression of the fields of the struct held by the field name of the type t,
+// nil unless the struct is decoded field by field.
+func (g *codecGen) nested(w *codecWriter, t types.Type, name string) string {
+	named, ok := t.(*types.Named)
+	if !ok || isDecimal(t) || g.implements(t, unmarshalerIface) {
+		return "nil"
+	}
+	if _, ok = named.Underlying().(*types.Struct); !ok {
+		return "nil"
+	}
+	fields := fmt.Sprintf("codecNested[%s](func(fn func(string, *codecTag, codecFields) bool) bool {\nreturn codecRemaining%s(&v.%s, 0, fn)\n})", g.typeExpr(t), g.require(named), name)
+	if !g.implements(t, textUnmarshalerIface) {
+		return fields
+	}
+	// A struct decoded by the UnmarshalText method has no fields of its own.
+	w.p("var nested codecFields\nif !codecTextMarshaler {\nnested = %s\n}", fields)
+	return "nested"
+}
+
+// encoderOf returns the name of the method encoding a non-embedded struct of the type named.
+func (g *codecGen) encoderOf(named *types.Named) string {
+	return "encodeNested" + g.require(named)
+}
+
+// decoderOf returns the name of the method decoding a non-embedded struct of the type named.
+func (g *codecGen) decoderOf(named *types.Named) string {
+	return "decodeNested" + g.require(named)
+}
+
+// countFields returns by the index of every field of the struct st that can be a tagged slice
+// the names of the integer fields declared before it that can hold its length,
+// and by the index of every such integer field the names of the slices declared after it.
+func (g *codecGen) countFields(st *types.Struct) (counts map[int][]string, slices map[int][]string) {
+	counts, slices = make(map[int][]string), make(map[int][]string)
+
+	ints := make(map[string]int)
+	names := []string{}
+	for i := 0; i < st.NumFields(); i++ {
+		sf := st.Field(i)
+		if sf.Embedded() || !sf.Exported() {
+			continue
+		}
+		tagValue, hasTag := reflect.StructTag(st.Tag(i)).Lookup(g.name)
+		if tagValue == "-" {
+			continue
+		}
+
+		switch u := sf.Type().Underlying().(type) {
+		case *types.Basic:
+			if u.Info()&types.IsInteger != 0 {
+				ints[sf.Name()] = i
+				names = append(names, sf.Name())
+			}
+		case *types.Slice:
+			if !hasTag || isBytes(u) || isNamedBytes(u) {
+				continue
+			}
+			counts[i] = append([]string{}, names...)
+			for _, name := range names {
+				slices[ints[name]] = append(slices[ints[name]], sf.Name())
+			}
+		}
+	}
+	return
+}
+
+// encValue generates the encoding of the value x of the type t the same way as the encoder of the engine does.
+func (g *codecGen) encValue(w *codecWriter, x string, t types.Type, f *codecField) {
+	// Functions registered by RegisterType take priority.
+	fn := g.temp("fn")
+	w.p("if %s, _ := codecRegistered[%s](); %s != nil {", fn, g.typeExpr(t), fn)
+	w.p("p, err := %s(%q, %s, %s)\nif err != nil {\n%s\n}", fn, f.name, f.tag, x, f.marshalError("err"))
+	g.encode(w, "p", f)
+	w.p("} else {")
+	g.encUnregistered(w, x, t, f)
+	w.p("}")
+}
+
+// encUnregistered generates the encoding of the value x of the type t without functions registered for t.
+func (g *codecGen) encUnregistered(w *codecWriter, x string, t types.Type, f *codecField) {
+	if ptr, ok := t.Underlying().(*types.Pointer); ok {
+		p := g.temp("p")
+		w.p("%s := %s\nif %s == nil {\n%s = new(%s)\n}", p, x, p, p, g.typeExpr(ptr.Elem()))
+		g.encValue(w, "(*"+p+")", ptr.Elem(), f)
+		return
+	}
+
+	if g.implements(t, marshallerIface) {
+		g.encMethod(w, x, t, "Marshal"+g.ucName, f)
+		return
+	}
+
+	if g.implements(t, textMarshalerIface) {
+		w.p("if codecTextMarshaler {")
+		g.encMethod(w, x, t, "MarshalText", f)
+		w.p("} else {")
+		g.encKind(w, x, t, f)
+		w.p("}")
+		return
+	}
+
+	g.encKind(w, x, t, f)
+}
+
+func (g *codecGen) encMethod(w *codecWriter, x string, t types.Type, method string, f *codecField) {
+	v := g.temp("v")
+	w.p("%s := %s\np, err := (&%s).%s()\nif err != nil {\n%s\n}", v, x, v, method, f.marshalError("err"))
+	g.encode(w, "p", f)
+}
+
+func (g *codecGen) encode(w *codecWriter, p string, f *codecField) {
+	w.p("if err := enc.value(%q, %s, %s, false); err != nil {\n%s\n}", f.name, f.tag, p, f.marshalError("err"))
+}
+
+// encodeNumber is like encode but packed decimals aren't transcoded to the charset.
+func (g *codecGen) encodeNumber(w *codecWriter, p string, f *codecField) {
+	w.p("if err := enc.value(%q, %s, %s, %s.Encoding == oxygen.PackedDecimal); err != nil {\n%s\n}", f.name, f.tag, p, f.format, f.marshalError("err"))
+}
+
+// encBinary generates writing of the primitive as a binary value if the field uses binary primitives.
+// The text of numbers is written with encodeNumber, after the check of the value if it's set.
+func (g *codecGen) encBinary(w *codecWriter, u string, size string, f *codecField, text, check string, number bool) {
+	w.p("if o := %s; o != nil {\nenc.Write(oxygen.AppendBinary(enc.scratch[:0], o, %s, %s))\n} else {", f.order, u, size)
+	if check != "" {
+		w.p("if err := %s; err != nil {\n%s\n}", check, f.marshalError("err"))
+	}
+	if number {
+		g.encodeNumber(w, text, f)
+	} else {
+		g.encode(w, text, f)
+	}
+	w.p("}")
+}
+
+func (g *codecGen) encKind(w *codecWriter, x string, t types.Type, f *codecField) {
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch info := u.Info(); {
+		case info&types.IsBoolean != 0:
+			g.encBinary(w, fmt.Sprintf("codecBinaryBool(bool(%s))", x), "1", f,
+				fmt.Sprintf("strconv.AppendBool(enc.scratch[:0], bool(%s))", x), "", false)
+		case info&types.IsInteger != 0 && info&types.IsUnsigned != 0:
+			g.encBinary(w, fmt.Sprintf("uint64(%s)", x), byteSize(u), f,
+				fmt.Sprintf("%s.AppendUint(enc.scratch[:0], uint64(%s))", f.format, x),
+				fmt.Sprintf("%s.CheckUint(uint64(%s))", f.format, x), true)
+		case info&types.IsInteger != 0:
+			g.encBinary(w, fmt.Sprintf("uint64(%s)", x), byteSize(u), f,
+				fmt.Sprintf("%s.AppendInt(enc.scratch[:0], int64(%s))", f.format, x),
+				fmt.Sprintf("%s.CheckInt(int64(%s))", f.format, x), true)
+		case info&types.IsFloat != 0:
+			g.encBinary(w, fmt.Sprintf("codecFloatBits(float64(%s), %s)", x, byteSize(u)), byteSize(u), f,
+				fmt.Sprintf("%s.AppendFloat(enc.scratch[:0], float64(%s), %s)", f.format, x, bitSize(u)),
+				fmt.Sprintf("%s.CheckFloat(float64(%s))", f.format, x), true)
+		case info&types.IsString != 0:
+			g.encode(w, fmt.Sprintf("append(enc.scratch[:0], string(%s)...)", x), f)
+		default:
+			g.unsupported(t, f)
+		}
+	case *types.Slice:
+		if isNamedBytes(u) {
+			g.unsupported(t, f)
+			return
+		}
+		if isBytes(u) {
+			g.encode(w, fmt.Sprintf("[]byte(%s)", x), f)
+			return
+		}
+		g.encList(w, x, u.Elem(), f)
+	case *types.Array:
+		g.encList(w, x, u.Elem(), f)
+	case *types.Struct:
+		if isDecimal(t) {
+			w.p("if err := %s.CheckDecimal(%s); err != nil {\n%s\n}", f.format, x, f.marshalError("err"))
+			g.encodeNumber(w, fmt.Sprintf("%s.AppendDecimal(enc.scratch[:0], %s)", f.format, x), f)
+			return
+		}
+		named, ok := t.(*types.Named)
+		if !ok {
+			g.unsupported(t, f)
+			return
+		}
+		w.p("if err := enc.%s(%s, codecWrap); err != nil {\n%s\n}", g.encoderOf(named), addr(x), f.marshalError("err"))
+	default:
+		g.unsupported(t, f)
+	}
+}
+
+func (g *codecGen) encList(w *codecWriter, x string, elem types.Type, f *codecField) {
+	i := g.temp("i")
+	w.p("if err := enc.enter(); err != nil {\n%s\n}", f.marshalError("err"))
+	w.p("if codecWrapList {\nenc.Write(codecListOpener)\n}")
+	w.p("for %s := range %s {", i, x)
+	w.p("if %s > 0 && codecSeparateElems {\nenc.Write(codecElementSeparator)\n}", i)
+	g.encValue(w, x+"["+i+"]", elem, f)
+	w.p("}")
+	w.p("if codecWrapList {\nenc.Write(codecListCloser)\n}\nenc.leave()")
+}
+
+// decValue generates the decoding of the value x of the type t the same way as the decoder of the engine does.
+func (g *codecGen) decValue(w *codecWriter, x string, t types.Type, f *codecField) {
+	// Functions registered by RegisterType take priority.
+	fn := g.temp("fn")
+	w.p("if _, %s := codecRegistered[%s](); %s != nil {", fn, g.typeExpr(t), fn)
+	g.decode(w, f, func(off string) {
+		w.p("r, err := %s(%q, %s, p)\nif err != nil {\n%s\n}\n%s = r", fn, f.name, f.tag, f.unmarshalError("dec.typeError("+off+", err)"), x)
+	})
+	w.p("} else {")
+	g.decUnregistered(w, x, t, f)
+	w.p("}")
+}
+
+// decUnregistered generates the decoding of the value x of the type t without functions registered for t.
+func (g *codecGen) decUnregistered(w *codecWriter, x string, t types.Type, f *codecField) {
+	if ptr, ok := t.Underlying().(*types.Pointer); ok {
+		// The default value of a pointer field is set when the value it points to is blank.
+		if f.defPtr == "" {
+			f = f.value()
+		}
+		p := g.temp("p")
+		w.p("{\n%s := %s\nif %s == nil {\n%s = new(%s)\n}", p, x, p, p, g.typeExpr(ptr.Elem()))
+		g.decValue(w, "(*"+p+")", ptr.Elem(), f)
+		if empty := isEmpty("(*"+p+")", ptr.Elem()); empty != "" {
+			w.p("if %s == nil && !(%s) {\n%s = %s\n}\n}", x, empty, x, p)
+		} else {
+			w.p("if %s == nil {\n%s = %s\n}\n}", x, x, p)
+		}
+		return
+	}
+
+	if g.implements(t, unmarshalerIface) {
+		g.decMethod(w, x, t, "Unmarshal"+g.ucName, f)
+		return
+	}
+
+	if g.implements(t, textUnmarshalerIface) {
+		w.p("if codecTextMarshaler {")
+		g.decMethod(w, x, t, "UnmarshalText", f)
+		w.p("} else {")
+		g.decKind(w, x, t, f)
+		w.p("}")
+		return
+	}
+
+	g.decKind(w, x, t, f)
+}
+
+// decode generates reading of the value of the field into p, the statements are executed if p isn't empty.
+func (g *codecGen) decode(w *codecWriter, f *codecField, then func(off string)) {
+	g.decodeRaw(w, f, "false", then)
+}
+
+// decodeNumber is like decode but packed decimals aren't transcoded from the charset.
+func (g *codecGen) decodeNumber(w *codecWriter, f *codecField, then func(off string)) {
+	g.decodeRaw(w, f, f.format+".Encoding == oxygen.PackedDecimal", then)
+}
+
+func (g *codecGen) decodeRaw(w *codecWriter, f *codecField, raw string, then func(off string)) {
+	off := g.temp("off")
+	w.p("{\n%s := dec.offset()", off)
+	w.p("p, err := dec.value(%q, %s, %s)\nif err != nil {\n%s\n}", f.name, f.tag, raw, f.unmarshalError("dec.typeError("+off+", err)"))
+	w.p("if len(p) != 0 {")
+	then(off)
+	switch {
+	case f.defPtr != "":
+		// The pointer field gets its own copy of the default value.
+		d := g.temp("d")
+		w.p("} else if %s.def != nil {\n%s := *%s.def.(*%s)\n%s = &%s", f.def, d, f.def, f.defPtr, f.path, d)
+	case f.def != "":
+		w.p("} else if %s.def != nil {\n%s = *%s.def.(*%s)", f.def, f.path, f.def, f.typ)
+	}
+	w.p("}\n}")
+}
+
+func (g *codecGen) decMethod(w *codecWriter, x string, t types.Type, method string, f *codecField) {
+	g.decode(w, f, func(off string) {
+		v := g.temp("v")
+		w.p("var %s %s\nif err = (&%s).%s(p); err != nil {\n%s\n}", v, g.typeExpr(t), v, method, f.unmarshalError("dec.typeError("+off+", err)"))
+		w.p("%s = %s", x, v)
+	})
+}
+
+func (g *codecGen) decKind(w *codecWriter, x string, t types.Type, f *codecField) {
+	// parse generates reading of a primitive, binary is the expression of the value of the binary u,
+	// it's empty for bools.
+	parse := func(binary, size, format string, args ...any) {
+		text := g.decodeNumber
+		if binary == "" {
+			text = g.decode
+		}
+		off := g.temp("off")
+		w.p("if o := %s; o != nil {\n%s := dec.offset()", f.order, off)
+		w.p("u, err := dec.binary(o, %s)\nif err != nil {\n%s\n}", size, f.unmarshalError("dec.typeError("+off+", err)"))
+		if binary == "" {
+			w.p("r, err := codecParseBinaryBool(u)\n%s = %s(r)\nif err != nil {\n%s\n}", x, g.typeExpr(t), f.unmarshalError("dec.typeError("+off+", err)"))
+		} else {
+			w.p("%s = %s(%s)", x, g.typeExpr(t), binary)
+		}
+		w.p("} else {")
+		text(w, f, func(off string) {
+			w.p("r, err := "+format, args...)
+			w.p("%s = %s(r)\nif err != nil {\n%s\n}", x, g.typeExpr(t), f.unmarshalError("dec.typeError("+off+", err)"))
+		})
+		w.p("}")
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch info := u.Info(); {
+		case info&types.IsBoolean != 0:
+			parse("", "1", "strconv.ParseBool(string(p))")
+		case info&types.IsInteger != 0 && info&types.IsUnsigned != 0:
+			parse("u", byteSize(u), "%s.ParseUint(string(p), %s)", f.format, bitSize(u))
+		case info&types.IsInteger != 0:
+			parse("codecSigned(u, "+byteSize(u)+")", byteSize(u), "%s.ParseInt(string(p), %s)", f.format, bitSize(u))
+		case info&types.IsFloat != 0:
+			parse("codecFloatFrom(u, "+byteSize(u)+")", byteSize(u), "%s.ParseFloat(string(p), %s)", f.format, bitSize(u))
+		case info&types.IsString != 0:
+			g.decode(w, f, func(string) { w.p("%s = %s(p)", x, g.typeExpr(t)) })
+		default:
+			g.unsupported(t, f)
+		}
+	case *types.Slice:
+		if isNamedBytes(u) {
+			g.unsupported(t, f)
+			return
+		}
+		if isBytes(u) {
+			g.decode(w, f, func(string) { w.p("%s = append(%s(nil), p...)", x, g.typeExpr(t)) })
+			return
+		}
+		g.decList(w, x, u.Elem(), false, "", f)
+	case *types.Array:
+		g.decList(w, x, u.Elem(), true, "", f)
+	case *types.Struct:
+		if isDecimal(t) {
+			g.decodeNumber(w, f, func(off string) {
+				w.p("r, err := %s.ParseDecimal(string(p))", f.format)
+				w.p("%s = r\nif err != nil {\n%s\n}", x, f.unmarshalError("dec.typeError("+off+", err)"))
+			})
+			return
+		}
+		named, ok := t.(*types.Named)
+		if !ok {
+			g.unsupported(t, f)
+			return
+		}
+		n := g.temp("n")
+		w.p("%s := len(dec.missing)", n)
+		w.p("if err := dec.%s(%s, codecRemoveWrapper); err != nil {\n%s\n}", g.decoderOf(named), addr(x), f.unmarshalError("err"))
+		w.p("dec.prefix(%s, %s)", n, f.label())
+	default:
+		g.unsupported(t, f)
+	}
+}
+
+// decList generates the decoding of the elements of the slice or the array x,
+// if want is set, exactly that number of elements of the slice is decoded.
+func (g *codecGen) decList(w *codecWriter, x string, elem types.Type, array bool, want string, f *codecField) {
+	n, z := g.temp("n"), g.temp("z")
+	label := f.label()
+	f = f.value()
+	removePrefix := func(b string) string {
+		return fmt.Sprintf("if err := dec.removePrefix(%s); err != nil {\n%s\n}", b, f.unmarshalError("err"))
+	}
+
+	w.p("{\nif err := dec.enter(); err != nil {\n%s\n}", f.unmarshalError("dec.typeError(dec.offset(), err)"))
+	w.p("if codecUnwrapList {\n%s\n}", removePrefix("codecListOpener"))
+	w.p("var %s %s\n%s := 0", z, g.typeExpr(elem), n)
+	// The paths of the missing fields of the elements start with the field of the list.
+	m := ""
+	if holdsStruct(elem) {
+		m = g.temp("m")
+		w.p("%s := len(dec.missing)", m)
+	}
+	switch {
+	case array:
+		w.p("for ; %s < len(%s); %s++ {", n, x, n)
+	case want != "":
+		limit := g.temp("limit")
+		w.p("%s := math.MaxInt32\nif %s < uint64(%s) {\n%s = int(%s)\n}\n%s = nil", limit, want, limit, limit, want, x)
+		w.p("for ; %s < %s; %s++ {", n, limit, n)
+	default:
+		w.p("for ; ; %s++ {", n)
+	}
+	left := g.temp("left")
+	w.p("if dec.endOf(codecUnwrapList, codecListCloser) {\nbreak\n}")
+	// An element that consumes no input ends the list, otherwise the same input would be decoded forever.
+	w.p("%s := len(dec.data)", left)
+	w.p("if %s > 0 && codecRemoveElemSep {\n%s\n}", n, removePrefix("codecElementSeparator"))
+	if array {
+		w.p("%s[%s] = %s", x, n, z)
+	} else {
+		w.p("if %s < len(%s) {\n%s[%s] = %s\n} else {\n%s = append(%s, %s)\n}", n, x, x, n, z, x, x, z)
+	}
+	e := *f
+	e.index = n
+	g.decValue(w, x+"["+n+"]", elem, &e)
+	w.p("if len(dec.data) == %s {\nbreak\n}\n}", left)
+	w.p("if codecUnwrapList {\n%s\n}\ndec.leave()", removePrefix("codecListCloser"))
+	if m != "" {
+		w.p("dec.prefix(%s, %s)", m, label)
+	}
+	switch {
+	case array:
+		w.p("for ; %s < len(%s); %s++ {\n%s[%s] = %s\n}\n}", n, x, n, x, n, z)
+	case want != "":
+		err := fmt.Sprintf("fmt.Errorf(\"found %%d elements out of %%d\", %s, %s)", n, want)
+		w.p("if %s != nil {\n%s = %s[:%s]\n}", x, x, x, n)
+		w.p("if uint64(%s) != %s {\n%s\n}\n}", n, want, f.unmarshalError("dec.typeError(dec.offset(), "+err+")"))
+	default:
+		w.p("if %s != nil {\n%s = %s[:%s]\n}\n}", x, x, x, n)
+	}
+}
+
+// holdsStruct reports whether the values of the type t are structs or lists of structs, pointed to or not.
+func holdsStruct(t types.Type) bool {
+	for {
+		switch u := t.Underlying().(type) {
+		case *types.Pointer:
+			t = u.Elem()
+		case *types.Slice:
+			t = u.Elem()
+		case *types.Array:
+			t = u.Elem()
+		case *types.Struct:
+			return !isDecimal(t)
+		default:
+			return false
+		}
+	}
+}
+
+func (g *codecGen) unsupported(t types.Type, f *codecField) {
+	g.errorf("%s.%s: type %s is not supported by the generated codec", f.owner, f.name, g.typeExpr(t))
+}
+
+// addr returns the expression of the address of x.
+func addr(x string) string {
+	if strings.HasPrefix(x, "(*") && strings.HasSuffix(x, ")") {
+		return x[2 : len(x)-1]
+	}
+	return "&" + x
+}
+
+func isBytes(t *types.Slice) bool {
+	b, ok := t.Elem().(*types.Basic)
+	return ok && b.Kind() == types.Byte
+}
+
+// isNamedBytes reports whether t is a slice of a named byte type that the engine encodes as bytes.
+func isNamedBytes(t *types.Slice) bool {
+	b, ok := t.Elem().Underlying().(*types.Basic)
+	return ok && b.Kind() == types.Byte && !isBytes(t)
+}
+
+// byteSize returns the expression of the size in bytes of the numeric type t written as a binary value.
+func byteSize(t *types.Basic) string {
+	if size := bitSize(t); size != "strconv.IntSize" {
+		n, _ := strconv.Atoi(size)
+		return strconv.Itoa(n / 8)
+	}
+	return "strconv.IntSize / 8"
+}
+
+// isDecimal reports whether t is oxygen.Decimal, which is written as a number.
+func isDecimal(t types.Type) bool {
+	named, ok := t.(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == oxygenPath && named.Obj().Name() == "Decimal"
+}
+
+// bitSize returns the expression of the size of the numeric type t the same way as the engine does.
+func bitSize(t *types.Basic) string {
+	switch t.Kind() {
+	case types.Int8, types.Uint8:
+		return "8"
+	case types.Int16, types.Uint16:
+		return "16"
+	case types.Int32, types.Uint32, types.Float32:
+		return "32"
+	case types.Int64, types.Uint64, types.Float64:
+		return "64"
+	default:
+		return "strconv.IntSize"
+	}
+}
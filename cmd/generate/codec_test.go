@@ -0,0 +1,98 @@
+package main
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func equal(t *testing.T, exp, got interface{}) {
+	t.Helper()
+	if !reflect.DeepEqual(exp, got) {
+		t.Fatalf("Not equal:\nexp: %v\ngot: %v", exp, got)
+	}
+}
+
+// chdir changes the working directory for the test.
+func chdir(t *testing.T, dir string) {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := os.Chdir(wd); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+// TestCodecSource checks test/codec.go is up to date, it's generated from the repository root with
+// go run ./cmd/generate -n=test -types=Order,Line,Reading,Payment,Batch,Address,Ticket,Timer,Node -src=test/records
+func TestCodecSource(t *testing.T) {
+	chdir(t, "../..")
+
+	want, err := os.ReadFile("test/codec.go")
+	equal(t, nil, err)
+
+	got, err := codecSource("test", "test/records", []string{"Order", "Line", "Reading", "Payment", "Batch", "Address", "Ticket", "Timer", "Node"})
+	equal(t, nil, err)
+	if string(got) != string(want) {
+		t.Fatal("test/codec.go differs from the generated codec, regenerate it")
+	}
+}
+
+func TestCodecSourceErrors(t *testing.T) {
+	chdir(t, "../..")
+
+	tests := []struct {
+		name  string
+		src   string
+		types []string
+		err   string
+	}{
+		{
+			name:  "undeclared",
+			src:   "test/records",
+			types: []string{"Order", "Missing"},
+			err:   "type Missing is not declared in package records",
+		},
+		{
+			name:  "not a struct",
+			src:   "test/records",
+			types: []string{"Code"},
+			err:   "type Code must be a struct type",
+		},
+		{
+			name:  "generic",
+			src:   "cmd/generate/testdata/unsupported",
+			types: []string{"Generic"},
+			err:   "type Generic must be a non-generic named struct type",
+		},
+		{
+			name:  "unsupported fields",
+			src:   "cmd/generate/testdata/unsupported",
+			types: []string{"Queue"},
+			err: "cannot generate codec:\n\tQueue.Items: type chan int is not supported by the generated codec" +
+				"\n\tQueue.Index: type map[string]int is not supported by the generated codec",
+		},
+		{
+			name: "no package",
+			src:  "cmd/generate/testdata/missing",
+			err:  "cannot resolve the import path of cmd/generate/testdata/missing: chdir cmd/generate/testdata/missing: no such file or directory",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := codecSource("test", tt.src, tt.types)
+			if err == nil {
+				t.Fatal("no error")
+			}
+			equal(t, tt.err, err.Error())
+		})
+	}
+}
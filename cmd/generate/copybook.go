@@ -0,0 +1,507 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// copybookEntry is a data description entry of a copybook.
+type copybookEntry struct {
+	line      int
+	level     int
+	name      string // empty for FILLER
+	picture   string
+	usage     string
+	occurs    int
+	dependsOn string
+	value     *string
+	redefines bool
+	justified bool
+}
+
+// copybookToken is a word or a literal of a copybook, end is set for the last token of an entry.
+type copybookToken struct {
+	text    string
+	literal bool
+	end     bool
+	line    int
+}
+
+// parseCopybook parses the data description entries of a COBOL copybook in the fixed reference format,
+// the code is read from columns 8 to 72. An 01 level starts a record, entries before the first 01 level belong
+// to a record named after the file. Level 88 entries and entries redefining an other one are skipped.
+func parseCopybook(src []byte, name string) (*schema, error) {
+	tokens, err := copybookTokens(src)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*copybookEntry
+	for len(tokens) != 0 {
+		n := 1
+		for !tokens[n-1].end {
+			if n == len(tokens) {
+				return nil, fmt.Errorf("line %d: the entry doesn't end with a period", tokens[0].line)
+			}
+			n++
+		}
+		e, err := parseEntry(tokens[:n])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", tokens[0].line, err)
+		}
+		if e != nil {
+			entries = append(entries, e)
+		}
+		tokens = tokens[n:]
+	}
+
+	return buildCopybook(entries, name)
+}
+
+// copybookTokens splits the code of the copybook into tokens, comment lines are skipped.
+func copybookTokens(src []byte) ([]copybookToken, error) {
+	var tokens []copybookToken
+
+	sc := bufio.NewScanner(bytes.NewReader(src))
+	for n := 1; sc.Scan(); n++ {
+		line := sc.Text()
+		if len(line) < 8 || line[6] == '*' || line[6] == '/' {
+			continue
+		}
+		if len(line) > 72 {
+			line = line[:72]
+		}
+		if line[6] == '-' {
+			return nil, fmt.Errorf("line %d: continuation lines are not supported", n)
+		}
+		code := line[7:]
+		if i := strings.Index(code, "*>"); i >= 0 {
+			code = code[:i]
+		}
+
+		for i := 0; i < len(code); {
+			if unicode.IsSpace(rune(code[i])) {
+				i++
+				continue
+			}
+
+			t := copybookToken{line: n}
+			if q := code[i]; q == '\'' || q == '"' {
+				// A quote is written twice in a literal.
+				var b strings.Builder
+				for i++; ; i++ {
+					if i == len(code) {
+						return nil, fmt.Errorf("line %d: the literal isn't closed", n)
+					}
+					if code[i] == q {
+						if i+1 < len(code) && code[i+1] == q {
+							i++
+						} else {
+							i++
+							break
+						}
+					}
+					b.WriteByte(code[i])
+				}
+				t.text, t.literal = b.String(), true
+				if i < len(code) && code[i] == '.' {
+					i++
+				}
+				t.end = code[i-1] == '.'
+			} else {
+				j := i
+				for j < len(code) && !unicode.IsSpace(rune(code[j])) {
+					j++
+				}
+				t.text = strings.TrimRight(code[i:j], ",;")
+				if t.end = strings.HasSuffix(t.text, "."); t.end {
+					t.text = strings.TrimSuffix(t.text, ".")
+				}
+				i = j
+			}
+			tokens = append(tokens, t)
+		}
+	}
+
+	return tokens, sc.Err()
+}
+
+// parseEntry parses the tokens of a data description entry, it returns nil for entries that are skipped.
+func parseEntry(tokens []copybookToken) (*copybookEntry, error) {
+	level, err := strconv.Atoi(tokens[0].text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid level number %s", tokens[0].text)
+	}
+	switch {
+	case level == 88:
+		return nil, nil
+	case level == 66 || level == 77:
+		return nil, fmt.Errorf("level %d is not supported", level)
+	case level < 1 || level > 49:
+		return nil, fmt.Errorf("invalid level number %s", tokens[0].text)
+	}
+
+	e := &copybookEntry{line: tokens[0].line, level: level}
+	words := tokens[1:]
+	if len(words) != 0 && !words[0].literal && !isClause(words[0].text) {
+		if name := strings.ToUpper(words[0].text); name != "FILLER" {
+			e.name = name
+		}
+		words = words[1:]
+	}
+
+	// nextToken returns the next word or literal, IS, ON and TIMES are skipped.
+	nextToken := func() (copybookToken, bool) {
+		for len(words) != 0 {
+			w := words[0]
+			words = words[1:]
+			switch u := strings.ToUpper(w.text); {
+			case w.literal:
+				return w, true
+			case u != "IS" && u != "ON" && u != "TIMES":
+				return w, true
+			}
+		}
+		return copybookToken{}, false
+	}
+	next := func() (string, bool) {
+		t, ok := nextToken()
+		return t.text, ok
+	}
+
+	for {
+		w, ok := next()
+		if !ok {
+			return e, nil
+		}
+
+		switch u := strings.ToUpper(w); u {
+		case "PIC", "PICTURE":
+			if e.picture, ok = next(); !ok {
+				return nil, fmt.Errorf("%s has no character string", u)
+			}
+			e.picture = strings.ToUpper(e.picture)
+		case "USAGE":
+			if w, ok = next(); !ok || !isUsage(strings.ToUpper(w)) {
+				return nil, fmt.Errorf("invalid usage %s", w)
+			}
+			e.usage = usageOf(strings.ToUpper(w))
+		case "OCCURS":
+			if w, ok = next(); ok {
+				e.occurs, err = strconv.Atoi(w)
+			}
+			if !ok || err != nil {
+				return nil, fmt.Errorf("invalid number of occurrences %s", w)
+			}
+		case "TO":
+			if w, ok = next(); ok {
+				e.occurs, err = strconv.Atoi(w)
+			}
+			if !ok || err != nil {
+				return nil, fmt.Errorf("invalid maximum number of occurrences %s", w)
+			}
+		case "DEPENDING":
+			if e.dependsOn, ok = next(); !ok {
+				return nil, fmt.Errorf("DEPENDING has no data name")
+			}
+			e.dependsOn = strings.ToUpper(e.dependsOn)
+		case "ASCENDING", "DESCENDING", "KEY", "INDEXED", "BY":
+			// The keys and the indexes of a table don't change its layout, their names are skipped.
+			for len(words) != 0 && !words[0].literal && !isClause(words[0].text) {
+				words = words[1:]
+			}
+		case "VALUE", "VALUES":
+			t, ok := nextToken()
+			if !ok {
+				return nil, fmt.Errorf("VALUE has no literal")
+			}
+			w = t.text
+			if !t.literal {
+				// ZERO and SPACE are the only figurative constants a default value can hold.
+				switch strings.ToUpper(w) {
+				case "ZERO", "ZEROS", "ZEROES":
+					w = "0"
+				case "SPACE", "SPACES":
+					continue
+				default:
+					if !isNumeric(w) {
+						return nil, fmt.Errorf("unsupported VALUE %s", w)
+					}
+				}
+			}
+			e.value = &w
+		case "REDEFINES":
+			e.redefines = true
+			next()
+		case "JUST", "JUSTIFIED":
+			e.justified = true
+			if len(words) != 0 && strings.ToUpper(words[0].text) == "RIGHT" {
+				words = words[1:]
+			}
+		case "BLANK":
+			// BLANK WHEN ZERO only changes how a number is edited.
+			for len(words) != 0 && strings.ToUpper(words[0].text) != "ZERO" {
+				words = words[1:]
+			}
+			next()
+		case "GLOBAL", "EXTERNAL":
+		default:
+			if !isUsage(u) {
+				return nil, fmt.Errorf("unsupported clause %s", w)
+			}
+			e.usage = usageOf(u)
+		}
+	}
+}
+
+// isNumeric reports whether the word w is a numeric literal, digits with an optional sign and decimal point.
+func isNumeric(w string) bool {
+	if strings.HasPrefix(w, "+") || strings.HasPrefix(w, "-") {
+		w = w[1:]
+	}
+	if i := strings.IndexByte(w, '.'); i >= 0 {
+		w = w[:i] + w[i+1:]
+	}
+	if w == "" {
+		return false
+	}
+	for _, r := range w {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// isClause reports whether the word w starts a clause of a data description entry.
+func isClause(w string) bool {
+	switch u := strings.ToUpper(w); u {
+	case "PIC", "PICTURE", "USAGE", "OCCURS", "TO", "DEPENDING", "ASCENDING", "DESCENDING", "INDEXED",
+		"VALUE", "VALUES", "REDEFINES", "JUST", "JUSTIFIED", "BLANK", "GLOBAL", "EXTERNAL", "SIGN", "SYNC",
+		"SYNCHRONIZED":
+		return true
+	default:
+		return isUsage(u)
+	}
+}
+
+// isUsage reports whether the word u is a usage.
+func isUsage(u string) bool {
+	return usageOf(u) != ""
+}
+
+// usageOf returns the usage named u: DISPLAY, COMP, COMP-1, COMP-2 or COMP-3, empty if u isn't a usage.
+func usageOf(u string) string {
+	switch u {
+	case "DISPLAY":
+		return "DISPLAY"
+	case "COMP", "COMP-4", "COMP-5", "COMPUTATIONAL", "COMPUTATIONAL-4", "COMPUTATIONAL-5", "BINARY":
+		return "COMP"
+	case "COMP-1", "COMPUTATIONAL-1":
+		return "COMP-1"
+	case "COMP-2", "COMPUTATIONAL-2":
+		return "COMP-2"
+	case "COMP-3", "COMPUTATIONAL-3", "PACKED-DECIMAL":
+		return "COMP-3"
+	}
+	return ""
+}
+
+// buildCopybook builds the records of the entries, name is the name of the record of entries before an 01 level.
+func buildCopybook(entries []*copybookEntry, name string) (*schema, error) {
+	type group struct {
+		level   int
+		field   *schemaField
+		usage   string
+		fillers int
+	}
+
+	s := new(schema)
+	var stack []*group
+	skip := 0 // the level of a skipped entry, its subordinate entries are skipped too
+
+	for i, e := range entries {
+		if skip != 0 && e.level > skip {
+			continue
+		}
+		skip = 0
+		if e.redefines {
+			skip = e.level
+			continue
+		}
+
+		// An 01 level starts a record, it also ends the record of the entries before the first 01 level.
+		if e.level == 1 {
+			stack = stack[:0]
+		}
+		for len(stack) != 0 && stack[len(stack)-1].level >= e.level {
+			stack = stack[:len(stack)-1]
+		}
+
+		f := &schemaField{cobol: e.name, Name: goName(e.name)}
+		if len(stack) == 0 {
+			if e.level != 1 {
+				// The entries before an 01 level belong to a record named after the file.
+				r := &schemaField{Name: goName(name)}
+				s.Records = append(s.Records, r)
+				stack = append(stack, &group{field: r})
+			} else {
+				s.Records = append(s.Records, f)
+				stack = append(stack, &group{level: e.level, field: f, usage: e.usage})
+				continue
+			}
+		}
+
+		parent := stack[len(stack)-1]
+		if e.name == "" {
+			parent.fillers++
+			f.Name = fmt.Sprintf("Filler%d", parent.fillers)
+		}
+		if e.usage == "" {
+			e.usage = parent.usage
+		}
+		f.Occurs = e.occurs
+		if e.dependsOn != "" {
+			f.DependsOn = goName(e.dependsOn)
+		}
+		parent.field.Fields = append(parent.field.Fields, f)
+
+		if i+1 < len(entries) && entries[i+1].level > e.level {
+			if e.picture != "" {
+				return nil, fmt.Errorf("line %d: group %s has a PICTURE", e.line, e.name)
+			}
+			stack = append(stack, &group{level: e.level, field: f, usage: e.usage})
+			continue
+		}
+
+		if err := elementary(f, e); err != nil {
+			return nil, fmt.Errorf("line %d: %w", e.line, err)
+		}
+	}
+
+	return s, nil
+}
+
+// elementary sets the type, the length and the format of the field f of the elementary entry e.
+func elementary(f *schemaField, e *copybookEntry) error {
+	f.Default = e.value
+
+	// Mainframes write COMP-1 and COMP-2 items as hexadecimal floats, reading them as IEEE floats would be wrong.
+	if e.usage == "COMP-1" || e.usage == "COMP-2" {
+		return fmt.Errorf("%s of %s is a hexadecimal float, it isn't supported", e.usage, f.Name)
+	}
+
+	if e.picture == "" {
+		return fmt.Errorf("%s has no PICTURE", f.Name)
+	}
+	pic, err := expandPicture(e.picture)
+	if err != nil {
+		return err
+	}
+
+	signed := strings.HasPrefix(pic, "S")
+	digits, decimals, isNumber := strings.TrimPrefix(pic, "S"), "", true
+	if i := strings.IndexByte(digits, 'V'); i >= 0 {
+		digits, decimals = digits[:i], digits[i+1:]
+	}
+	for _, r := range digits + decimals {
+		isNumber = isNumber && r == '9'
+	}
+	if !isNumber || digits+decimals == "" {
+		if e.usage != "" && e.usage != "DISPLAY" {
+			return fmt.Errorf("%s of %s must be numeric", e.usage, f.Name)
+		}
+		// Alphanumeric and edited data are strings of the size of the picture.
+		f.Type, f.Length = "string", len(pic)
+		if e.justified || strings.ContainsAny(pic, "Z*+-.,$") {
+			f.Align = "r"
+		}
+		return nil
+	}
+
+	n, scale := len(digits)+len(decimals), len(decimals)
+	f.Type = integerType(n, signed)
+	if scale != 0 || n > 18 {
+		f.Type = "decimal"
+	}
+
+	switch e.usage {
+	case "COMP":
+		if f.Type == "decimal" {
+			return fmt.Errorf("binary %s must be an integer of at most 18 digits", f.Name)
+		}
+		f.Format = "be"
+	case "COMP-3":
+		f.Length, f.Format = n/2+1, fmt.Sprintf("p%d", n)
+	default:
+		f.Length = n
+		switch {
+		case signed:
+			f.Format = fmt.Sprintf("z%d", n)
+		case scale != 0:
+			f.Format = fmt.Sprintf("i%d", scale)
+		}
+	}
+	if scale != 0 && (e.usage == "COMP-3" || signed) {
+		f.Format += "." + strconv.Itoa(scale)
+	}
+	return nil
+}
+
+// expandPicture returns the character string p with the repetitions expanded, X(3) is returned as XXX.
+func expandPicture(p string) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(p); i++ {
+		c := p[i]
+		if c == '(' {
+			j := strings.IndexByte(p[i:], ')')
+			if j < 0 || b.Len() == 0 {
+				return "", fmt.Errorf("invalid PICTURE %s", p)
+			}
+			n, err := strconv.Atoi(p[i+1 : i+j])
+			if err != nil || n < 1 {
+				return "", fmt.Errorf("invalid PICTURE %s", p)
+			}
+			last := b.String()[b.Len()-1:]
+			b.WriteString(strings.Repeat(last, n-1))
+			i += j
+			continue
+		}
+		if c == 'P' {
+			return "", fmt.Errorf("PICTURE %s: the scaling position P is not supported", p)
+		}
+		b.WriteByte(c)
+	}
+	return b.String(), nil
+}
+
+// integerType returns the Go integer type holding n decimal digits, the size COBOL gives a binary item of n digits.
+func integerType(n int, signed bool) string {
+	t := "int64"
+	switch {
+	case n <= 4:
+		t = "int16"
+	case n <= 9:
+		t = "int32"
+	}
+	if !signed {
+		t = "u" + t
+	}
+	return t
+}
+
+// goName returns the exported Go name of the COBOL name, ORDER-ID is named OrderId.
+func goName(name string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool { return r == '-' || r == '_' }) {
+		b.WriteString(strings.ToUpper(part[:1]) + strings.ToLower(part[1:]))
+	}
+	if s := b.String(); s == "" || !unicode.IsLetter(rune(s[0])) {
+		return "F" + s
+	}
+	return b.String()
+}
@@ -0,0 +1,326 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// copybook returns the lines in the fixed reference format, the code starting in column 8.
+func copybook(lines ...string) []byte {
+	var b strings.Builder
+	for _, l := range lines {
+		b.WriteString("       " + l + "\n")
+	}
+	return []byte(b.String())
+}
+
+func TestCopybookTokens(t *testing.T) {
+	tests := []struct {
+		name   string
+		src    string
+		tokens []copybookToken
+		err    string
+	}{
+		{
+			name: "words",
+			src:  "000100 01  ORDER-REC.\n000200     05 ID PIC 9(4), COMP.",
+			tokens: []copybookToken{
+				{text: "01", line: 1}, {text: "ORDER-REC", end: true, line: 1},
+				{text: "05", line: 2}, {text: "ID", line: 2}, {text: "PIC", line: 2}, {text: "9(4)", line: 2}, {text: "COMP", end: true, line: 2},
+			},
+		},
+		{
+			name: "comments",
+			src:  "000100* 01 SKIPPED.\n000200/\n000300 01 A. *> the record\n",
+			tokens: []copybookToken{
+				{text: "01", line: 3}, {text: "A", end: true, line: 3},
+			},
+		},
+		{
+			name: "literals",
+			src:  `       05 A VALUE 'it''s'.` + "\n" + `       05 B VALUE "a.b" .`,
+			tokens: []copybookToken{
+				{text: "05", line: 1}, {text: "A", line: 1}, {text: "VALUE", line: 1}, {text: "it's", literal: true, end: true, line: 1},
+				{text: "05", line: 2}, {text: "B", line: 2}, {text: "VALUE", line: 2}, {text: "a.b", literal: true, line: 2}, {text: "", end: true, line: 2},
+			},
+		},
+		{
+			name: "identification area",
+			src:  "       01 A." + strings.Repeat(" ", 60) + "IGNORED.",
+			tokens: []copybookToken{
+				{text: "01", line: 1}, {text: "A", end: true, line: 1},
+			},
+		},
+		{
+			name: "continuation",
+			src:  "       05 A VALUE 'AB\n      -    'CD'.",
+			err:  "line 1: the literal isn't closed",
+		},
+		{
+			name: "continuation line",
+			src:  "       05 A PIC X.\n      -    'CD'.",
+			err:  "line 2: continuation lines are not supported",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, err := copybookTokens([]byte(tt.src))
+			if tt.err != "" {
+				equal(t, tt.err, fmt.Sprint(err))
+				return
+			}
+			equal(t, nil, err)
+			equal(t, tt.tokens, tokens)
+		})
+	}
+}
+
+func TestParseEntry(t *testing.T) {
+	value := func(v string) *string { return &v }
+
+	tests := []struct {
+		entry string
+		want  *copybookEntry
+		err   string
+	}{
+		{
+			entry: "05 BALANCE PIC S9(5)V99 USAGE IS COMP-3.",
+			want:  &copybookEntry{line: 1, level: 5, name: "BALANCE", picture: "S9(5)V99", usage: "COMP-3"},
+		},
+		{
+			entry: "05 SEQ PIC 9(4) BINARY.",
+			want:  &copybookEntry{line: 1, level: 5, name: "SEQ", picture: "9(4)", usage: "COMP"},
+		},
+		{
+			entry: "05 FILLER PIC x(2).",
+			want:  &copybookEntry{line: 1, level: 5, picture: "X(2)"},
+		},
+		{
+			entry: "05 PIC X.",
+			want:  &copybookEntry{line: 1, level: 5, picture: "X"},
+		},
+		{
+			entry: "05 PARCELS OCCURS 1 TO 5 TIMES DEPENDING ON PARCEL-COUNT INDEXED BY I J.",
+			want:  &copybookEntry{line: 1, level: 5, name: "PARCELS", occurs: 5, dependsOn: "PARCEL-COUNT"},
+		},
+		{
+			entry: "05 CODES PIC X(2) OCCURS 3 TIMES ASCENDING KEY IS CODE.",
+			want:  &copybookEntry{line: 1, level: 5, name: "CODES", picture: "X(2)", occurs: 3},
+		},
+		{
+			entry: "05 CODE REDEFINES STATUS PIC 9.",
+			want:  &copybookEntry{line: 1, level: 5, name: "CODE", picture: "9", redefines: true},
+		},
+		{
+			entry: "05 NAME PIC X(4) JUSTIFIED RIGHT.",
+			want:  &copybookEntry{line: 1, level: 5, name: "NAME", picture: "X(4)", justified: true},
+		},
+		{
+			entry: "05 COUNT PIC 9(3) BLANK WHEN ZERO VALUE ZEROES.",
+			want:  &copybookEntry{line: 1, level: 5, name: "COUNT", picture: "9(3)", value: value("0")},
+		},
+		{
+			entry: "05 DELTA PIC S9(3) VALUE -1.5.",
+			want:  &copybookEntry{line: 1, level: 5, name: "DELTA", picture: "S9(3)", value: value("-1.5")},
+		},
+		{
+			entry: "05 NOTE PIC X(4) VALUE SPACES.",
+			want:  &copybookEntry{line: 1, level: 5, name: "NOTE", picture: "X(4)"},
+		},
+		{
+			entry: "05 WORD PIC X(4) VALUE 'ZERO'.",
+			want:  &copybookEntry{line: 1, level: 5, name: "WORD", picture: "X(4)", value: value("ZERO")},
+		},
+		{entry: "88 DELIVERED VALUE 'D'."},
+		{entry: "05 TOP PIC X VALUE HIGH-VALUES.", err: "unsupported VALUE HIGH-VALUES"},
+		{entry: "05 LOW PIC X VALUE LOW-VALUE.", err: "unsupported VALUE LOW-VALUE"},
+		{entry: "05 QUOTE PIC X VALUE QUOTES.", err: "unsupported VALUE QUOTES"},
+		{entry: "05 STARS PIC X(3) VALUE ALL '*'.", err: "unsupported VALUE ALL"},
+		{entry: "05 PTR USAGE POINTER VALUE NULL.", err: "invalid usage POINTER"},
+		{entry: "05 NOTHING PIC X VALUE NULL.", err: "unsupported VALUE NULL"},
+		{entry: "05 EMPTY PIC X VALUE.", err: "VALUE has no literal"},
+		{entry: "77 ALONE PIC X.", err: "level 77 is not supported"},
+		{entry: "50 DEEP PIC X.", err: "invalid level number 50"},
+		{entry: "A PIC X.", err: "invalid level number A"},
+		{entry: "05 MANY OCCURS N TIMES.", err: "invalid number of occurrences N"},
+		{entry: "05 SIGNED PIC S9 SIGN LEADING.", err: "unsupported clause SIGN"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.entry, func(t *testing.T) {
+			tokens, err := copybookTokens(copybook(tt.entry))
+			equal(t, nil, err)
+
+			e, err := parseEntry(tokens)
+			if tt.err != "" {
+				equal(t, tt.err, fmt.Sprint(err))
+				return
+			}
+			equal(t, nil, err)
+			equal(t, tt.want, e)
+		})
+	}
+}
+
+// fieldList lists the fields of the records with their path, type, length, format, occurrences and count field.
+func fieldList(fs []*schemaField, path string) []string {
+	var list []string
+	for _, f := range fs {
+		p := path + f.Name
+		if len(f.Fields) != 0 {
+			list = append(list, fmt.Sprintf("%s occurs=%d %s", p, f.Occurs, f.DependsOn))
+			list = append(list, fieldList(f.Fields, p+".")...)
+			continue
+		}
+		if path == "" {
+			list = append(list, p)
+			continue
+		}
+		list = append(list, fmt.Sprintf("%s %s %d %s occurs=%d", p, f.Type, f.Length, f.Format, f.Occurs))
+	}
+	return list
+}
+
+func TestBuildCopybook(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []*copybookEntry
+		fields  []string
+		err     string
+	}{
+		{
+			name: "levels before 01",
+			entries: []*copybookEntry{
+				{level: 5, name: "LOOSE", picture: "X"},
+				{level: 1, name: "RECORD"},
+				{level: 5, name: "ID", picture: "9(4)"},
+			},
+			fields: []string{
+				"Shipment occurs=0 ", "Shipment.Loose string 1  occurs=0",
+				"Record occurs=0 ", "Record.Id uint16 4  occurs=0",
+			},
+		},
+		{
+			name: "records",
+			entries: []*copybookEntry{
+				{level: 1, name: "HEADER"},
+				{level: 5, name: "KIND", picture: "X"},
+				{level: 1, name: "TRAILER", usage: "COMP"},
+				{level: 5, name: "TOTAL", picture: "S9(9)"},
+				{level: 5, picture: "X(2)", usage: "DISPLAY"},
+				{level: 5, picture: "X(3)", usage: "DISPLAY"},
+			},
+			fields: []string{
+				"Header occurs=0 ", "Header.Kind string 1  occurs=0",
+				"Trailer occurs=0 ", "Trailer.Total int32 0 be occurs=0",
+				"Trailer.Filler1 string 2  occurs=0", "Trailer.Filler2 string 3  occurs=0",
+			},
+		},
+		{
+			name: "redefines",
+			entries: []*copybookEntry{
+				{level: 1, name: "RECORD"},
+				{level: 5, name: "DATE", picture: "X(8)"},
+				{level: 5, name: "PARTS", redefines: true},
+				{level: 10, name: "YEAR", picture: "9(4)"},
+				{level: 10, name: "REST", picture: "X(4)"},
+				{level: 5, name: "STATUS", picture: "X"},
+			},
+			fields: []string{"Record occurs=0 ", "Record.Date string 8  occurs=0", "Record.Status string 1  occurs=0"},
+		},
+		{
+			name: "occurs depending",
+			entries: []*copybookEntry{
+				{level: 1, name: "RECORD"},
+				{level: 5, name: "PARCEL-COUNT", picture: "9(2)"},
+				{level: 5, name: "PARCELS", occurs: 5, dependsOn: "PARCEL-COUNT"},
+				{level: 10, name: "PARCEL-KG", picture: "9(3)", usage: "COMP-3"},
+				{level: 5, name: "CODES", picture: "X(2)", occurs: 3},
+			},
+			fields: []string{
+				"Record occurs=0 ", "Record.ParcelCount uint16 2  occurs=0",
+				"Record.Parcels occurs=5 ParcelCount", "Record.Parcels.ParcelKg uint16 2 p3 occurs=0",
+				"Record.Codes string 2  occurs=3",
+			},
+		},
+		{
+			name: "group with a picture",
+			entries: []*copybookEntry{
+				{line: 2, level: 1, name: "RECORD"},
+				{line: 3, level: 5, name: "GROUP", picture: "X"},
+				{line: 4, level: 10, name: "ITEM", picture: "X"},
+			},
+			err: "line 3: group GROUP has a PICTURE",
+		},
+		{
+			name: "invalid item",
+			entries: []*copybookEntry{
+				{line: 2, level: 1, name: "RECORD"},
+				{line: 3, level: 5, name: "ITEM"},
+			},
+			err: "line 3: Item has no PICTURE",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := buildCopybook(tt.entries, "SHIPMENT")
+			if tt.err != "" {
+				equal(t, tt.err, fmt.Sprint(err))
+				return
+			}
+			equal(t, nil, err)
+			equal(t, tt.fields, fieldList(s.Records, ""))
+		})
+	}
+}
+
+func TestElementary(t *testing.T) {
+	tests := []struct {
+		picture, usage string
+		justified      bool
+		want           schemaField
+		err            string
+	}{
+		{picture: "X(5)", want: schemaField{Type: "string", Length: 5}},
+		{picture: "X(4)", justified: true, want: schemaField{Type: "string", Length: 4, Align: "r"}},
+		{picture: "ZZ9.99", want: schemaField{Type: "string", Length: 6, Align: "r"}},
+		{picture: "9(4)", want: schemaField{Type: "uint16", Length: 4}},
+		{picture: "9(4)V99", want: schemaField{Type: "decimal", Length: 6, Format: "i2"}},
+		{picture: "S9(3)", want: schemaField{Type: "int16", Length: 3, Format: "z3"}},
+		{picture: "S9(3)V9", want: schemaField{Type: "decimal", Length: 4, Format: "z4.1"}},
+		{picture: "9(19)", want: schemaField{Type: "decimal", Length: 19}},
+		{picture: "9(4)", usage: "COMP", want: schemaField{Type: "uint16", Format: "be"}},
+		{picture: "S9(5)", usage: "COMP", want: schemaField{Type: "int32", Format: "be"}},
+		{picture: "S9(9)", usage: "COMP", want: schemaField{Type: "int32", Format: "be"}},
+		{picture: "9(10)", usage: "COMP", want: schemaField{Type: "uint64", Format: "be"}},
+		{picture: "S9(18)", usage: "COMP", want: schemaField{Type: "int64", Format: "be"}},
+		{picture: "S9(5)V99", usage: "COMP-3", want: schemaField{Type: "decimal", Length: 4, Format: "p7.2"}},
+		{picture: "9(4)", usage: "COMP-3", want: schemaField{Type: "uint16", Length: 3, Format: "p4"}},
+		{picture: "9(19)", usage: "COMP", err: "binary Item must be an integer of at most 18 digits"},
+		{picture: "9V9", usage: "COMP", err: "binary Item must be an integer of at most 18 digits"},
+		{usage: "COMP-1", err: "COMP-1 of Item is a hexadecimal float, it isn't supported"},
+		{usage: "COMP-2", err: "COMP-2 of Item is a hexadecimal float, it isn't supported"},
+		{picture: "X", usage: "COMP-3", err: "COMP-3 of Item must be numeric"},
+		{picture: "9(3)P", err: "PICTURE 9(3)P: the scaling position P is not supported"},
+		{picture: "X(0)", err: "invalid PICTURE X(0)"},
+		{picture: "(2)", err: "invalid PICTURE (2)"},
+		{err: "Item has no PICTURE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.picture+" "+tt.usage, func(t *testing.T) {
+			f := &schemaField{Name: "Item"}
+			err := elementary(f, &copybookEntry{picture: tt.picture, usage: tt.usage, justified: tt.justified})
+			if tt.err != "" {
+				equal(t, tt.err, fmt.Sprint(err))
+				return
+			}
+			equal(t, nil, err)
+			tt.want.Name = "Item"
+			equal(t, &tt.want, f)
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/types"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/gromey/oxygen"
+)
+
+const layoutTemplate = "template/layout.tmpl"
+
+type layoutData struct {
+	Formatter string // import path of the package of the formatter
+	Source    string // import path of the package declaring the type, empty if it's the package of the formatter
+	Type      string
+}
+
+// column is a field of the layout as the program generated from layout.tmpl writes it.
+type column struct {
+	Field         string
+	Offset, Width int
+	Align         oxygen.Alignment
+	Filler        byte
+}
+
+// layout runs the layout subcommand with the arguments args.
+func layout(args []string) error {
+	var name, typeName, src, format string
+
+	fs := flag.NewFlagSet("layout", flag.ExitOnError)
+	fs.StringVar(&name, "n", "example", "the name of your tag, the layout is computed by its Describe function")
+	fs.StringVar(&typeName, "type", "", "the struct type to print the layout of")
+	fs.StringVar(&src, "src", "", "the directory of the package declaring the type, the package of your tag by default")
+	fs.StringVar(&format, "format", "text", "the format of the layout: text, markdown or csv")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if typeName == "" {
+		return fmt.Errorf("the type is not set")
+	}
+	if format != "text" && format != "markdown" && format != "csv" {
+		return fmt.Errorf("format has an invalid value: %s must be text, markdown or csv", format)
+	}
+
+	columns, err := describe(strings.ToLower(name), src, typeName)
+	if err != nil {
+		return err
+	}
+
+	return printLayout(os.Stdout, columns, format)
+}
+
+// describe returns the columns of the layout of the struct type typeName declared in the package in the src directory,
+// computed by the formatter in the directory dir.
+func describe(dir, src, typeName string) ([]column, error) {
+	if src == "" {
+		src = dir
+	}
+
+	sameDir, err := samePath(dir, src)
+	if err != nil {
+		return nil, err
+	}
+
+	formatter, err := importPath(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	result := layoutData{Formatter: formatter, Type: typeName}
+	path := formatter
+	if !sameDir {
+		if path, err = importPath(src); err != nil {
+			return nil, err
+		}
+		result.Source = path
+	}
+
+	// The type is checked before the program is run to report a missing type clearly.
+	pkg, err := loadPackage(src, path, sameDir)
+	if err != nil {
+		return nil, err
+	}
+	obj, ok := pkg.Scope().Lookup(typeName).(*types.TypeName)
+	if !ok || !obj.Exported() {
+		return nil, fmt.Errorf("exported type %s is not declared in package %s", typeName, pkg.Name())
+	}
+	if named, ok := obj.Type().(*types.Named); !ok || named.TypeParams().Len() != 0 {
+		return nil, fmt.Errorf("type %s must be a non-generic named struct type", typeName)
+	} else if _, ok = named.Underlying().(*types.Struct); !ok {
+		return nil, fmt.Errorf("type %s must be a struct type", typeName)
+	}
+
+	temp, err := template.ParseFS(content, layoutTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	tmp, err := os.MkdirTemp("", "oxygen-layout")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = os.RemoveAll(tmp) }()
+
+	var buf bytes.Buffer
+	if err = temp.Execute(&buf, result); err != nil {
+		return nil, err
+	}
+	file := filepath.Join(tmp, "main.go")
+	if err = os.WriteFile(file, buf.Bytes(), 0660); err != nil {
+		return nil, err
+	}
+
+	// The program is run in the directory of the formatter to build it with the module of the formatter.
+	var stderr bytes.Buffer
+	cmd := exec.Command("go", "run", file)
+	cmd.Dir, cmd.Stderr = dir, &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("cannot describe type %s: %w\n%s", typeName, err, stderr.String())
+	}
+
+	var columns []column
+	if err = json.Unmarshal(out, &columns); err != nil {
+		return nil, err
+	}
+	return columns, nil
+}
+
+// printLayout writes the columns to w in the format.
+func printLayout(w io.Writer, columns []column, format string) error {
+	header := []string{"Field", "Offset", "Length", "Alignment", "Filler"}
+
+	// quote quotes the filler unless the format is csv.
+	quote := strconv.QuoteRune
+	if format == "csv" {
+		quote = func(r rune) string { return string(r) }
+	}
+
+	rows := make([][]string, 0, len(columns))
+	for _, c := range columns {
+		row := []string{c.Field, size(c.Offset), size(c.Width), "", ""}
+		switch c.Align {
+		case oxygen.LeftAlignment:
+			row[3], row[4] = "left", quote(rune(c.Filler))
+		case oxygen.RightAlignment:
+			row[3], row[4] = "right", quote(rune(c.Filler))
+		}
+		rows = append(rows, row)
+	}
+
+	switch format {
+	case "text":
+		var buf bytes.Buffer
+		tw := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+		for _, row := range append([][]string{header}, rows...) {
+			_, _ = fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+		// Empty cells at the end of a row are padded, the lines are trimmed to keep trailing spaces out.
+		for _, line := range strings.SplitAfter(buf.String(), "\n") {
+			if line == "" {
+				continue
+			}
+			if _, err := fmt.Fprintln(w, strings.TrimRight(line, " \n")); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "markdown":
+		_, _ = fmt.Fprintf(w, "| %s |\n", strings.Join(header, " | "))
+		_, _ = fmt.Fprintln(w, "|-------|-------:|-------:|-----------|--------|")
+		for _, row := range rows {
+			for i, cell := range row {
+				row[i] = strings.ReplaceAll(cell, "|", `\|`)
+			}
+			if _, err := fmt.Fprintf(w, "| %s |\n", strings.Join(row, " | ")); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "csv":
+		cw := csv.NewWriter(w)
+		_ = cw.WriteAll(append([][]string{header}, rows...))
+		return cw.Error()
+	default:
+		return fmt.Errorf("format has an invalid value: %s must be text, markdown or csv", format)
+	}
+}
+
+// size returns the offset or the width n, - if it varies.
+func size(n int) string {
+	if n < 0 {
+		return "-"
+	}
+	return strconv.Itoa(n)
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gromey/oxygen"
+)
+
+func TestPrintLayout(t *testing.T) {
+	columns := []column{
+		{Field: "ID", Offset: 1, Width: 4, Align: oxygen.RightAlignment, Filler: '0'},
+		{Field: "Name", Offset: 6, Width: 10, Align: oxygen.LeftAlignment, Filler: '|'},
+		{Field: "Tags", Offset: 17, Width: -1},
+		{Field: "Note", Offset: -1, Width: 3, Align: oxygen.LeftAlignment, Filler: ' '},
+	}
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{
+			format: "text",
+			want: "Field  Offset  Length  Alignment  Filler\n" +
+				"ID     1       4       right      '0'\n" +
+				"Name   6       10      left       '|'\n" +
+				"Tags   17      -\n" +
+				"Note   -       3       left       ' '\n",
+		},
+		{
+			format: "markdown",
+			want: "| Field | Offset | Length | Alignment | Filler |\n" +
+				"|-------|-------:|-------:|-----------|--------|\n" +
+				"| ID | 1 | 4 | right | '0' |\n" +
+				"| Name | 6 | 10 | left | '\\|' |\n" +
+				"| Tags | 17 | - |  |  |\n" +
+				"| Note | - | 3 | left | ' ' |\n",
+		},
+		{
+			format: "csv",
+			want: "Field,Offset,Length,Alignment,Filler\n" +
+				"ID,1,4,right,0\n" +
+				"Name,6,10,left,|\n" +
+				"Tags,17,-,,\n" +
+				"Note,-,3,left,\" \"\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var b strings.Builder
+			equal(t, nil, printLayout(&b, columns, tt.format))
+			equal(t, tt.want, b.String())
+		})
+	}
+
+	err := printLayout(new(strings.Builder), columns, "html")
+	equal(t, "format has an invalid value: html must be text, markdown or csv", err.Error())
+}
@@ -12,7 +12,7 @@ import (
 	"unicode"
 )
 
-//go:embed template/asserts.tmpl template/tag.tmpl
+//go:embed template/asserts.tmpl template/tag.tmpl template/codec.tmpl template/layout.tmpl template/schema.tmpl
 var content embed.FS
 
 const (
@@ -29,23 +29,52 @@ type data struct {
 }
 
 func main() {
-	var name string
+	if len(os.Args) > 1 && os.Args[1] == "layout" {
+		if err := layout(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	var name, typeNames, src, schemaFile, tagText string
 
 	flag.StringVar(&name, "n", "example", "the name of your tag you want to create")
+	flag.StringVar(&typeNames, "types", "", "comma-separated list of struct types to generate a reflection-free codec for")
+	flag.StringVar(&src, "src", "", "the directory of the package declaring the types, the package of your tag by default")
+	flag.StringVar(&schemaFile, "schema", "", "a JSON layout or a COBOL copybook to generate the struct types of its records from")
+	flag.StringVar(&tagText, "tag", "", "the template of the tags of the types generated from the schema")
 	flag.Parse()
 
-	if err := run(name); err != nil {
+	if err := run(name, typeNames, src, schemaFile, tagText); err != nil {
 		log.Fatal(err)
 	}
 }
 
-func run(name string) error {
+func run(name, typeNames, src, schemaFile, tagText string) error {
 	for _, r := range name {
 		if !unicode.IsLetter(r) {
 			return fmt.Errorf("name has an invalid value: %s must be letters only", name)
 		}
 	}
 
+	if schemaFile != "" {
+		dir := src
+		if dir == "" {
+			dir = strings.ToLower(name)
+		}
+		if err := generateSchema(strings.ToLower(name), dir, schemaFile, tagText); err != nil {
+			return err
+		}
+		// The codec of the generated types can be generated at once.
+		if typeNames == "" {
+			return nil
+		}
+	}
+
+	if typeNames != "" {
+		return generateCodec(strings.ToLower(name), src, strings.Split(typeNames, ","))
+	}
+
 	result := data{
 		LCName: strings.ToLower(name),
 		UCName: strings.ToUpper(name),
@@ -0,0 +1,349 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/build"
+	"go/format"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+const (
+	schemaTemplate = "template/schema.tmpl"
+
+	// defaultTagTemplate writes tags in the grammar of the fixed-width test formatter: the length, ! marking
+	// a required field, the filler, the alignment, the number format, the count field, the presence condition
+	// and the default value, * writing it instead of the zero value.
+	defaultTagTemplate = `{{if .Required}}!{{end}}{{.Length}},{{.Filler}},{{.Align}},{{.Format}},{{.Count}},{{.If}},{{if .PutDefault}}*{{end}}{{.Default}}`
+
+	// schemaHeader starts the files generated from a schema, other files are never overwritten.
+	schemaHeader = "// Code generated by oxygen from "
+)
+
+// schema is the layout of the records read from a JSON file or a COBOL copybook.
+type schema struct {
+	Records []*schemaField `json:"records"`
+}
+
+// schemaField is a record, a group of fields or an elementary field.
+type schemaField struct {
+	Name string `json:"name"`
+	// Type is the Go type of an elementary field or the name of the struct type of a group.
+	Type       string         `json:"type"`
+	Length     int            `json:"length"`
+	Filler     string         `json:"filler"`
+	Align      string         `json:"align"`
+	Format     string         `json:"format"`
+	Occurs     int            `json:"occurs"`
+	DependsOn  string         `json:"dependsOn"`
+	If         string         `json:"if"`
+	Required   bool           `json:"required"`
+	Default    *string        `json:"default"`
+	PutDefault bool           `json:"putDefault"`
+	Fields     []*schemaField `json:"fields"`
+
+	cobol string // the name in the copybook
+}
+
+// schemaTag is the data of the tag template of a field.
+type schemaTag struct {
+	Length     int
+	Filler     string
+	Align      string
+	Format     string
+	Count      string
+	If         string
+	Required   bool
+	Default    string
+	PutDefault bool
+}
+
+type schemaData struct {
+	Schema  string
+	Package string
+	Oxygen  bool
+	Types   []*schemaType
+}
+
+type schemaType struct {
+	Doc    string
+	Name   string
+	Fields []schemaTypeField
+}
+
+type schemaTypeField struct {
+	Name, Type, Tag string
+}
+
+type schemaGen struct {
+	name    string // the name of the tag
+	tag     *template.Template
+	grammar bool // the tags are written by defaultTagTemplate
+	types   map[string]bool
+	data    schemaData
+}
+
+// generateSchema writes the struct types of the records of the schema file with the tags of the formatter name
+// into the package in the directory dir, the tags are written by the template tagText.
+func generateSchema(name, dir, file, tagText string) error {
+	src, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	var s *schema
+	switch ext := strings.ToLower(filepath.Ext(file)); ext {
+	case ".json":
+		s, err = parseJSONSchema(src)
+	case ".cpy", ".cbl", ".cob":
+		s, err = parseCopybook(src, strings.TrimSuffix(filepath.Base(file), filepath.Ext(file)))
+	default:
+		return fmt.Errorf("schema has an invalid extension: %s must be .json, .cpy, .cbl or .cob", ext)
+	}
+	if err != nil {
+		return fmt.Errorf("cannot parse schema %s: %w", file, err)
+	}
+
+	grammar := tagText == ""
+	if grammar {
+		tagText = defaultTagTemplate
+	}
+	tag, err := template.New("tag").Option("missingkey=error").Parse(tagText)
+	if err != nil {
+		return err
+	}
+
+	pkg, err := packageName(dir)
+	if err != nil {
+		return err
+	}
+
+	g := &schemaGen{
+		name:    name,
+		tag:     tag,
+		grammar: grammar,
+		types:   make(map[string]bool),
+		data:    schemaData{Schema: filepath.Base(file), Package: pkg},
+	}
+	if len(s.Records) == 0 {
+		return fmt.Errorf("schema %s has no records", file)
+	}
+	for _, r := range s.Records {
+		if len(r.Fields) == 0 {
+			return fmt.Errorf("record %s has no fields", r.Name)
+		}
+		if _, err = g.structType(r, "", "record"); err != nil {
+			return err
+		}
+	}
+
+	temp, err := template.ParseFS(content, schemaTemplate)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err = temp.Execute(&buf, g.data); err != nil {
+		return err
+	}
+
+	out, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("cannot format generated types: %w", err)
+	}
+
+	filename := filepath.Join(dir, strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))+".go")
+	if old, err := os.ReadFile(filename); err == nil && !bytes.HasPrefix(old, []byte(schemaHeader)) {
+		return fmt.Errorf("cannot overwrite %s, it isn't generated from a schema", filename)
+	}
+
+	if err = os.MkdirAll(dir, 0770); err != nil {
+		return err
+	}
+	return os.WriteFile(filename, out, 0660)
+}
+
+// parseJSONSchema parses a schema written in JSON, unknown properties are reported.
+func parseJSONSchema(src []byte) (*schema, error) {
+	dec := json.NewDecoder(bytes.NewReader(src))
+	dec.DisallowUnknownFields()
+
+	s := new(schema)
+	if err := dec.Decode(s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// packageName returns the name of the package in the directory dir, the name of the directory if it has no package.
+func packageName(dir string) (string, error) {
+	if _, err := os.Stat(dir); err == nil {
+		bp, err := build.ImportDir(dir, 0)
+		if err == nil {
+			return bp.Name, nil
+		}
+		var noGo *build.NoGoError
+		if !errors.As(err, &noGo) {
+			return "", err
+		}
+	} else if !os.IsNotExist(err) {
+		return "", err
+	}
+
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	name := strings.ToLower(filepath.Base(abs))
+	if !token.IsIdentifier(name) {
+		return "", fmt.Errorf("cannot name a package after the directory %s", dir)
+	}
+	return name, nil
+}
+
+// structType declares the struct type of the record or the group f and returns its name.
+// A group named like a declared type is prefixed with the name of the type it belongs to.
+func (g *schemaGen) structType(f *schemaField, parent, kind string) (string, error) {
+	name := f.Type
+	if name == "" {
+		name = f.Name
+	}
+	if g.types[name] && parent != "" {
+		name = parent + name
+	}
+	if !token.IsIdentifier(name) || !token.IsExported(name) {
+		return "", fmt.Errorf("%s %s must be named after an exported Go identifier", kind, name)
+	}
+	if g.types[name] {
+		return "", fmt.Errorf("type %s is declared twice", name)
+	}
+	g.types[name] = true
+
+	st := &schemaType{Name: name, Doc: fmt.Sprintf("%s is a %s of the schema.", name, kind)}
+	if f.cobol != "" {
+		st.Doc = fmt.Sprintf("%s is the %s %s of the copybook.", name, kind, f.cobol)
+	}
+	g.data.Types = append(g.data.Types, st)
+
+	before := make(map[string]bool)
+	for _, c := range f.Fields {
+		if !token.IsIdentifier(c.Name) || !token.IsExported(c.Name) {
+			return "", fmt.Errorf("field %s of %s must be named after an exported Go identifier", c.Name, name)
+		}
+		if before[c.Name] {
+			return "", fmt.Errorf("field %s of %s is declared twice", c.Name, name)
+		}
+
+		field, err := g.field(c, name, before)
+		if err != nil {
+			return "", fmt.Errorf("field %s of %s: %w", c.Name, name, err)
+		}
+		st.Fields = append(st.Fields, field)
+		before[c.Name] = true
+	}
+
+	return name, nil
+}
+
+// field returns the struct field of f declared in the type parent after the fields before.
+func (g *schemaGen) field(f *schemaField, parent string, before map[string]bool) (schemaTypeField, error) {
+	field := schemaTypeField{Name: f.Name}
+	t := schemaTag{
+		Length:     f.Length,
+		Filler:     f.Filler,
+		Align:      f.Align,
+		Format:     f.Format,
+		Count:      f.DependsOn,
+		If:         f.If,
+		Required:   f.Required,
+		PutDefault: f.PutDefault,
+	}
+	if f.Default != nil {
+		t.Default = *f.Default
+	}
+
+	// A group is tagged only for its count or its presence, its fields are tagged instead.
+	tagged := true
+	filler, align := " ", "l"
+	if len(f.Fields) != 0 {
+		var err error
+		if field.Type, err = g.structType(f, parent, "group"); err != nil {
+			return field, err
+		}
+		tagged = f.DependsOn != "" || f.If != ""
+	} else {
+		numeric := false
+		switch f.Type {
+		case "", "string":
+			field.Type = "string"
+		case "bytes", "[]byte":
+			field.Type = "[]byte"
+		case "bool":
+			field.Type = "bool"
+		case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "float32", "float64":
+			field.Type, numeric = f.Type, true
+		case "decimal", "oxygen.Decimal":
+			field.Type, numeric = "oxygen.Decimal", true
+			g.data.Oxygen = true
+		default:
+			return field, fmt.Errorf("unsupported type %s", f.Type)
+		}
+
+		binary := f.Format == "be" || f.Format == "le"
+		if f.Length <= 0 && !binary {
+			return field, fmt.Errorf("the length must be positive")
+		}
+		// A packed decimal fills its length, a filler would be taken for a byte of its digits.
+		if numeric && !binary && !strings.HasPrefix(f.Format, "p") {
+			filler, align = "0", "r"
+		}
+	}
+	if t.Filler == "" {
+		t.Filler = filler
+	}
+	if t.Align == "" {
+		t.Align = align
+	}
+
+	switch {
+	case f.DependsOn != "":
+		if !before[f.DependsOn] {
+			return field, fmt.Errorf("the count field %s must be declared before it", f.DependsOn)
+		}
+		field.Type = "[]" + field.Type
+	case f.Occurs > 0:
+		field.Type = fmt.Sprintf("[%d]%s", f.Occurs, field.Type)
+	}
+
+	if !tagged {
+		return field, nil
+	}
+
+	// The parts of the default template are separated by commas.
+	if g.grammar {
+		for _, part := range [...]struct{ name, value string }{{"filler", t.Filler}, {"condition", t.If}, {"default value", t.Default}} {
+			if strings.ContainsAny(part.value, `,"`) {
+				return field, fmt.Errorf("the %s %s cannot contain a comma or a quote", part.name, part.value)
+			}
+		}
+	}
+
+	var buf strings.Builder
+	if err := g.tag.Execute(&buf, t); err != nil {
+		return field, err
+	}
+	// Empty parts at the end are left out.
+	value := strings.TrimRight(buf.String(), ",")
+	if strings.Contains(value, "`") {
+		return field, fmt.Errorf("the tag %s cannot contain a backquote", value)
+	}
+	field.Tag = "`" + g.name + ":" + strconv.Quote(value) + "`"
+	return field, nil
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+	"text/template"
+)
+
+func TestSchemaField(t *testing.T) {
+	value := func(v string) *string { return &v }
+
+	tests := []struct {
+		name    string
+		field   schemaField
+		tag     string
+		grammar bool
+		want    schemaTypeField
+		err     string
+	}{
+		{
+			name:    "string",
+			field:   schemaField{Name: "Name", Length: 4},
+			grammar: true,
+			want:    schemaTypeField{Name: "Name", Type: "string", Tag: "`test:\"4, ,l\"`"},
+		},
+		{
+			name:    "number",
+			field:   schemaField{Name: "Qty", Type: "uint16", Length: 3, Required: true},
+			grammar: true,
+			want:    schemaTypeField{Name: "Qty", Type: "uint16", Tag: "`test:\"!3,0,r\"`"},
+		},
+		{
+			name:    "packed",
+			field:   schemaField{Name: "Balance", Type: "decimal", Length: 4, Format: "p7.2"},
+			grammar: true,
+			want:    schemaTypeField{Name: "Balance", Type: "oxygen.Decimal", Tag: "`test:\"4, ,l,p7.2\"`"},
+		},
+		{
+			name:    "binary",
+			field:   schemaField{Name: "Seq", Type: "int32", Format: "be"},
+			grammar: true,
+			want:    schemaTypeField{Name: "Seq", Type: "int32", Tag: "`test:\"0, ,l,be\"`"},
+		},
+		{
+			name:    "count",
+			field:   schemaField{Name: "Codes", Length: 2, DependsOn: "Count"},
+			grammar: true,
+			want:    schemaTypeField{Name: "Codes", Type: "[]string", Tag: "`test:\"2, ,l,,Count\"`"},
+		},
+		{
+			name:    "default",
+			field:   schemaField{Name: "Country", Length: 2, Default: value("US"), PutDefault: true, Occurs: 2},
+			grammar: true,
+			want:    schemaTypeField{Name: "Country", Type: "[2]string", Tag: "`test:\"2, ,l,,,,*US\"`"},
+		},
+		{
+			name:  "template",
+			field: schemaField{Name: "Note", Length: 3, Default: value("a,b")},
+			tag:   `{{.Length}};{{.Default}}`,
+			want:  schemaTypeField{Name: "Note", Type: "string", Tag: "`test:\"3;a,b\"`"},
+		},
+		{name: "type", field: schemaField{Name: "At", Type: "time.Time", Length: 8}, err: "unsupported type time.Time"},
+		{name: "length", field: schemaField{Name: "Name"}, err: "the length must be positive"},
+		{name: "count after", field: schemaField{Name: "Codes", Length: 2, DependsOn: "Total"}, err: "the count field Total must be declared before it"},
+		{name: "backquote", field: schemaField{Name: "Name", Length: 2, Filler: "`"}, err: "the tag 2,`,l cannot contain a backquote"},
+		{
+			name:    "comma filler",
+			field:   schemaField{Name: "Name", Length: 2, Filler: ","},
+			grammar: true,
+			err:     "the filler , cannot contain a comma or a quote",
+		},
+		{
+			name:    "comma condition",
+			field:   schemaField{Name: "Name", Length: 2, If: "Kind=A,B"},
+			grammar: true,
+			err:     "the condition Kind=A,B cannot contain a comma or a quote",
+		},
+		{
+			name:    "quoted default",
+			field:   schemaField{Name: "Name", Length: 2, Default: value(`"A"`)},
+			grammar: true,
+			err:     `the default value "A" cannot contain a comma or a quote`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text := tt.tag
+			if tt.grammar || text == "" {
+				text = defaultTagTemplate
+			}
+			g := &schemaGen{
+				name:    "test",
+				tag:     template.Must(template.New("tag").Option("missingkey=error").Parse(text)),
+				grammar: tt.grammar,
+				types:   make(map[string]bool),
+			}
+
+			field, err := g.field(&tt.field, "Record", map[string]bool{"Count": true})
+			if tt.err != "" {
+				equal(t, tt.err, fmt.Sprint(err))
+				return
+			}
+			equal(t, nil, err)
+			equal(t, tt.want, field)
+		})
+	}
+}
@@ -0,0 +1,12 @@
+// Package unsupported declares types the reflection-free codec cannot be generated for.
+package unsupported
+
+type Queue struct {
+	ID    int            `test:"4,0,r"`
+	Items chan int       `test:"2"`
+	Index map[string]int `test:"2"`
+}
+
+type Generic[T any] struct {
+	Value T `test:"2"`
+}
@@ -1,9 +1,12 @@
 package oxygen
 
 import (
+	"encoding"
 	"errors"
 	"fmt"
 	"reflect"
+	"strconv"
+	"strings"
 )
 
 var (
@@ -11,8 +14,22 @@ var (
 
 	ErrNotSupportType      = errors.New("cannot support type")
 	ErrNilInterface        = errors.New("interface is nil")
+	ErrUnknownType         = errors.New("no type registered for discriminator")
 	ErrPointerToUnexported = errors.New("cannot set embedded pointer to unexported struct")
 	ErrInvalidFormat       = errors.New("the raw data has an invalid format for an object value")
+	ErrInvalidConfig       = errors.New("invalid configuration")
+	ErrMaxDepth            = errors.New("exceeded max depth")
+	ErrUnmappableRune      = errors.New("cannot map rune")
+	ErrUnknownRecord       = errors.New("no record type registered for the prefix")
+	ErrTrailingData        = errors.New("unexpected data after the value")
+	ErrTruncated           = errors.New("the data ended before the field")
+	ErrRequired            = errors.New("required field has no data")
+)
+
+var (
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	decimalType         = reflect.TypeOf(Decimal{})
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
 )
 
 func bitSize(v reflect.Kind) int {
@@ -32,6 +49,17 @@ func bitSize(v reflect.Kind) int {
 	}
 }
 
+// isInteger reports whether the kind is a signed or an unsigned integer.
+func isInteger(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	default:
+		return false
+	}
+}
+
 func isEmptyValue(v reflect.Value) bool {
 	switch v.Kind() {
 	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
@@ -49,24 +77,160 @@ func isEmptyValue(v reflect.Value) bool {
 	}
 }
 
+// MarshalTypeError describes a Go value that could not be encoded.
+type MarshalTypeError struct {
+	Name   string       // name of the engine
+	Struct string       // name of the root struct type
+	Field  string       // the full path from the root struct to the field, empty for a non-struct value
+	Type   reflect.Type // type of the Go value that could not be encoded
+	Err    error
+}
+
+func (e *MarshalTypeError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("%s: cannot %s Go value of type %s: %v", e.Name, marshalError, e.Type, e.Err)
+	}
+	return fmt.Sprintf("%s: cannot %s Go struct field %s.%s of type %s: %v", e.Name, marshalError, e.Struct, e.Field, e.Type, e.Err)
+}
+
+func (e *MarshalTypeError) Unwrap() error { return e.Err }
+
+// UnmarshalTypeError describes encoded data that could not be decoded into a Go value.
+type UnmarshalTypeError struct {
+	Name   string       // name of the engine
+	Struct string       // name of the root struct type
+	Field  string       // the full path from the root struct to the field, empty for a non-struct value
+	Type   reflect.Type // type of the Go value that could not be decoded
+	Offset int64        // offset of the value in the input
+	Err    error
+}
+
+func (e *UnmarshalTypeError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("%s: cannot %s Go value of type %s: %v", e.Name, unmarshalError, e.Type, e.Err)
+	}
+	return fmt.Sprintf("%s: cannot %s Go struct field %s.%s of type %s: %v", e.Name, unmarshalError, e.Struct, e.Field, e.Type, e.Err)
+}
+
+func (e *UnmarshalTypeError) Unwrap() error { return e.Err }
+
+// SyntaxError describes encoded data that doesn't match the structure of the format,
+// for example a missing StructOpener or ValueSeparator.
+type SyntaxError struct {
+	Name   string // name of the engine
+	Struct string // name of the root struct type
+	Field  string // the full path from the root struct to the last field being decoded
+	Offset int64  // offset in the input where the error occurred
+	Err    error
+}
+
+func (e *SyntaxError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("%s: syntax error at offset %d: %v", e.Name, e.Offset, e.Err)
+	}
+	return fmt.Sprintf("%s: syntax error at offset %d in Go struct field %s.%s: %v", e.Name, e.Offset, e.Struct, e.Field, e.Err)
+}
+
+func (e *SyntaxError) Unwrap() error { return e.Err }
+
+// RequiredFieldError describes required struct fields the encoded data ended before.
+type RequiredFieldError struct {
+	Name   string   // name of the engine
+	Struct string   // name of the root struct type
+	Fields []string // the full paths from the root struct to the fields
+}
+
+func (e *RequiredFieldError) Error() string {
+	return fmt.Sprintf("%s: no data for required fields of struct %s: %s", e.Name, e.Struct, strings.Join(e.Fields, ", "))
+}
+
+func (e *RequiredFieldError) Unwrap() error { return ErrRequired }
+
+// TagError describes a struct field tag that could not be parsed.
+type TagError struct {
+	Name   string // name of the engine
+	Tag    string // value of the tag
+	Struct string // name of the root struct type
+	Field  string // the full path from the root struct to the field
+	Err    error
+}
+
+func (e *TagError) Error() string {
+	return fmt.Sprintf("%s: tag %s of struct field %s.%s: %v", e.Name, e.Tag, e.Struct, e.Field, e.Err)
+}
+
+func (e *TagError) Unwrap() error { return e.Err }
+
 type context[T any] struct {
-	structName string
+	structName string   // name of the root struct type
+	fieldPath  []string // names of the fields from the root struct to the current field
 	field      *field[T]
+	offset     int64 // offset of the current value in the input
+	nesting    int   // nesting depth of structs, lists and maps
 	err        error
 }
 
-func (c *context[T]) setError(tagName, state string, err error) {
-	err = unwrapErr(err)
-	if c.structName == "" {
-		c.err = fmt.Errorf("%s: cannot %s Go value of type %s: %w", tagName, state, c.field.typ, err)
-	} else {
-		c.err = fmt.Errorf("%s: cannot %s Go struct field %s.%s of type %s: %w", tagName, state, c.structName, c.field.name, c.field.typ, err)
+// enter increases the nesting depth, it returns ErrMaxDepth if the depth exceeds a non-zero max.
+func (c *context[T]) enter(max int) error {
+	if c.nesting++; max > 0 && c.nesting > max {
+		return ErrMaxDepth
+	}
+	return nil
+}
+
+func (c *context[T]) leave() { c.nesting-- }
+
+// path returns the full dotted path from the root struct to the current field.
+func (c *context[T]) path() string {
+	return strings.Join(c.fieldPath, ".")
+}
+
+func (c *context[T]) setMarshalError(tagName string, err error) {
+	c.err = &MarshalTypeError{
+		Name:   tagName,
+		Struct: c.structName,
+		Field:  c.path(),
+		Type:   c.field.typ,
+		Err:    unwrapErr(err),
+	}
+}
+
+func (c *context[T]) setUnmarshalError(tagName string, err error) {
+	c.err = &UnmarshalTypeError{
+		Name:   tagName,
+		Struct: c.structName,
+		Field:  c.path(),
+		Type:   c.field.typ,
+		Offset: c.offset,
+		Err:    unwrapErr(err),
 	}
 }
 
+func (c *context[T]) setSyntaxError(tagName string, err error) {
+	c.err = &SyntaxError{
+		Name:   tagName,
+		Struct: c.structName,
+		Field:  c.path(),
+		Offset: c.offset,
+		Err:    err,
+	}
+}
+
+func (c *context[T]) setTagError(tagName, tag string, err error) {
+	c.err = &TagError{
+		Name:   tagName,
+		Tag:    tag,
+		Struct: c.structName,
+		Field:  c.path(),
+		Err:    err,
+	}
+}
+
+// unwrapErr strips the details of strconv errors, since the error already describes the field.
 func unwrapErr(err error) error {
-	if ew := errors.Unwrap(err); ew != nil {
-		return ew
+	var ne *strconv.NumError
+	if errors.As(err, &ne) {
+		return ne.Err
 	}
 	return err
 }
@@ -77,3 +241,27 @@ func unPoint(t reflect.Type) reflect.Type {
 	}
 	return unPoint(t.Elem())
 }
+
+// isEncodableKey reports whether a map key of type t can be encoded.
+func isEncodableKey(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	default:
+		return t.Implements(textMarshalerType)
+	}
+}
+
+// isDecodableKey reports whether a map key of type t can be decoded.
+func isDecodableKey(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	default:
+		return reflect.PointerTo(t).Implements(textUnmarshalerType)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"math/bits"
 	"reflect"
+	"strconv"
 	"testing"
 )
 
@@ -182,27 +183,37 @@ type empty struct{}
 
 func Test_contextSetError(t *testing.T) {
 	tagName := "tagName"
-	str := "set/get"
 
 	var tests = []struct {
 		name   string
 		ctx    context[empty]
+		set    func(c *context[empty], err error)
 		expect error
 	}{
 		{
-			name: "error for structs",
+			name: "marshal error for structs",
 			ctx: context[empty]{
 				structName: "structName",
+				fieldPath:  []string{"parent", "fieldName"},
 				field: &field[empty]{
 					name: "fieldName",
 					typ:  reflect.TypeOf(true),
 				},
 				err: ErrNotSupportType,
 			},
-			expect: errors.New("tagName: cannot set/get Go struct field structName.fieldName of type bool: cannot support type"),
+			set: func(c *context[empty], err error) {
+				c.setMarshalError(tagName, err)
+			},
+			expect: &MarshalTypeError{
+				Name:   tagName,
+				Struct: "structName",
+				Field:  "parent.fieldName",
+				Type:   reflect.TypeOf(true),
+				Err:    ErrNotSupportType,
+			},
 		},
 		{
-			name: "error for simple types",
+			name: "marshal error for simple types",
 			ctx: context[empty]{
 				structName: "",
 				field: &field[empty]{
@@ -210,19 +221,132 @@ func Test_contextSetError(t *testing.T) {
 				},
 				err: ErrNotSupportType,
 			},
-			expect: errors.New("tagName: cannot set/get Go value of type bool: cannot support type"),
+			set: func(c *context[empty], err error) {
+				c.setMarshalError(tagName, err)
+			},
+			expect: &MarshalTypeError{
+				Name: tagName,
+				Type: reflect.TypeOf(true),
+				Err:  ErrNotSupportType,
+			},
+		},
+		{
+			name: "unmarshal error for structs",
+			ctx: context[empty]{
+				structName: "structName",
+				fieldPath:  []string{"parent", "fieldName"},
+				field: &field[empty]{
+					name: "fieldName",
+					typ:  reflect.TypeOf(true),
+				},
+				offset: 7,
+				err:    &strconv.NumError{Func: "ParseBool", Num: "x", Err: strconv.ErrSyntax},
+			},
+			set: func(c *context[empty], err error) {
+				c.setUnmarshalError(tagName, err)
+			},
+			expect: &UnmarshalTypeError{
+				Name:   tagName,
+				Struct: "structName",
+				Field:  "parent.fieldName",
+				Type:   reflect.TypeOf(true),
+				Offset: 7,
+				Err:    strconv.ErrSyntax,
+			},
+		},
+		{
+			name: "syntax error",
+			ctx: context[empty]{
+				structName: "structName",
+				fieldPath:  []string{"fieldName"},
+				field:      &field[empty]{},
+				offset:     3,
+				err:        ErrInvalidFormat,
+			},
+			set: func(c *context[empty], err error) {
+				c.setSyntaxError(tagName, err)
+			},
+			expect: &SyntaxError{
+				Name:   tagName,
+				Struct: "structName",
+				Field:  "fieldName",
+				Offset: 3,
+				Err:    ErrInvalidFormat,
+			},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tt.ctx.setError(tagName, str, tt.ctx.err)
-			if tt.expect != nil {
-				equal(t, tt.expect.Error(), tt.ctx.err.Error())
-				return
-			}
-			equal(t, nil, tt.ctx.err)
+			tt.set(&tt.ctx, tt.ctx.err)
+			equal(t, tt.expect, tt.ctx.err)
+			equal(t, true, errors.Is(tt.ctx.err, errors.Unwrap(tt.expect)))
 		})
+	}
+}
 
+func TestErrors(t *testing.T) {
+	var tests = []struct {
+		name   string
+		err    error
+		expect string
+	}{
+		{
+			name: "marshal error for structs",
+			err: &MarshalTypeError{
+				Name:   "tagName",
+				Struct: "structName",
+				Field:  "parent.fieldName",
+				Type:   reflect.TypeOf(true),
+				Err:    ErrNotSupportType,
+			},
+			expect: "tagName: cannot encode data from Go struct field structName.parent.fieldName of type bool: cannot support type",
+		},
+		{
+			name: "unmarshal error for simple types",
+			err: &UnmarshalTypeError{
+				Name: "tagName",
+				Type: reflect.TypeOf(true),
+				Err:  ErrNotSupportType,
+			},
+			expect: "tagName: cannot decode data into Go value of type bool: cannot support type",
+		},
+		{
+			name: "syntax error",
+			err: &SyntaxError{
+				Name:   "tagName",
+				Offset: 3,
+				Err:    ErrInvalidFormat,
+			},
+			expect: "tagName: syntax error at offset 3: the raw data has an invalid format for an object value",
+		},
+		{
+			name: "syntax error for structs",
+			err: &SyntaxError{
+				Name:   "tagName",
+				Struct: "structName",
+				Field:  "parent.fieldName",
+				Offset: 12,
+				Err:    ErrInvalidFormat,
+			},
+			expect: "tagName: syntax error at offset 12 in Go struct field structName.parent.fieldName: the raw data has an invalid format for an object value",
+		},
+		{
+			name: "tag error",
+			err: &TagError{
+				Name:   "tagName",
+				Tag:    "x",
+				Struct: "structName",
+				Field:  "fieldName",
+				Err:    strconv.ErrSyntax,
+			},
+			expect: "tagName: tag x of struct field structName.fieldName: invalid syntax",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			equal(t, tt.expect, tt.err.Error())
+		})
 	}
 }
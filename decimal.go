@@ -0,0 +1,299 @@
+package oxygen
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// NumberEncoding selects how the digits of numbers are written.
+type NumberEncoding int
+
+const (
+	// TextNumber writes numbers as text, it's the default.
+	TextNumber NumberEncoding = iota
+	// PackedDecimal writes numbers as COBOL COMP-3 packed decimals: two digits per byte
+	// followed by a sign nibble, C for positive, D for negative and F for unsigned numbers.
+	PackedDecimal
+	// ZonedDecimal writes numbers as zoned decimals: one digit per byte with the sign overpunched
+	// on the last digit, '{' and 'A' to 'I' for positive, '}' and 'J' to 'R' for negative numbers.
+	// The last digit of unsigned numbers isn't overpunched.
+	ZonedDecimal
+)
+
+// Decimal is a fixed-point number equal to Value * 10^-Scale.
+type Decimal struct {
+	Value int64
+	Scale int
+}
+
+// String returns the decimal with the decimal point, for example 12.50 for Decimal{1250, 2}.
+func (d Decimal) String() string {
+	return string(NumberFormat{}.AppendDecimal(nil, d))
+}
+
+// rescale returns the value of d with the scale, rounding half away from zero.
+// It reports false if the value overflows an int64.
+func (d Decimal) rescale(scale int) (int64, bool) {
+	v := d.Value
+	for s := d.Scale; s < scale; s++ {
+		if v > math.MaxInt64/10 || v < math.MinInt64/10 {
+			return 0, false
+		}
+		v *= 10
+	}
+	for s := d.Scale; s > scale; s-- {
+		r := v % 10
+		v /= 10
+		if r >= 5 {
+			v++
+		} else if r <= -5 {
+			v--
+		}
+	}
+	return v, true
+}
+
+// rescaled reports whether the decimal d is written as an integer rescaled to ImpliedDecimals.
+func (f NumberFormat) rescaled(d Decimal) bool {
+	return f.Encoding != TextNumber || f.ImpliedDecimals > 0 || d.Scale <= 0
+}
+
+// AppendDecimal appends the form of the decimal d to dst, CheckDecimal reports the decimals it cannot write.
+// Text decimals are written with the decimal point unless ImpliedDecimals is set,
+// then d is rescaled to it as for packed and zoned decimals.
+func (f NumberFormat) AppendDecimal(dst []byte, d Decimal) []byte {
+	if !f.rescaled(d) {
+		s, _ := insertPoint(strconv.FormatInt(d.Value, 10), d.Scale)
+		return append(dst, s...)
+	}
+	v, _ := d.rescale(f.ImpliedDecimals)
+	if f.Encoding != TextNumber {
+		return f.appendDigits(dst, v, true)
+	}
+	return strconv.AppendInt(dst, v, 10)
+}
+
+// CheckDecimal returns an error if AppendDecimal cannot write the decimal d: if rescaling it to ImpliedDecimals
+// overflows an int64 or if it has more digits than Digits when it's written as a packed or a zoned decimal.
+func (f NumberFormat) CheckDecimal(d Decimal) error {
+	if !f.rescaled(d) {
+		return nil
+	}
+	v, ok := d.rescale(f.ImpliedDecimals)
+	if !ok {
+		return fmt.Errorf("cannot rescale %v to %d decimals: %w", d, f.ImpliedDecimals, strconv.ErrRange)
+	}
+	return f.checkDigits(magnitude(v), d)
+}
+
+// ParseDecimal interprets the form of a decimal, the scale of the result is ImpliedDecimals
+// unless it's a text decimal with the decimal point.
+func (f NumberFormat) ParseDecimal(s string) (Decimal, error) {
+	if f.Encoding != TextNumber || f.ImpliedDecimals > 0 {
+		v, err := f.ParseInt(s, 64)
+		if err != nil {
+			err.(*strconv.NumError).Func = "ParseDecimal"
+		}
+		return Decimal{Value: v, Scale: f.ImpliedDecimals}, err
+	}
+
+	var scale int
+	if p := strings.IndexByte(s, '.'); p >= 0 {
+		scale = len(s) - p - 1
+		if scale == 0 || strings.IndexByte(s[p+1:], '.') >= 0 || s[p+1] == '-' || s[p+1] == '+' {
+			return Decimal{}, &strconv.NumError{Func: "ParseDecimal", Num: s, Err: strconv.ErrSyntax}
+		}
+		s = s[:p] + s[p+1:]
+	}
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		err.(*strconv.NumError).Func = "ParseDecimal"
+	}
+	return Decimal{Value: v, Scale: scale}, err
+}
+
+// appendDigits appends v in the packed or zoned encoding, v is unsigned if signed is false.
+func (f NumberFormat) appendDigits(dst []byte, v int64, signed bool) []byte {
+	u, neg := uint64(v), false
+	if signed && v < 0 {
+		u, neg = uint64(-v), true
+	}
+	return f.appendMagnitude(dst, u, neg, signed)
+}
+
+// magnitude returns the absolute value of v.
+func magnitude(v int64) uint64 {
+	if v < 0 {
+		return uint64(-v)
+	}
+	return uint64(v)
+}
+
+// checkDigits returns an error if the magnitude u of the number v has more digits than Digits
+// when it's written as a packed or a zoned decimal.
+func (f NumberFormat) checkDigits(u uint64, v any) error {
+	if f.Encoding == TextNumber || f.Digits == 0 || f.Digits >= 20 {
+		return nil
+	}
+	limit := uint64(1)
+	for i := 0; i < f.Digits; i++ {
+		limit *= 10
+	}
+	if u >= limit {
+		return fmt.Errorf("cannot write %v as a decimal of %d digits: %w", v, f.Digits, strconv.ErrRange)
+	}
+	return nil
+}
+
+func (f NumberFormat) appendMagnitude(dst []byte, u uint64, neg, signed bool) []byte {
+	var buf [20]byte
+	digits := strconv.AppendUint(buf[:0], u, 10)
+
+	n := len(digits)
+	if n < f.Digits {
+		n = f.Digits
+	}
+
+	if f.Encoding == ZonedDecimal {
+		for i := len(digits); i < n; i++ {
+			dst = append(dst, '0')
+		}
+		dst = append(dst, digits...)
+		if signed {
+			last := &dst[len(dst)-1]
+			if neg {
+				*last = "}JKLMNOPQR"[*last-'0']
+			} else {
+				*last = "{ABCDEFGHI"[*last-'0']
+			}
+		}
+		return dst
+	}
+
+	// A packed decimal holds an odd number of digits and the sign nibble.
+	if n%2 == 0 {
+		n++
+	}
+	sign := byte(0xF)
+	if signed {
+		sign = 0xC
+		if neg {
+			sign = 0xD
+		}
+	}
+
+	nibbles := make([]byte, 0, n+1)
+	for i := len(digits); i < n; i++ {
+		nibbles = append(nibbles, 0)
+	}
+	for _, c := range digits {
+		nibbles = append(nibbles, c-'0')
+	}
+	nibbles = append(nibbles, sign)
+
+	for i := 0; i < len(nibbles); i += 2 {
+		dst = append(dst, nibbles[i]<<4|nibbles[i+1])
+	}
+	return dst
+}
+
+// parseMagnitude interprets a packed or zoned decimal, it reports the absolute value and whether it's negative.
+func (f NumberFormat) parseMagnitude(s string) (u uint64, neg bool, err error) {
+	if len(s) == 0 {
+		return 0, false, strconv.ErrSyntax
+	}
+
+	add := func(d byte) error {
+		if d > 9 {
+			return strconv.ErrSyntax
+		}
+		if u > (math.MaxUint64-uint64(d))/10 {
+			return strconv.ErrRange
+		}
+		u = u*10 + uint64(d)
+		return nil
+	}
+
+	if f.Encoding == ZonedDecimal {
+		for i := 0; i < len(s)-1; i++ {
+			if err = add(s[i] - '0'); err != nil {
+				return
+			}
+		}
+		last := s[len(s)-1]
+		switch {
+		case last >= '0' && last <= '9':
+			err = add(last - '0')
+		case last == '{':
+			err = add(0)
+		case last >= 'A' && last <= 'I':
+			err = add(last - 'A' + 1)
+		case last == '}':
+			neg, err = true, add(0)
+		case last >= 'J' && last <= 'R':
+			neg, err = true, add(last-'J'+1)
+		default:
+			err = strconv.ErrSyntax
+		}
+		return
+	}
+
+	for i := 0; i < len(s); i++ {
+		if err = add(s[i] >> 4); err != nil {
+			return
+		}
+		if i == len(s)-1 {
+			break
+		}
+		if err = add(s[i] & 0xF); err != nil {
+			return
+		}
+	}
+	switch s[len(s)-1] & 0xF {
+	case 0xA, 0xC, 0xE, 0xF:
+	case 0xB, 0xD:
+		neg = true
+	default:
+		err = strconv.ErrSyntax
+	}
+	return
+}
+
+// parseDigitsInt interprets a packed or zoned decimal as a signed integer of the bitSize.
+func (f NumberFormat) parseDigitsInt(s string, bitSize int) (int64, error) {
+	u, neg, err := f.parseMagnitude(s)
+	if err != nil {
+		return 0, &strconv.NumError{Func: "ParseInt", Num: s, Err: err}
+	}
+
+	limit := uint64(1) << uint(bitSize-1)
+	if !neg && u >= limit || neg && u > limit {
+		return 0, &strconv.NumError{Func: "ParseInt", Num: s, Err: strconv.ErrRange}
+	}
+	if neg {
+		return -int64(u), nil
+	}
+	return int64(u), nil
+}
+
+// parseDigitsUint interprets a packed or zoned decimal as an unsigned integer of the bitSize.
+func (f NumberFormat) parseDigitsUint(s string, bitSize int) (uint64, error) {
+	u, neg, err := f.parseMagnitude(s)
+	if err == nil && neg && u != 0 {
+		err = strconv.ErrSyntax
+	}
+	if err == nil && bitSize < 64 && u >= uint64(1)<<uint(bitSize) {
+		err = strconv.ErrRange
+	}
+	if err != nil {
+		return 0, &strconv.NumError{Func: "ParseUint", Num: s, Err: err}
+	}
+	return u, nil
+}
+
+// scale returns 10 to the power of ImpliedDecimals.
+func (f NumberFormat) scale() float64 {
+	return math.Pow10(f.ImpliedDecimals)
+}
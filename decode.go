@@ -2,26 +2,28 @@ package oxygen
 
 import (
 	"bytes"
+	"encoding"
+	"encoding/binary"
 	"errors"
 	"fmt"
+	"io"
+	"math"
 	"reflect"
 	"strconv"
-	"sync"
+	"strings"
+	"unicode/utf8"
 )
 
-// Proper usage of a sync.Pool requires each entry to have approximately
-// the same memory cost. To obtain this property when the stored type
-// contains a variably-sized buffer, we add a hard limit on the maximum buffer
-// to place back in the pool.
-//
-// See https://golang.org/issue/23199
-const maxSize = 1 << 16 // 64KiB
-
 const unmarshalError = "decode data into"
 
 // Unmarshal decodes the encoded data and stores the result in the value pointed to by v.
 // If v is nil or not a pointer, Unmarshal returns a decoder error.
 func (e *engine[T]) Unmarshal(data []byte, v any) error {
+	return e.unmarshalAt(data, 0, v)
+}
+
+// unmarshalAt is like Unmarshal but reports offsets in errors relative to base.
+func (e *engine[T]) unmarshalAt(data []byte, base int64, v any) error {
 	if t := reflect.ValueOf(v).Kind(); t != reflect.Pointer {
 		return fmt.Errorf("%s: Unmarshal(non-pointer %s)", e.name, t)
 	}
@@ -29,62 +31,198 @@ func (e *engine[T]) Unmarshal(data []byte, v any) error {
 	s := e.newDecodeState()
 	defer putDecodeState(s)
 
-	s.data = append(s.data, data...)
-
-	s.unmarshal(v)
+	s.init(data, base)
+	if s.unmarshal(v); s.err == nil && s.strict && len(s.data) != 0 {
+		s.trailingData()
+	}
 	return s.err
 }
 
+// trailingData sets the error of data left after the value in strict mode.
+func (s *decodeState[T]) trailingData() {
+	s.offset = s.consumed()
+	s.setSyntaxError(s.name, ErrTrailingData)
+}
+
+// init sets the input of the state, base is the offset of the input in a stream.
+func (s *decodeState[T]) init(data []byte, base int64) {
+	s.data = data
+	s.size = len(data)
+	s.base = base
+	if s.charset != nil {
+		s.src, s.text = data, s.charset.Decode(s.text[:0], data)
+		s.srcPos, s.textPos = 0, 0
+	}
+}
+
 type decodeState[T any] struct {
 	*engine[T]
 	context[T]
-	*bytes.Buffer
-	data []byte // copy of input
+	data []byte // remaining input
+	size int    // length of input
+	base int64  // offset of the input in a stream
+
+	src             []byte // input, if the charset is set
+	text            []byte // input decoded from the charset
+	srcPos, textPos int    // positions of the same character in src and text
+
+	missing []string    // full paths of the required fields the data ended before
+	lists   []listIndex // lists being decoded with the index of their current element
+	blank   int         // nesting of the last empty value found by the Decode function
 }
 
-var decodeStatePool sync.Pool
+// listIndex is the index of the current element of the list held by the field at the depth of the field path.
+type listIndex struct {
+	depth, index int
+}
 
 func (e *engine[T]) newDecodeState() *decodeState[T] {
-	if p := decodeStatePool.Get(); p != nil {
+	if p := e.decodeStatePool.Get(); p != nil {
 		s := p.(*decodeState[T])
+		s.structName = ""
+		s.fieldPath = s.fieldPath[:0]
+		s.missing = s.missing[:0]
+		s.lists = s.lists[:0]
+		s.offset = 0
+		s.nesting = 0
 		s.field = new(field[T])
 		s.err = nil
-		s.Reset()
-		s.data = s.data[:0]
 		return s
 	}
 
-	s := &decodeState[T]{engine: e, Buffer: new(bytes.Buffer), data: make([]byte, 0, 512)}
+	s := &decodeState[T]{engine: e}
 	s.field = new(field[T])
 	return s
 }
 
 func putDecodeState[T any](s *decodeState[T]) {
-	if cap(s.data) <= maxSize {
-		decodeStatePool.Put(s)
-	}
+	// Don't keep the input alive while the state is in the pool.
+	s.data, s.src, s.text = nil, nil, s.text[:0]
+	s.decodeStatePool.Put(s)
 }
 
 func (s *decodeState[T]) unmarshal(v any) {
+	if s.cfgErr != nil {
+		s.err = s.cfgErr
+		return
+	}
+
 	if err := s.reflectValue(reflect.ValueOf(v)); err != nil {
 		if !errors.Is(err, errExist) {
 			if s.field.typ == nil {
 				s.field.typ = unPoint(reflect.TypeOf(v))
 			}
-			s.setError(s.name, unmarshalError, err)
+			s.setUnmarshalError(s.name, err)
 		}
+		return
+	}
+	if len(s.missing) != 0 {
+		s.err = &RequiredFieldError{Name: s.name, Struct: s.structName, Fields: append([]string(nil), s.missing...)}
 	}
 }
 
 func (s *decodeState[T]) reflectValue(v reflect.Value) error {
+	s.offset = s.consumed()
 	return s.cachedCoders(v.Type()).decoderFunc(s, v)
 }
 
+// consumed returns the offset of the remaining data in the input.
+func (s *decodeState[T]) consumed() int64 {
+	return s.base + int64(s.size-len(s.data))
+}
+
+// value finds the value of the current field with the Decode function
+// and advances the remaining data past the bytes it consumed.
+func (s *decodeState[T]) value() ([]byte, error) {
+	p, n, err := s.Decode(s.field.name, s.field.tag, s.input())
+	if err != nil {
+		return nil, err
+	}
+	if err = s.advance(n); err != nil {
+		return nil, err
+	}
+	if len(p) == 0 {
+		s.blank = s.nesting
+	}
+	return p, nil
+}
+
+// number is like value but packed decimals are passed to the Decode function without transcoding.
+func (s *decodeState[T]) number() ([]byte, error) {
+	if s.charset == nil || s.formatOf(s.field).Encoding != PackedDecimal {
+		return s.value()
+	}
+
+	p, n, err := s.Decode(s.field.name, s.field.tag, s.data)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.skip(n); err != nil {
+		return nil, err
+	}
+	if len(p) == 0 {
+		s.blank = s.nesting
+	}
+	return p, nil
+}
+
+// input returns the remaining data for the Tag functions, decoded from the charset if it's set.
+func (s *decodeState[T]) input() []byte {
+	if s.charset == nil {
+		return s.data
+	}
+	for pos := s.size - len(s.data); s.srcPos < pos; s.srcPos++ {
+		s.textPos += utf8.RuneLen(s.charset.runes[s.src[s.srcPos]])
+	}
+	return s.text[s.textPos:]
+}
+
+// advance skips n bytes of the input returned by the input function.
+func (s *decodeState[T]) advance(n int) error {
+	if s.charset == nil {
+		return s.skip(n)
+	}
+
+	in := s.input()
+	if n < 0 || n > len(in) {
+		return fmt.Errorf("consumed %d bytes out of %d remaining", n, len(in))
+	}
+	// Every character of the charset takes one byte of the remaining data.
+	var i, chars int
+	for ; i < n; chars++ {
+		_, size := utf8.DecodeRune(in[i:])
+		i += size
+	}
+	s.srcPos += chars
+	s.textPos += i
+	return s.skip(chars)
+}
+
+// skip skips n bytes of the remaining data.
+func (s *decodeState[T]) skip(n int) error {
+	if n < 0 || n > len(s.data) {
+		return fmt.Errorf("consumed %d bytes out of %d remaining", n, len(s.data))
+	}
+	s.data = s.data[n:]
+	return nil
+}
+
+// binary reads a binary primitive of the size bytes in the byte order.
+func (s *decodeState[T]) binary(order binary.ByteOrder, size int) (uint64, error) {
+	if len(s.data) < size {
+		return 0, io.ErrUnexpectedEOF
+	}
+	u := ParseBinary(order, s.data[:size])
+	s.data = s.data[size:]
+	return u, nil
+}
+
 type decoderFunc[T any] func(*decodeState[T], reflect.Value) error
 
 func (s *decodeState[T]) removePrefixBytes(b []byte) error {
 	if !bytes.HasPrefix(s.data, b) {
-		s.err = fmt.Errorf("%s: %w", s.name, ErrInvalidFormat)
+		s.offset = s.consumed()
+		s.setSyntaxError(s.name, ErrInvalidFormat)
 		return errExist
 	}
 	s.data = s.data[len(b):]
@@ -94,6 +232,8 @@ func (s *decodeState[T]) removePrefixBytes(b []byte) error {
 func (f *structFields[T]) decode(s *decodeState[T], v reflect.Value, unwrap bool) (err error) {
 	var sep bool
 
+	depth := len(s.fieldPath)
+
 	if unwrap {
 		if err = s.removePrefixBytes(s.structOpener); err != nil {
 			return
@@ -101,12 +241,28 @@ func (f *structFields[T]) decode(s *decodeState[T], v reflect.Value, unwrap bool
 	}
 
 	for _, s.field = range *f {
-		if s.data = bytes.TrimRightFunc(s.data, func(r rune) bool {
-			return r == 0x00
-		}); len(s.data) == 0 || unwrap && bytes.HasPrefix(s.data, s.structCloser) {
+		if len(s.data) == 0 || unwrap && bytes.HasPrefix(s.data, s.structCloser) {
+			if s.strict {
+				if r := f.from(s.field).firstRequired(v); r != nil {
+					s.fieldPath = append(s.fieldPath[:depth], r.name)
+					s.offset = s.consumed()
+					s.setSyntaxError(s.name, ErrTruncated)
+					return errExist
+				}
+			}
+			s.requireFields(f.from(s.field), v, s.fieldPath[:depth:depth])
 			break
 		}
 
+		// Skip the field with its separator if it's absent.
+		if s.field.presence != nil && !s.field.presence.present(v) {
+			continue
+		}
+
+		if s.fieldPath = s.fieldPath[:depth]; s.field.embedded == nil {
+			s.fieldPath = append(s.fieldPath, s.field.name)
+		}
+
 		if sep {
 			if err = s.removePrefixBytes(s.valueSeparator); err != nil {
 				return
@@ -114,7 +270,6 @@ func (f *structFields[T]) decode(s *decodeState[T], v reflect.Value, unwrap bool
 		}
 		sep = s.removeSeparator
 
-		s.Reset()
 		rv := v.Field(s.field.index)
 
 		if s.field.embedded != nil {
@@ -132,15 +287,33 @@ func (f *structFields[T]) decode(s *decodeState[T], v reflect.Value, unwrap bool
 			continue
 		}
 
-		s.structName = v.Type().Name()
-		if err = s.field.functions.decoderFunc(s, rv); err != nil {
+		s.offset = s.consumed()
+		s.blank = -1
+		if s.field.count != nil {
+			err = countedSliceDecoder(s, rv, v.Field(s.field.count.index))
+		} else {
+			err = s.field.functions.decoderFunc(s, rv)
+		}
+		if err != nil {
 			return
 		}
+		// Set the default value if the value of the field itself is empty, not an element or a field of it.
+		if s.blank == s.nesting && s.field.def.IsValid() {
+			s.field.setDefault(rv)
+		}
 	}
 
+	s.fieldPath = s.fieldPath[:depth]
+
 	if unwrap {
-		i := bytes.Index(s.data, s.structCloser)
-		s.data = s.data[i:]
+		if s.strict && len(s.data) != 0 && !bytes.HasPrefix(s.data, s.structCloser) {
+			s.trailingData()
+			return errExist
+		}
+		// Skip the data of the fields that the struct doesn't have.
+		if i := bytes.Index(s.data, s.structCloser); i > 0 {
+			s.data = s.data[i:]
+		}
 		if err = s.removePrefixBytes(s.structCloser); err != nil {
 			return
 		}
@@ -149,6 +322,52 @@ func (f *structFields[T]) decode(s *decodeState[T], v reflect.Value, unwrap bool
 	return
 }
 
+// setDefault sets v to the default value of the field, a pointer field gets its own copy of the value it points to.
+func (f *field[T]) setDefault(v reflect.Value) {
+	def := f.def
+	if def.Kind() == reflect.Pointer {
+		def = reflect.New(def.Type().Elem())
+		def.Elem().Set(f.def.Elem())
+	}
+	v.Set(def)
+}
+
+// requireFields records the required fields f present in the struct v the data ended before, the path leads to the struct.
+// The fields of nested structs are recorded too, unless they are decoded by a method or a registered function.
+func (s *decodeState[T]) requireFields(f structFields[T], v reflect.Value, path []string) {
+	f.each(v, func(fl *field[T]) bool {
+		fieldPath := append(path[:len(path):len(path)], fl.name)
+		if fl.required {
+			s.missing = append(s.missing, s.indexedPath(fieldPath))
+		} else if fl.typ.Kind() == reflect.Struct && fl.typ != decimalType {
+			if _, dec := s.codersOf(fl.typ); dec == KindCoder {
+				s.requireFields(s.cachedFields(fl.typ), v.Field(fl.index), fieldPath)
+			}
+		}
+		return true
+	})
+}
+
+// indexedPath joins the names of the path with the index of the current element of every list in it.
+func (s *decodeState[T]) indexedPath(path []string) string {
+	var b strings.Builder
+	l := 0
+	for depth := 0; depth <= len(path); depth++ {
+		if depth > 0 {
+			if b.Len() != 0 {
+				b.WriteByte('.')
+			}
+			b.WriteString(path[depth-1])
+		}
+		for ; l < len(s.lists) && s.lists[l].depth == depth; l++ {
+			b.WriteByte('[')
+			b.WriteString(strconv.Itoa(s.lists[l].index))
+			b.WriteByte(']')
+		}
+	}
+	return b.String()
+}
+
 func unmarshalerDecoder[T any](s *decodeState[T], v reflect.Value) error {
 	rv := reflect.New(v.Type())
 
@@ -157,14 +376,27 @@ func unmarshalerDecoder[T any](s *decodeState[T], v reflect.Value) error {
 		return nil
 	}
 
-	if err := s.Decode(s.field.name, s.field.tag, s.data, s); err != nil {
+	p, err := s.value()
+	if err != nil || len(p) == 0 {
 		return err
 	}
-	if s.Len() == 0 {
-		return nil
+
+	if err = f(p); err != nil {
+		return err
+	}
+
+	v.Set(rv.Elem())
+	return nil
+}
+
+func textUnmarshalerDecoder[T any](s *decodeState[T], v reflect.Value) error {
+	p, err := s.value()
+	if err != nil || len(p) == 0 {
+		return err
 	}
 
-	if err := f(s.Bytes()); err != nil {
+	rv := reflect.New(v.Type())
+	if err = rv.Interface().(encoding.TextUnmarshaler).UnmarshalText(p); err != nil {
 		return err
 	}
 
@@ -172,69 +404,337 @@ func unmarshalerDecoder[T any](s *decodeState[T], v reflect.Value) error {
 	return nil
 }
 
+func customDecoder[T, V any](fn func(string, *T, []byte) (V, error)) decoderFunc[T] {
+	return func(s *decodeState[T], v reflect.Value) error {
+		p, err := s.value()
+		if err != nil || len(p) == 0 {
+			return err
+		}
+
+		r, err := fn(s.field.name, s.field.tag, p)
+		if err != nil {
+			return err
+		}
+
+		v.Set(reflect.ValueOf(&r).Elem())
+		return nil
+	}
+}
+
 func boolDecoder[T any](s *decodeState[T], v reflect.Value) error {
-	if err := s.Decode(s.field.name, s.field.tag, s.data, s); err != nil {
+	if order := s.byteOrderOf(s.field); order != nil {
+		u, err := s.binary(order, 1)
+		if err != nil {
+			return err
+		}
+		r, err := parseBinaryBool(u)
+		v.SetBool(r)
 		return err
 	}
-	if s.Len() == 0 {
-		return nil
+
+	p, err := s.value()
+	if err != nil || len(p) == 0 {
+		return err
 	}
-	r, err := strconv.ParseBool(s.String())
+	r, err := strconv.ParseBool(string(p))
 	v.SetBool(r)
 	return err
 }
 
 func intDecoder[T any](s *decodeState[T], v reflect.Value) error {
-	if err := s.Decode(s.field.name, s.field.tag, s.data, s); err != nil {
-		return err
-	}
-	if s.Len() == 0 {
+	if order := s.byteOrderOf(s.field); order != nil {
+		size := bitSize(v.Kind()) / 8
+		u, err := s.binary(order, size)
+		if err != nil {
+			return err
+		}
+		v.SetInt(signed(u, size))
 		return nil
 	}
-	r, err := strconv.ParseInt(s.String(), 10, bitSize(v.Kind()))
+
+	p, err := s.number()
+	if err != nil || len(p) == 0 {
+		return err
+	}
+	r, err := s.formatOf(s.field).ParseInt(string(p), bitSize(v.Kind()))
 	v.SetInt(r)
 	return err
 }
 
 func uintDecoder[T any](s *decodeState[T], v reflect.Value) error {
-	if err := s.Decode(s.field.name, s.field.tag, s.data, s); err != nil {
-		return err
-	}
-	if s.Len() == 0 {
+	if order := s.byteOrderOf(s.field); order != nil {
+		u, err := s.binary(order, bitSize(v.Kind())/8)
+		if err != nil {
+			return err
+		}
+		v.SetUint(u)
 		return nil
 	}
-	r, err := strconv.ParseUint(s.String(), 10, bitSize(v.Kind()))
+
+	p, err := s.number()
+	if err != nil || len(p) == 0 {
+		return err
+	}
+	r, err := s.formatOf(s.field).ParseUint(string(p), bitSize(v.Kind()))
 	v.SetUint(r)
 	return err
 }
 
 func floatDecoder[T any](s *decodeState[T], v reflect.Value) error {
-	if err := s.Decode(s.field.name, s.field.tag, s.data, s); err != nil {
-		return err
-	}
-	if s.Len() == 0 {
+	if order := s.byteOrderOf(s.field); order != nil {
+		size := bitSize(v.Kind()) / 8
+		u, err := s.binary(order, size)
+		if err != nil {
+			return err
+		}
+		if size == 4 {
+			v.SetFloat(float64(math.Float32frombits(uint32(u))))
+		} else {
+			v.SetFloat(math.Float64frombits(u))
+		}
 		return nil
 	}
-	r, err := strconv.ParseFloat(s.String(), bitSize(v.Kind()))
+
+	p, err := s.number()
+	if err != nil || len(p) == 0 {
+		return err
+	}
+	r, err := s.formatOf(s.field).ParseFloat(string(p), bitSize(v.Kind()))
 	v.SetFloat(r)
 	return err
 }
 
-//func arrayDecoder[T any](s *decodeState[T], v reflect.Value) error {
-//	return nil
-//}
+// endOf reports whether there are no more elements of the current list or map in the data.
+func (s *decodeState[T]) endOf(unwrap bool, closer []byte) bool {
+	if len(s.data) == 0 {
+		return true
+	}
+	if unwrap && len(closer) != 0 {
+		return bytes.HasPrefix(s.data, closer)
+	}
+	return s.removeWrapper && bytes.HasPrefix(s.data, s.structCloser)
+}
+
+// decodeList decodes the elements of a slice or an array one by one,
+// each element is received from the Decode function with the tag of the current field.
+// If limit is negative, the number of elements is unlimited.
+func (s *decodeState[T]) decodeList(limit int, elem func(i int) reflect.Value) (n int, err error) {
+	if err = s.enter(s.maxDepth); err != nil {
+		return
+	}
+	defer s.leave()
+
+	f := s.field
+
+	l := len(s.lists)
+	s.lists = append(s.lists, listIndex{depth: len(s.fieldPath)})
+	defer func() { s.lists = s.lists[:l] }()
+
+	if s.unwrapList {
+		if err = s.removePrefixBytes(s.listOpener); err != nil {
+			return
+		}
+	}
+
+	for ; limit < 0 || n < limit; n++ {
+		if s.endOf(s.unwrapList, s.listCloser) {
+			break
+		}
+
+		// An element that consumes no input ends the list, otherwise the same input would be decoded forever.
+		left := len(s.data)
+		if n > 0 && s.removeElemSep {
+			if err = s.removePrefixBytes(s.elemSeparator); err != nil {
+				return
+			}
+		}
+
+		s.field = f
+		s.lists[l].index = n
+		if err = s.reflectValue(elem(n)); err != nil {
+			return
+		}
+		if len(s.data) == left {
+			break
+		}
+	}
+
+	if s.unwrapList {
+		if err = s.removePrefixBytes(s.listCloser); err != nil {
+			return
+		}
+	}
+
+	s.field = f
+	return
+}
+
+func arrayDecoder[T any](s *decodeState[T], v reflect.Value) error {
+	n, err := s.decodeList(v.Len(), v.Index)
+	if err != nil {
+		return err
+	}
+
+	// Zero the remaining elements if there is less data than the array length.
+	z := reflect.Zero(v.Type().Elem())
+	for ; n < v.Len(); n++ {
+		v.Index(n).Set(z)
+	}
+
+	return nil
+}
 
 func interfaceDecoder[T any](s *decodeState[T], v reflect.Value) error {
-	if v.IsNil() {
-		s.err = ErrNilInterface
-		return errExist
+	if !v.IsNil() {
+		return s.reflectValue(v.Elem())
 	}
-	return s.reflectValue(v.Elem())
+
+	if s.discriminator == nil {
+		return ErrNilInterface
+	}
+
+	d, n, err := s.discriminator.Discriminate(s.field.name, s.field.tag, s.input())
+	if err != nil {
+		return err
+	}
+	if err = s.advance(n); err != nil {
+		return err
+	}
+
+	t, ok := s.registeredType(d)
+	if !ok {
+		return fmt.Errorf("%w %q", ErrUnknownType, d)
+	}
+	if !t.Implements(v.Type()) {
+		return fmt.Errorf("%w: %s does not implement %s", ErrNotSupportType, t, v.Type())
+	}
+
+	// Allocate the registered type, if it's a pointer, allocate the value it points to.
+	var rv reflect.Value
+	if t.Kind() == reflect.Pointer {
+		rv = reflect.New(t.Elem())
+		err = s.reflectValue(rv.Elem())
+	} else {
+		rv = reflect.New(t).Elem()
+		err = s.reflectValue(rv)
+	}
+	if err != nil {
+		return err
+	}
+
+	v.Set(rv)
+	return nil
+}
+
+// decodeKey finds a map key in the data and stores it into a new value of type t.
+func (s *decodeState[T]) decodeKey(t reflect.Type) (reflect.Value, error) {
+	var p []byte
+	if s.keyCoder != nil {
+		var (
+			n   int
+			err error
+		)
+		if p, n, err = s.keyCoder.DecodeKey(s.field.name, s.field.tag, s.input()); err != nil {
+			return reflect.Value{}, err
+		}
+		if err = s.advance(n); err != nil {
+			return reflect.Value{}, err
+		}
+	} else {
+		i := bytes.Index(s.data, s.kvSeparator)
+		if len(s.kvSeparator) == 0 || i < 0 {
+			s.offset = s.consumed()
+			s.setSyntaxError(s.name, ErrInvalidFormat)
+			return reflect.Value{}, errExist
+		}
+		p, s.data = s.data[:i], s.data[i:]
+		if s.charset != nil {
+			p = s.charset.Decode(nil, p)
+		}
+	}
+
+	k := reflect.New(t).Elem()
+	if t.Kind() == reflect.String {
+		k.SetString(string(p))
+		return k, nil
+	}
+	if tu, ok := k.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return k, tu.UnmarshalText(p)
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		r, err := strconv.ParseInt(string(p), 10, bitSize(t.Kind()))
+		k.SetInt(r)
+		return k, err
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		r, err := strconv.ParseUint(string(p), 10, bitSize(t.Kind()))
+		k.SetUint(r)
+		return k, err
+	}
+	return k, ErrNotSupportType
 }
 
-//func mapDecoder[T any](s *decodeState[T], v reflect.Value) error {
-//	return nil
-//}
+// mapDecoder decodes key-value pairs into a map,
+// each value is received from the Decode function with the tag of the current field.
+func mapDecoder[T any](s *decodeState[T], v reflect.Value) error {
+	if err := s.enter(s.maxDepth); err != nil {
+		return err
+	}
+	defer s.leave()
+
+	f := s.field
+	t := v.Type()
+
+	if s.unwrapMap {
+		if err := s.removePrefixBytes(s.mapOpener); err != nil {
+			return err
+		}
+	}
+
+	for n := 0; !s.endOf(s.unwrapMap, s.mapCloser); n++ {
+		// A pair that consumes no input ends the map, as an element does in decodeList.
+		left := len(s.data)
+		if n > 0 && s.removePairSep {
+			if err := s.removePrefixBytes(s.pairSeparator); err != nil {
+				return err
+			}
+		}
+
+		s.field = f
+		k, err := s.decodeKey(t.Key())
+		if err != nil {
+			return err
+		}
+
+		if s.removeKVSep {
+			if err = s.removePrefixBytes(s.kvSeparator); err != nil {
+				return err
+			}
+		}
+
+		e := reflect.New(t.Elem()).Elem()
+		if err = s.reflectValue(e); err != nil {
+			return err
+		}
+		if len(s.data) == left {
+			break
+		}
+
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(t))
+		}
+		v.SetMapIndex(k, e)
+	}
+
+	if s.unwrapMap {
+		if err := s.removePrefixBytes(s.mapCloser); err != nil {
+			return err
+		}
+	}
+
+	s.field = f
+	return nil
+}
 
 func pointerDecoder[T any](s *decodeState[T], v reflect.Value) error {
 	if v.IsNil() {
@@ -251,44 +751,156 @@ func pointerDecoder[T any](s *decodeState[T], v reflect.Value) error {
 }
 
 func bytesDecoder[T any](s *decodeState[T], v reflect.Value) error {
-	if err := s.Decode(s.field.name, s.field.tag, s.data, s); err != nil {
+	p, err := s.value()
+	if err != nil || len(p) == 0 {
 		return err
 	}
-	if s.Len() == 0 {
-		return nil
+	v.SetBytes(append([]byte(nil), p...))
+	return nil
+}
+
+func sliceDecoder[T any](s *decodeState[T], v reflect.Value) error {
+	z := reflect.Zero(v.Type().Elem())
+
+	n, err := s.decodeList(-1, func(i int) reflect.Value {
+		if i < v.Len() {
+			v.Index(i).Set(z)
+		} else {
+			v.Set(reflect.Append(v, z))
+		}
+		return v.Index(i)
+	})
+	if err != nil {
+		return err
+	}
+
+	if !v.IsNil() {
+		v.SetLen(n)
 	}
-	v.SetBytes(s.Bytes())
+
 	return nil
 }
 
-//func sliceDecoder[T any](s *decodeState[T], v reflect.Value) error {
-//	return nil
-//}
+// countedSliceDecoder decodes exactly as many elements of the slice v as the integer count holds.
+func countedSliceDecoder[T any](s *decodeState[T], v, count reflect.Value) error {
+	var want uint64
+	if count.CanInt() {
+		if c := count.Int(); c < 0 {
+			return fmt.Errorf("negative count %d of elements", c)
+		}
+		want = uint64(count.Int())
+	} else {
+		want = count.Uint()
+	}
 
-func stringDecoder[T any](s *decodeState[T], v reflect.Value) error {
-	if err := s.Decode(s.field.name, s.field.tag, s.data, s); err != nil {
+	// The number of elements is also limited by the data, decoding stops at its end.
+	limit := math.MaxInt32
+	if want < uint64(limit) {
+		limit = int(want)
+	}
+
+	z := reflect.Zero(v.Type().Elem())
+	v.Set(reflect.Zero(v.Type()))
+
+	n, err := s.decodeList(limit, func(int) reflect.Value {
+		v.Set(reflect.Append(v, z))
+		return v.Index(v.Len() - 1)
+	})
+	if err != nil {
 		return err
 	}
-	if s.Len() == 0 {
-		return nil
+	// An element that consumed no input was appended but isn't counted.
+	v.SetLen(n)
+	if uint64(n) != want {
+		s.offset = s.consumed()
+		return fmt.Errorf("found %d elements out of %d", n, want)
 	}
-	v.SetString(s.String())
+
 	return nil
 }
 
+func stringDecoder[T any](s *decodeState[T], v reflect.Value) error {
+	p, err := s.value()
+	if err != nil || len(p) == 0 {
+		return err
+	}
+	v.SetString(string(p))
+	return nil
+}
+
+func decimalDecoder[T any](s *decodeState[T], v reflect.Value) error {
+	p, err := s.number()
+	if err != nil || len(p) == 0 {
+		return err
+	}
+	r, err := s.formatOf(s.field).ParseDecimal(string(p))
+	v.Set(reflect.ValueOf(r))
+	return err
+}
+
 func structDecoder[T any](s *decodeState[T], v reflect.Value) error {
+	if err := s.enter(s.maxDepth); err != nil {
+		return err
+	}
+	defer s.leave()
+
+	if len(s.fieldPath) == 0 {
+		s.structName = v.Type().Name()
+	}
 	f := s.cachedFields(v.Type())
 	return f.decode(s, v, s.removeWrapper)
 }
 
-func unsupportedTypeDecoder[T any](s *decodeState[T], _ reflect.Value) error {
-	s.err = ErrNotSupportType
-	return errExist
+// ParseDefault parses the text of a default value into the value pointed to by v.
+// Bools, numbers and strings are parsed by their kind, numbers in base 10, Decimal with the decimal point
+// and other types with their UnmarshalText method.
+func ParseDefault(value string, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("ParseDefault(non-pointer %T)", v)
+	}
+	rv = rv.Elem()
+
+	switch rv.Kind() {
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		rv.SetBool(b)
+		return err
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(value, 10, rv.Type().Bits())
+		rv.SetInt(i)
+		return err
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, err := strconv.ParseUint(value, 10, rv.Type().Bits())
+		rv.SetUint(u)
+		return err
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, rv.Type().Bits())
+		rv.SetFloat(f)
+		return err
+	case reflect.String:
+		rv.SetString(value)
+		return nil
+	}
+
+	if rv.Type() == decimalType {
+		d, err := NumberFormat{}.ParseDecimal(value)
+		rv.Set(reflect.ValueOf(d))
+		return err
+	}
+	if u, ok := v.(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(value))
+	}
+	return fmt.Errorf("cannot set a default value of type %s", rv.Type())
+}
+
+func unsupportedTypeDecoder[T any](*decodeState[T], reflect.Value) error {
+	return ErrNotSupportType
 }
 
 func invalidTagDecoder[T any](tag string, err error) decoderFunc[T] {
 	return func(s *decodeState[T], _ reflect.Value) error {
-		s.err = fmt.Errorf("%s: tag %s of struct field %s.%s: %w", s.name, tag, s.structName, s.field.name, err)
+		s.setTagError(s.name, tag, err)
 		return errExist
 	}
 }
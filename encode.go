@@ -2,11 +2,13 @@ package oxygen
 
 import (
 	"bytes"
+	"encoding"
 	"errors"
 	"fmt"
+	"math"
 	"reflect"
+	"sort"
 	"strconv"
-	"sync"
 )
 
 const marshalError = "encode data from"
@@ -15,7 +17,7 @@ const marshalError = "encode data from"
 // If v is nil, Marshal returns an encoder error.
 func (e *engine[T]) Marshal(v any) ([]byte, error) {
 	s := e.newEncodeState()
-	defer encodeStatePool.Put(s)
+	defer e.encodeStatePool.Put(s)
 
 	if s.marshal(v); s.err != nil {
 		return nil, s.err
@@ -31,11 +33,12 @@ type encodeState[T any] struct {
 	scratch       [64]byte
 }
 
-var encodeStatePool sync.Pool
-
 func (e *engine[T]) newEncodeState() *encodeState[T] {
-	if p := encodeStatePool.Get(); p != nil {
+	if p := e.encodeStatePool.Get(); p != nil {
 		s := p.(*encodeState[T])
+		s.structName = ""
+		s.fieldPath = s.fieldPath[:0]
+		s.nesting = 0
 		s.field = new(field[T])
 		s.err = nil
 		s.Reset()
@@ -48,12 +51,17 @@ func (e *engine[T]) newEncodeState() *encodeState[T] {
 }
 
 func (s *encodeState[T]) marshal(v any) {
+	if s.cfgErr != nil {
+		s.err = s.cfgErr
+		return
+	}
+
 	if err := s.reflectValue(reflect.ValueOf(v)); err != nil {
 		if !errors.Is(err, errExist) {
 			if s.field.typ == nil {
 				s.field.typ = unPoint(reflect.TypeOf(v))
 			}
-			s.setError(s.name, marshalError, err)
+			s.setMarshalError(s.name, err)
 		}
 		s.Reset()
 	}
@@ -63,6 +71,31 @@ func (s *encodeState[T]) reflectValue(v reflect.Value) error {
 	return s.cachedCoders(v.Type()).encoderFunc(s, v)
 }
 
+// encode writes the value p of the current field with the Encode function.
+func (s *encodeState[T]) encode(p []byte) error {
+	start := s.Len()
+	return s.transcode(start, s.Encode(s.field.name, s.field.tag, p, s.Buffer))
+}
+
+// encodeNumber is like encode but packed decimals aren't transcoded to the charset.
+func (s *encodeState[T]) encodeNumber(p []byte) error {
+	if s.formatOf(s.field).Encoding == PackedDecimal {
+		return s.Encode(s.field.name, s.field.tag, p, s.Buffer)
+	}
+	return s.encode(p)
+}
+
+// transcode transcodes the data written since the start to the charset, if it's set and err is nil.
+func (s *encodeState[T]) transcode(start int, err error) error {
+	if err != nil || s.charset == nil {
+		return err
+	}
+	p := s.Bytes()[start:]
+	p, err = s.charset.Encode(p[:0], p)
+	s.Truncate(start + len(p))
+	return err
+}
+
 type encoderFunc[T any] func(*encodeState[T], reflect.Value) error
 
 func valueFromPtr(v reflect.Value) reflect.Value {
@@ -78,6 +111,8 @@ func valueFromPtr(v reflect.Value) reflect.Value {
 func (f *structFields[T]) encode(s *encodeState[T], v reflect.Value, wrap bool) (err error) {
 	var sep bool
 
+	depth := len(s.fieldPath)
+
 	if wrap {
 		s.Write(s.structOpener)
 	}
@@ -85,7 +120,30 @@ func (f *structFields[T]) encode(s *encodeState[T], v reflect.Value, wrap bool)
 	for _, s.field = range *f {
 		rv := v.Field(s.field.index)
 
-		// Ignore the field if empty values can be omitted.
+		if s.field.length != nil {
+			// The field holds the number of elements of a slice, it's written from the length of the slice.
+			if rv, err = lengthOf(rv.Type(), v.Field(s.field.length.index).Len()); err != nil {
+				s.fieldPath = append(s.fieldPath[:depth], s.field.name)
+				return
+			}
+		}
+
+		if s.field.encodeDef && rv.IsZero() {
+			rv = s.field.def
+		}
+
+		if c := s.field.count; c != nil && c.length != s.field {
+			// The slice shares the count field with a slice before it.
+			if n, want := rv.Len(), v.Field(c.length.index).Len(); n != want {
+				s.fieldPath = append(s.fieldPath[:depth], s.field.name)
+				return fmt.Errorf("length %d of the slice differs from the count %d", n, want)
+			}
+		}
+
+		// Ignore the field if it's absent or empty values can be omitted.
+		if s.field.presence != nil && !s.field.presence.present(v) {
+			continue
+		}
 		if s.field.omitempty && isEmptyValue(rv) {
 			continue
 		}
@@ -96,18 +154,21 @@ func (f *structFields[T]) encode(s *encodeState[T], v reflect.Value, wrap bool)
 		sep = s.separate
 
 		if s.field.embedded != nil {
+			s.fieldPath = s.fieldPath[:depth]
 			if err = s.field.embedded.encode(s, valueFromPtr(rv), false); err != nil {
 				return
 			}
 			continue
 		}
 
-		s.structName = v.Type().Name()
+		s.fieldPath = append(s.fieldPath[:depth], s.field.name)
 		if err = s.field.functions.encoderFunc(s, rv); err != nil {
 			return
 		}
 	}
 
+	s.fieldPath = s.fieldPath[:depth]
+
 	if wrap {
 		s.Write(s.structCloser)
 	}
@@ -115,6 +176,23 @@ func (f *structFields[T]) encode(s *encodeState[T], v reflect.Value, wrap bool)
 	return
 }
 
+// lengthOf returns the length n of a slice as a value of the integer type t.
+func lengthOf(t reflect.Type, n int) (reflect.Value, error) {
+	v := reflect.New(t).Elem()
+	if v.CanInt() {
+		if v.OverflowInt(int64(n)) {
+			return v, fmt.Errorf("length %d of the slice overflows the count", n)
+		}
+		v.SetInt(int64(n))
+		return v, nil
+	}
+	if v.OverflowUint(uint64(n)) {
+		return v, fmt.Errorf("length %d of the slice overflows the count", n)
+	}
+	v.SetUint(uint64(n))
+	return v, nil
+}
+
 func marshallerEncoder[T any](s *encodeState[T], v reflect.Value) error {
 	tmp := reflect.ValueOf(v.Interface())
 	v = reflect.New(v.Type())
@@ -130,28 +208,120 @@ func marshallerEncoder[T any](s *encodeState[T], v reflect.Value) error {
 		return err
 	}
 
-	return s.Encode(s.field.name, s.field.tag, p, s.Buffer)
+	return s.encode(p)
+}
+
+func textMarshalerEncoder[T any](s *encodeState[T], v reflect.Value) error {
+	rv := reflect.New(v.Type())
+	rv.Elem().Set(v)
+
+	p, err := rv.Interface().(encoding.TextMarshaler).MarshalText()
+	if err != nil {
+		return err
+	}
+
+	return s.encode(p)
+}
+
+func customEncoder[T, V any](fn func(string, *T, V) ([]byte, error)) encoderFunc[T] {
+	return func(s *encodeState[T], v reflect.Value) error {
+		i, _ := v.Interface().(V)
+		p, err := fn(s.field.name, s.field.tag, i)
+		if err != nil {
+			return err
+		}
+		return s.encode(p)
+	}
 }
 
 func boolEncoder[T any](s *encodeState[T], v reflect.Value) error {
-	return s.Encode(s.field.name, s.field.tag, strconv.AppendBool(s.scratch[:0], v.Bool()), s.Buffer)
+	if order := s.byteOrderOf(s.field); order != nil {
+		var b uint64
+		if v.Bool() {
+			b = 1
+		}
+		s.Write(AppendBinary(s.scratch[:0], order, b, 1))
+		return nil
+	}
+	return s.encode(strconv.AppendBool(s.scratch[:0], v.Bool()))
 }
 
 func intEncoder[T any](s *encodeState[T], v reflect.Value) error {
-	return s.Encode(s.field.name, s.field.tag, strconv.AppendInt(s.scratch[:0], v.Int(), 10), s.Buffer)
+	if order := s.byteOrderOf(s.field); order != nil {
+		s.Write(AppendBinary(s.scratch[:0], order, uint64(v.Int()), bitSize(v.Kind())/8))
+		return nil
+	}
+	f := s.formatOf(s.field)
+	if err := f.CheckInt(v.Int()); err != nil {
+		return err
+	}
+	return s.encodeNumber(f.AppendInt(s.scratch[:0], v.Int()))
 }
 
 func uintEncoder[T any](s *encodeState[T], v reflect.Value) error {
-	return s.Encode(s.field.name, s.field.tag, strconv.AppendUint(s.scratch[:0], v.Uint(), 10), s.Buffer)
+	if order := s.byteOrderOf(s.field); order != nil {
+		s.Write(AppendBinary(s.scratch[:0], order, v.Uint(), bitSize(v.Kind())/8))
+		return nil
+	}
+	f := s.formatOf(s.field)
+	if err := f.CheckUint(v.Uint()); err != nil {
+		return err
+	}
+	return s.encodeNumber(f.AppendUint(s.scratch[:0], v.Uint()))
 }
 
 func floatEncoder[T any](s *encodeState[T], v reflect.Value) error {
-	return s.Encode(s.field.name, s.field.tag, strconv.AppendFloat(s.scratch[:0], v.Float(), 'g', -1, bitSize(v.Kind())), s.Buffer)
+	if order := s.byteOrderOf(s.field); order != nil {
+		bits := math.Float64bits(v.Float())
+		if v.Kind() == reflect.Float32 {
+			bits = uint64(math.Float32bits(float32(v.Float())))
+		}
+		s.Write(AppendBinary(s.scratch[:0], order, bits, bitSize(v.Kind())/8))
+		return nil
+	}
+	f := s.formatOf(s.field)
+	if err := f.CheckFloat(v.Float()); err != nil {
+		return err
+	}
+	return s.encodeNumber(f.AppendFloat(s.scratch[:0], v.Float(), bitSize(v.Kind())))
 }
 
-//func arrayEncoder[T any](s *encodeState[T], v reflect.Value) error {
-//	return nil
-//}
+// encodeList encodes the elements of a slice or an array one by one,
+// each element is passed to the Encode function with the tag of the current field.
+func (s *encodeState[T]) encodeList(v reflect.Value) error {
+	if err := s.enter(s.maxDepth); err != nil {
+		return err
+	}
+	defer s.leave()
+
+	f := s.field
+
+	if s.wrapList {
+		s.Write(s.listOpener)
+	}
+
+	for i := 0; i < v.Len(); i++ {
+		if i > 0 && s.separateElems {
+			s.Write(s.elemSeparator)
+		}
+
+		s.field = f
+		if err := s.reflectValue(v.Index(i)); err != nil {
+			return err
+		}
+	}
+
+	if s.wrapList {
+		s.Write(s.listCloser)
+	}
+
+	s.field = f
+	return nil
+}
+
+func arrayEncoder[T any](s *encodeState[T], v reflect.Value) error {
+	return s.encodeList(v)
+}
 
 func interfaceEncoder[T any](s *encodeState[T], v reflect.Value) error {
 	if v.IsNil() {
@@ -160,39 +330,138 @@ func interfaceEncoder[T any](s *encodeState[T], v reflect.Value) error {
 	return s.reflectValue(v.Elem())
 }
 
-//func mapEncoder[T any](s *encodeState[T], v reflect.Value) error {
-//	return nil
-//}
+type mapPair struct {
+	key   []byte
+	value reflect.Value
+}
+
+// resolveKey returns the encoded representation of a map key.
+func resolveKey(k reflect.Value) ([]byte, error) {
+	if k.Kind() == reflect.String {
+		return []byte(k.String()), nil
+	}
+	if tm, ok := k.Interface().(encoding.TextMarshaler); ok {
+		if k.Kind() == reflect.Pointer && k.IsNil() {
+			return nil, nil
+		}
+		return tm.MarshalText()
+	}
+	switch k.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.AppendInt(nil, k.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.AppendUint(nil, k.Uint(), 10), nil
+	}
+	return nil, ErrNotSupportType
+}
+
+func (s *encodeState[T]) encodeKey(k []byte) error {
+	start := s.Len()
+	if s.keyCoder != nil {
+		return s.transcode(start, s.keyCoder.EncodeKey(s.field.name, s.field.tag, k, s.Buffer))
+	}
+	_, err := s.Write(k)
+	return s.transcode(start, err)
+}
+
+// mapEncoder encodes the key-value pairs of a map sorted by their encoded keys,
+// each value is passed to the Encode function with the tag of the current field.
+func mapEncoder[T any](s *encodeState[T], v reflect.Value) error {
+	if err := s.enter(s.maxDepth); err != nil {
+		return err
+	}
+	defer s.leave()
+
+	f := s.field
+
+	pairs := make([]mapPair, 0, v.Len())
+	for it := v.MapRange(); it.Next(); {
+		k, err := resolveKey(it.Key())
+		if err != nil {
+			return err
+		}
+		pairs = append(pairs, mapPair{key: k, value: it.Value()})
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		return bytes.Compare(pairs[i].key, pairs[j].key) < 0
+	})
+
+	if s.wrapMap {
+		s.Write(s.mapOpener)
+	}
+
+	for i, p := range pairs {
+		if i > 0 && s.separatePairs {
+			s.Write(s.pairSeparator)
+		}
+
+		s.field = f
+		if err := s.encodeKey(p.key); err != nil {
+			return err
+		}
+
+		if s.separateKV {
+			s.Write(s.kvSeparator)
+		}
+
+		if err := s.reflectValue(p.value); err != nil {
+			return err
+		}
+	}
+
+	if s.wrapMap {
+		s.Write(s.mapCloser)
+	}
+
+	s.field = f
+	return nil
+}
 
 func pointerEncoder[T any](s *encodeState[T], v reflect.Value) error {
 	return s.reflectValue(valueFromPtr(v))
 }
 
 func bytesEncoder[T any](s *encodeState[T], v reflect.Value) error {
-	return s.Encode(s.field.name, s.field.tag, v.Bytes(), s.Buffer)
+	return s.encode(v.Bytes())
 }
 
-//func sliceEncoder[T any](s *encodeState[T], v reflect.Value) error {
-//	return nil
-//}
+func sliceEncoder[T any](s *encodeState[T], v reflect.Value) error {
+	return s.encodeList(v)
+}
 
 func stringEncoder[T any](s *encodeState[T], v reflect.Value) error {
-	return s.Encode(s.field.name, s.field.tag, append(s.scratch[:0], v.String()...), s.Buffer)
+	return s.encode(append(s.scratch[:0], v.String()...))
+}
+
+func decimalEncoder[T any](s *encodeState[T], v reflect.Value) error {
+	d := Decimal{Value: v.Field(0).Int(), Scale: int(v.Field(1).Int())}
+	f := s.formatOf(s.field)
+	if err := f.CheckDecimal(d); err != nil {
+		return err
+	}
+	return s.encodeNumber(f.AppendDecimal(s.scratch[:0], d))
 }
 
 func structEncoder[T any](s *encodeState[T], v reflect.Value) error {
+	if err := s.enter(s.maxDepth); err != nil {
+		return err
+	}
+	defer s.leave()
+
+	if len(s.fieldPath) == 0 {
+		s.structName = v.Type().Name()
+	}
 	f := s.cachedFields(v.Type())
 	return f.encode(s, reflect.ValueOf(v.Interface()), s.wrap)
 }
 
-func unsupportedTypeEncoder[T any](s *encodeState[T], _ reflect.Value) error {
-	s.err = ErrNotSupportType
-	return errExist
+func unsupportedTypeEncoder[T any](*encodeState[T], reflect.Value) error {
+	return ErrNotSupportType
 }
 
 func invalidTagEncoder[T any](tag string, err error) encoderFunc[T] {
 	return func(s *encodeState[T], _ reflect.Value) error {
-		s.err = fmt.Errorf("%s: tag %s of struct field %s.%s: %w", s.name, tag, s.structName, s.field.name, err)
-		return nil
+		s.setTagError(s.name, tag, err)
+		return errExist
 	}
 }
@@ -1,6 +1,10 @@
 package oxygen
 
 import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
 	"reflect"
 	"sync"
 )
@@ -11,6 +15,18 @@ type Engine interface {
 	Marshal(v any) ([]byte, error)
 	// Unmarshal decodes the encoded data and stores the result in the value pointed to by v.
 	Unmarshal(data []byte, v any) error
+	// NewEncoder returns a new encoder that writes records to w.
+	NewEncoder(w io.Writer) Encoder
+	// NewDecoder returns a new decoder that reads records from r.
+	NewDecoder(r io.Reader) Decoder
+	// Register records the type of the value v under the discriminator,
+	// so that it can be decoded into nil interface values.
+	// If the Tag doesn't implement the Discriminator interface, registered types are not used.
+	Register(discriminator string, v any)
+	// NewRecordSet returns a new empty set of record types told apart by a leading code.
+	NewRecordSet() RecordSet
+	// Describe returns the layout of the struct type of the value v, or of the struct v points to.
+	Describe(v any) (Layout, error)
 }
 
 type Writer interface {
@@ -30,9 +46,12 @@ type Tag[T any] interface {
 	// Encode takes encoded data and performs secondary encoding.
 	// It's a mandatory function.
 	Encode(fieldName string, tag *T, in []byte, out Writer) error
-	// Decode takes the raw encoded data and performs a primary decode.
+	// Decode takes the remaining raw encoded data, finds the value of the field and performs a primary decode.
+	// It returns the decoded value and the number of bytes of the input the value occupies,
+	// the engine continues decoding the next field after these bytes.
+	// Decode must not modify the input, it may return a subslice of it.
 	// It's a mandatory function.
-	Decode(fieldName string, tag *T, in []byte, out Writer) error
+	Decode(fieldName string, tag *T, in []byte) (value []byte, n int, err error)
 	// IsMarshaller attempts to cast the value to a Marshaller interface,
 	// if so, returns a marshal function.
 	IsMarshaller(v reflect.Value) (func() ([]byte, error), bool)
@@ -59,35 +78,312 @@ type Config struct {
 	ValueSeparator []byte
 	// RemoveSeparatorWhenDecoding this flag tells the library whether to remove the ValueSeparator.
 	RemoveSeparatorWhenDecoding bool
+	// ListOpener a byte array that denotes the beginning of a slice or an array.
+	// Will be automatically added when encoding.
+	ListOpener []byte
+	// ListCloser a byte array that denotes the end of a slice or an array.
+	// Will be automatically added when encoding.
+	ListCloser []byte
+	// UnwrapListWhenDecoding this flag tells the library whether to remove the ListOpener and ListCloser bytes of a list.
+	UnwrapListWhenDecoding bool
+	// ElementSeparator a byte array separating elements of a slice or an array.
+	// Will be automatically added when encoding.
+	ElementSeparator []byte
+	// RemoveElementSeparatorWhenDecoding this flag tells the library whether to remove the ElementSeparator.
+	RemoveElementSeparatorWhenDecoding bool
+	// MapOpener a byte array that denotes the beginning of a map.
+	// Will be automatically added when encoding.
+	MapOpener []byte
+	// MapCloser a byte array that denotes the end of a map.
+	// Will be automatically added when encoding.
+	MapCloser []byte
+	// UnwrapMapWhenDecoding this flag tells the library whether to remove the MapOpener and MapCloser bytes of a map.
+	UnwrapMapWhenDecoding bool
+	// KeyValueSeparator a byte array separating a key from a value of a map.
+	// Will be automatically added when encoding.
+	KeyValueSeparator []byte
+	// RemoveKeyValueSeparatorWhenDecoding this flag tells the library whether to remove the KeyValueSeparator.
+	RemoveKeyValueSeparatorWhenDecoding bool
+	// PairSeparator a byte array separating key-value pairs of a map.
+	// Will be automatically added when encoding.
+	PairSeparator []byte
+	// RemovePairSeparatorWhenDecoding this flag tells the library whether to remove the PairSeparator.
+	RemovePairSeparatorWhenDecoding bool
+	// RecordTerminator a byte array that denotes the end of a record in a stream.
+	// Will be automatically added by an Encoder, a Decoder uses it to split a stream into records.
+	RecordTerminator []byte
+	// DisableTextMarshaler this flag tells the library not to use encoding.TextMarshaler and encoding.TextUnmarshaler
+	// for types that don't implement the Marshaller and Unmarshaler interfaces.
+	DisableTextMarshaler bool
+	// NumberFormat describes how integers and floats are written as text, the zero value writes them as strconv does
+	// by default. Use the NumberFormatter interface to override it per field.
+	NumberFormat NumberFormat
+	// Primitives selects whether bools and numbers are written as text or as fixed-size binary values.
+	// The Encode and Decode functions aren't called for binary values, since their size is known.
+	// Use the PrimitivesSelector interface to override it per field.
+	Primitives Primitives
+	// ByteOrder of binary values, binary.BigEndian is used if it's nil.
+	ByteOrder binary.ByteOrder
+	// Charset of the encoded data, nil means UTF-8. Values are transcoded to it after the Encode function
+	// and from it before the Decode function, the delimiters and the RecordTerminator are written in it too.
+	// Binary values and packed decimals aren't transcoded.
+	Charset *Charset
+	// MaxDepth limits the nesting of structs, lists and maps, encoding or decoding a deeper value returns ErrMaxDepth.
+	// Zero means no limit.
+	MaxDepth int
+	// Strict makes decoding fail with a SyntaxError wrapping ErrTrailingData if data is left after the value
+	// or after the last field of a struct, and wrapping ErrTruncated if the data ends before a field
+	// that is present and cannot be omitted when empty.
+	Strict bool
 	// Marshaller is used to check if a type implements a type of the Marshaller interface.
 	Marshaller reflect.Type
 	// Unmarshaler is used to check if a type implements a type of the Unmarshaler interface.
 	Unmarshaler reflect.Type
 }
 
+// KeyCoder describes what functions an entity should implement to encode and decode map keys by itself.
+// It's an optional interface, if the Tag doesn't implement it,
+// keys are written as is and read up to the KeyValueSeparator.
+type KeyCoder[T any] interface {
+	// EncodeKey takes an encoded map key and performs secondary encoding.
+	EncodeKey(fieldName string, tag *T, in []byte, out Writer) error
+	// DecodeKey takes the remaining raw encoded data, finds a map key and performs a primary decode.
+	// It returns the decoded key and the number of bytes of the input the key occupies like Decode does.
+	DecodeKey(fieldName string, tag *T, in []byte) (key []byte, n int, err error)
+}
+
+// NumberFormatter describes what function an entity should implement to override Config.NumberFormat per field.
+// It's an optional interface, it's called once for every field with a tag when the fields of a struct type are scanned.
+type NumberFormatter[T any] interface {
+	// NumberFormat returns the format of numbers of the field, ok is false if the field uses Config.NumberFormat.
+	NumberFormat(fieldName string, tag *T) (format NumberFormat, ok bool)
+}
+
+// PrimitivesSelector describes what function an entity should implement to override Config.Primitives per field.
+// It's an optional interface, it's called once for every field with a tag when the fields of a struct type are scanned.
+type PrimitivesSelector[T any] interface {
+	// Primitives returns the primitives of the field and their byte order, binary.BigEndian is used if the order is nil.
+	// It returns false if the field uses Config.Primitives.
+	Primitives(fieldName string, tag *T) (p Primitives, order binary.ByteOrder, ok bool)
+}
+
+// CountBinder describes what function an entity should implement to bind the length of a slice field
+// to a sibling integer field, as the COBOL OCCURS DEPENDING ON clause does.
+// It's an optional interface, it's called once for every field with a tag when the fields of a struct type are scanned.
+type CountBinder[T any] interface {
+	// CountField returns the name of the integer field declared before the slice field in the same struct
+	// that holds the number of its elements, ok is false if the length of the field isn't bound.
+	// Decoding reads exactly that number of elements, encoding writes the length of the slice into the count field.
+	// Several slices can share a count field, then their lengths must be equal when they are encoded.
+	CountField(fieldName string, tag *T) (name string, ok bool)
+}
+
+// PresenceSelector describes what function an entity should implement to include a field in the data
+// only when a condition on the struct holds, for example a currency code only for foreign payments.
+// It's an optional interface, it's called once for every field with a tag when the fields of a struct type are scanned.
+type PresenceSelector[T any] interface {
+	// Presence returns the condition of the presence of the field, ok is false if the field is always present.
+	// An absent field is skipped with its separator when encoding and decoding.
+	Presence(fieldName string, tag *T) (p Presence, ok bool)
+}
+
+// RequiredSelector describes what function an entity should implement to mark fields that must have data.
+// It's an optional interface, it's called once for every field with a tag when the fields of a struct type are scanned.
+type RequiredSelector[T any] interface {
+	// Required reports whether the field must have data, Unmarshal returns a RequiredFieldError
+	// listing every present required field the data ended before, in nested structs too.
+	Required(fieldName string, tag *T) bool
+}
+
+// Defaulter describes what function an entity should implement to set default values of fields.
+// It's an optional interface, it's called once for every field with a tag when the fields of a struct type are scanned.
+type Defaulter[T any] interface {
+	// DefaultValue returns the text of the default value of the field, ok is false if the field has no default value.
+	// The text is parsed with ParseDefault into the type of the field, or the type a pointer field points to.
+	// The value is set when the data of the field is blank and it's written instead of the zero value of the field
+	// if encode is true. Fields the data ended before keep their zero values.
+	DefaultValue(fieldName string, tag *T) (value string, encode, ok bool)
+}
+
+// Discriminator describes what function an entity should implement to decode into nil interface values.
+// It's an optional interface, if the Tag doesn't implement it, decoding into a nil interface returns an error.
+type Discriminator[T any] interface {
+	// Discriminate takes the remaining raw encoded data and returns the discriminator of the type registered with Engine.Register.
+	// It also returns the number of bytes of the input the discriminator occupies,
+	// it must be zero if the discriminator is a part of the value.
+	Discriminate(fieldName string, tag *T, in []byte) (discriminator string, n int, err error)
+}
+
 // New returns a new entity that implements the Engine interface.
 func New[T any](tag Tag[T], cfg Config) Engine {
+	keyCoder, _ := tag.(KeyCoder[T])
+	discriminator, _ := tag.(Discriminator[T])
+	numberFormatter, _ := tag.(NumberFormatter[T])
+	primitivesSelector, _ := tag.(PrimitivesSelector[T])
+	countBinder, _ := tag.(CountBinder[T])
+	presenceSelector, _ := tag.(PresenceSelector[T])
+	requiredSelector, _ := tag.(RequiredSelector[T])
+	defaulter, _ := tag.(Defaulter[T])
+
+	// New cannot return an error, an invalid configuration is reported by every call of the engine.
+	var cfgErr error
+	if err := cfg.validateFormats(); err != nil {
+		cfgErr = fmt.Errorf("%s: %w: %v", cfg.Name, ErrInvalidConfig, err)
+	}
+
 	return &engine[T]{
 		Tag:             tag,
+		keyCoder:        keyCoder,
+		discriminator:   discriminator,
+		numberFormatter: numberFormatter,
+		primitives:      primitivesSelector,
+		countBinder:     countBinder,
+		presence:        presenceSelector,
+		required:        requiredSelector,
+		defaulter:       defaulter,
 		name:            cfg.Name,
 		wrap:            len(cfg.StructOpener) != 0 || len(cfg.StructCloser) != 0,
 		removeWrapper:   (len(cfg.StructOpener) != 0 || len(cfg.StructCloser) != 0) && cfg.UnwrapWhenDecoding,
 		separate:        len(cfg.ValueSeparator) != 0,
 		removeSeparator: len(cfg.ValueSeparator) != 0 && cfg.RemoveSeparatorWhenDecoding,
-		structOpener:    cfg.StructOpener,
-		structCloser:    cfg.StructCloser,
-		valueSeparator:  cfg.ValueSeparator,
+		structOpener:    cfg.Charset.delimiter(cfg.StructOpener),
+		structCloser:    cfg.Charset.delimiter(cfg.StructCloser),
+		valueSeparator:  cfg.Charset.delimiter(cfg.ValueSeparator),
+		wrapList:        len(cfg.ListOpener) != 0 || len(cfg.ListCloser) != 0,
+		unwrapList:      (len(cfg.ListOpener) != 0 || len(cfg.ListCloser) != 0) && cfg.UnwrapListWhenDecoding,
+		separateElems:   len(cfg.ElementSeparator) != 0,
+		removeElemSep:   len(cfg.ElementSeparator) != 0 && cfg.RemoveElementSeparatorWhenDecoding,
+		listOpener:      cfg.Charset.delimiter(cfg.ListOpener),
+		listCloser:      cfg.Charset.delimiter(cfg.ListCloser),
+		elemSeparator:   cfg.Charset.delimiter(cfg.ElementSeparator),
+		wrapMap:         len(cfg.MapOpener) != 0 || len(cfg.MapCloser) != 0,
+		unwrapMap:       (len(cfg.MapOpener) != 0 || len(cfg.MapCloser) != 0) && cfg.UnwrapMapWhenDecoding,
+		separateKV:      len(cfg.KeyValueSeparator) != 0,
+		removeKVSep:     len(cfg.KeyValueSeparator) != 0 && cfg.RemoveKeyValueSeparatorWhenDecoding,
+		separatePairs:   len(cfg.PairSeparator) != 0,
+		removePairSep:   len(cfg.PairSeparator) != 0 && cfg.RemovePairSeparatorWhenDecoding,
+		mapOpener:       cfg.Charset.delimiter(cfg.MapOpener),
+		mapCloser:       cfg.Charset.delimiter(cfg.MapCloser),
+		kvSeparator:     cfg.Charset.delimiter(cfg.KeyValueSeparator),
+		pairSeparator:   cfg.Charset.delimiter(cfg.PairSeparator),
+		terminator:      cfg.Charset.delimiter(cfg.RecordTerminator),
+		charset:         cfg.Charset,
+		numberFormat:    cfg.NumberFormat,
+		byteOrder:       newByteOrder(cfg.Primitives, cfg.ByteOrder),
+		maxDepth:        cfg.MaxDepth,
+		strict:          cfg.Strict,
 		marshaller:      cfg.Marshaller,
 		unmarshaler:     cfg.Unmarshaler,
+		textMarshaler:   !cfg.DisableTextMarshaler,
+		cfgErr:          cfgErr,
 	}
 }
 
 type engine[T any] struct {
 	Tag[T]
-	name                                           string
-	wrap, removeWrapper, separate, removeSeparator bool
-	structOpener, structCloser, valueSeparator     []byte
-	marshaller, unmarshaler                        reflect.Type
+	keyCoder                                           KeyCoder[T]
+	discriminator                                      Discriminator[T]
+	numberFormatter                                    NumberFormatter[T]
+	primitives                                         PrimitivesSelector[T]
+	countBinder                                        CountBinder[T]
+	presence                                           PresenceSelector[T]
+	required                                           RequiredSelector[T]
+	defaulter                                          Defaulter[T]
+	name                                               string
+	wrap, removeWrapper, separate, removeSeparator     bool
+	structOpener, structCloser, valueSeparator         []byte
+	wrapList, unwrapList, separateElems, removeElemSep bool
+	listOpener, listCloser, elemSeparator              []byte
+	wrapMap, unwrapMap, separateKV, removeKVSep        bool
+	separatePairs, removePairSep                       bool
+	mapOpener, mapCloser, kvSeparator, pairSeparator   []byte
+	terminator                                         []byte
+	numberFormat                                       NumberFormat
+	byteOrder                                          byteOrder
+	charset                                            *Charset
+	maxDepth                                           int
+	textMarshaler, strict                              bool
+	marshaller, unmarshaler                            reflect.Type
+	cfgErr                                             error // error of the configuration, returned by every call
+
+	typeRegistry    sync.Map // map[string]reflect.Type
+	customCoders    sync.Map // map[reflect.Type]*registered[T]
+	predicates      sync.Map // map[string]predicate
+	coderCache      sync.Map // map[reflect.Type]*coders[T]
+	fieldCache      sync.Map // map[reflect.Type]structFields[T]
+	encodeStatePool sync.Pool
+	decodeStatePool sync.Pool
+}
+
+// Register records the type of the value v under the discriminator,
+// so that it can be decoded into nil interface values.
+// Register panics if v is nil or the discriminator is already registered.
+func (e *engine[T]) Register(discriminator string, v any) {
+	if v == nil {
+		panic(e.name + ": attempt to register nil type")
+	}
+	if _, dup := e.typeRegistry.LoadOrStore(discriminator, reflect.TypeOf(v)); dup {
+		panic(e.name + ": registering duplicate discriminator " + discriminator)
+	}
+}
+
+// registeredType returns the type recorded under the discriminator.
+func (e *engine[T]) registeredType(discriminator string) (reflect.Type, bool) {
+	t, ok := e.typeRegistry.Load(discriminator)
+	if !ok {
+		return nil, false
+	}
+	return t.(reflect.Type), true
+}
+
+// RegisterType registers functions to encode and decode values of type V with the engine,
+// it's useful for types that cannot implement the Marshaller and Unmarshaler interfaces.
+// The encode function performs a primary encoding of a value, the result is passed to Tag.Encode.
+// The decode function receives data found by Tag.Decode and returns the decoded value,
+// it must copy the data if it wishes to retain the data after returning.
+// If one of the functions is nil, the engine encodes or decodes the type as usual.
+// Registered functions take priority over all other ways to encode and decode the type.
+// RegisterType panics if the engine wasn't created by New with the tag type T.
+func RegisterType[T, V any](e Engine, encode func(fieldName string, tag *T, v V) ([]byte, error), decode func(fieldName string, tag *T, data []byte) (V, error)) {
+	en, ok := e.(*engine[T])
+	if !ok {
+		panic(fmt.Sprintf("oxygen: RegisterType with an engine of type %T", e))
+	}
+
+	c := &registered[T]{encode: encode, decode: decode}
+	if encode != nil {
+		c.encoderFunc = customEncoder[T](encode)
+	}
+	if decode != nil {
+		c.decoderFunc = customDecoder[T](decode)
+	}
+
+	t := reflect.TypeOf((*V)(nil)).Elem()
+	en.customCoders.Store(t, c)
+
+	// Drop the cached coders that may have been created before the registration.
+	en.coderCache.Delete(t)
+	en.fieldCache.Range(func(k, _ any) bool {
+		en.fieldCache.Delete(k)
+		return true
+	})
+}
+
+// RegisteredType returns the functions registered by RegisterType to encode and decode values of type V
+// with the engine, a function is nil if it isn't registered. The generated codec uses them as the engine does.
+// RegisteredType panics if the engine wasn't created by New with the tag type T.
+func RegisteredType[T, V any](e Engine) (encode func(fieldName string, tag *T, v V) ([]byte, error), decode func(fieldName string, tag *T, data []byte) (V, error)) {
+	en, ok := e.(*engine[T])
+	if !ok {
+		panic(fmt.Sprintf("oxygen: RegisteredType with an engine of type %T", e))
+	}
+
+	c, ok := en.customCoders.Load(reflect.TypeOf((*V)(nil)).Elem())
+	if !ok {
+		return nil, nil
+	}
+	r := c.(*registered[T])
+	return r.encode.(func(string, *T, V) ([]byte, error)), r.decode.(func(string, *T, []byte) (V, error))
 }
 
 type coders[T any] struct {
@@ -95,15 +391,19 @@ type coders[T any] struct {
 	decoderFunc[T]
 }
 
-var coderCache sync.Map // map[reflect.Type]*coders[T]
+// registered holds the functions registered by RegisterType for a type and the coders calling them.
+type registered[T any] struct {
+	coders[T]
+	encode, decode any
+}
 
 // cachedCoders is like typeCoders but uses a cache to avoid repeated work.
 func (e *engine[T]) cachedCoders(t reflect.Type) *coders[T] {
-	if c, ok := coderCache.Load(t); ok {
+	if c, ok := e.coderCache.Load(t); ok {
 		return c.(*coders[T])
 	}
 
-	c, _ := coderCache.LoadOrStore(t, e.typeCoders(t))
+	c, _ := e.coderCache.LoadOrStore(t, e.typeCoders(t))
 	return c.(*coders[T])
 }
 
@@ -123,15 +423,21 @@ func (e *engine[T]) typeCoders(t reflect.Type) *coders[T] {
 	case reflect.Float32, reflect.Float64:
 		f.encoderFunc = floatEncoder[T]
 		f.decoderFunc = floatDecoder[T]
-	//case reflect.Array:
-	//	f.encoderFunc = arrayEncoder[T]
-	//	f.decoderFunc = arrayDecoder[T]
+	case reflect.Array:
+		f.encoderFunc = arrayEncoder[T]
+		f.decoderFunc = arrayDecoder[T]
 	case reflect.Interface:
 		f.encoderFunc = interfaceEncoder[T]
 		f.decoderFunc = interfaceDecoder[T]
-	//case reflect.Map:
-	//	f.encoderFunc = mapEncoder[T]
-	//	f.decoderFunc = mapDecoder[T])
+	case reflect.Map:
+		f.encoderFunc = unsupportedTypeEncoder[T]
+		f.decoderFunc = unsupportedTypeDecoder[T]
+		if isEncodableKey(t.Key()) {
+			f.encoderFunc = mapEncoder[T]
+		}
+		if isDecodableKey(t.Key()) {
+			f.decoderFunc = mapDecoder[T]
+		}
 	case reflect.Pointer:
 		f.encoderFunc = pointerEncoder[T]
 		f.decoderFunc = pointerDecoder[T]
@@ -140,15 +446,29 @@ func (e *engine[T]) typeCoders(t reflect.Type) *coders[T] {
 			f.encoderFunc = bytesEncoder[T]
 			f.decoderFunc = bytesDecoder[T]
 		} else {
-			f.encoderFunc = unsupportedTypeEncoder[T]
-			f.decoderFunc = unsupportedTypeDecoder[T]
+			f.encoderFunc = sliceEncoder[T]
+			f.decoderFunc = sliceDecoder[T]
 		}
 	case reflect.String:
 		f.encoderFunc = stringEncoder[T]
 		f.decoderFunc = stringDecoder[T]
 	case reflect.Struct:
-		f.encoderFunc = structEncoder[T]
-		f.decoderFunc = structDecoder[T]
+		if t == decimalType {
+			f.encoderFunc = decimalEncoder[T]
+			f.decoderFunc = decimalDecoder[T]
+		} else {
+			f.encoderFunc = structEncoder[T]
+			f.decoderFunc = structDecoder[T]
+
+			// Hooks run around the fields of the struct.
+			p := reflect.PointerTo(t)
+			if p.Implements(beforeMarshalerType) {
+				f.encoderFunc = beforeMarshalEncoder[T]
+			}
+			if p.Implements(afterUnmarshalerType) || p.Implements(validatorType) {
+				f.decoderFunc = afterUnmarshalDecoder[T]
+			}
+		}
 	default:
 		f.encoderFunc = unsupportedTypeEncoder[T]
 		f.decoderFunc = unsupportedTypeDecoder[T]
@@ -158,9 +478,24 @@ func (e *engine[T]) typeCoders(t reflect.Type) *coders[T] {
 		p := reflect.PointerTo(t)
 		if p.Implements(e.marshaller) {
 			f.encoderFunc = marshallerEncoder[T]
+		} else if e.textMarshaler && p.Implements(textMarshalerType) {
+			f.encoderFunc = textMarshalerEncoder[T]
 		}
 		if p.Implements(e.unmarshaler) {
 			f.decoderFunc = unmarshalerDecoder[T]
+		} else if e.textMarshaler && p.Implements(textUnmarshalerType) {
+			f.decoderFunc = textUnmarshalerDecoder[T]
+		}
+	}
+
+	// Functions registered by RegisterType take priority.
+	if c, ok := e.customCoders.Load(t); ok {
+		c := c.(*registered[T])
+		if c.encoderFunc != nil {
+			f.encoderFunc = c.encoderFunc
+		}
+		if c.decoderFunc != nil {
+			f.decoderFunc = c.decoderFunc
 		}
 	}
 
@@ -174,20 +509,100 @@ type field[T any] struct {
 	typ       reflect.Type
 	tag       *T
 	omitempty bool
+	required  bool
+	format    *NumberFormat // nil if the field uses the format of the engine
+	byteOrder *byteOrder    // nil if the field uses the primitives of the engine
+	count     *field[T]     // field holding the number of elements of the slice, nil if the length isn't bound
+	length    *field[T]     // first slice field whose length the field holds, nil if it doesn't hold a length
+	presence  *presence     // nil if the field is always present
+	err       error         // error of the tag, the coders of the field report it
+	def       reflect.Value // default value, invalid if the field has none
+	encodeDef bool          // the default value is written instead of the zero value
 	functions *coders[T]
 	embedded  structFields[T]
 }
 
 type structFields[T any] []*field[T]
 
-var fieldCache sync.Map // map[reflect.Type]structFields[T]
+// countField returns the integer field with the name declared before the slice field of the type t
+// and binds the length of the slice to it.
+func (f structFields[T]) countField(name string, t reflect.Type) (*field[T], error) {
+	if t.Kind() != reflect.Slice || t.Elem().Kind() == reflect.Uint8 {
+		return nil, errors.New("only the length of a slice can be bound")
+	}
+	for _, c := range f {
+		if c.name == name && c.embedded == nil && isInteger(c.typ.Kind()) {
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("no integer field %s precedes the field", name)
+}
+
+// from returns the fields starting with the field fl.
+func (f structFields[T]) from(fl *field[T]) structFields[T] {
+	for i := range f {
+		if f[i] == fl {
+			return f[i:]
+		}
+	}
+	return nil
+}
+
+// each calls fn for the fields present in the struct v, the fields of embedded structs included,
+// it stops and returns false when fn returns false.
+func (f structFields[T]) each(v reflect.Value, fn func(*field[T]) bool) bool {
+	for _, fl := range f {
+		if fl.embedded != nil {
+			if !fl.embedded.each(valueFromPtr(v.Field(fl.index)), fn) {
+				return false
+			}
+			continue
+		}
+		if fl.presence != nil && !fl.presence.present(v) {
+			continue
+		}
+		if !fn(fl) {
+			return false
+		}
+	}
+	return true
+}
+
+// firstRequired returns the first of the fields present in the struct v that cannot be omitted when empty,
+// nil if there is no such field.
+func (f structFields[T]) firstRequired(v reflect.Value) (r *field[T]) {
+	f.each(v, func(fl *field[T]) bool {
+		if fl.omitempty {
+			return true
+		}
+		r = fl
+		return false
+	})
+	return
+}
+
+// formatOf returns the format of numbers of the field.
+func (e *engine[T]) formatOf(f *field[T]) *NumberFormat {
+	if f.format != nil {
+		return f.format
+	}
+	return &e.numberFormat
+}
+
+// byteOrderOf returns the byte order of binary primitives of the field, nil if primitives are written as text.
+func (e *engine[T]) byteOrderOf(f *field[T]) binary.ByteOrder {
+	if f.byteOrder != nil {
+		return f.byteOrder.ByteOrder
+	}
+	return e.byteOrder.ByteOrder
+}
 
 // cachedFields is like typeFields but uses a cache to avoid repeated work.
 func (e *engine[T]) cachedFields(t reflect.Type) structFields[T] {
-	if c, ok := fieldCache.Load(t); ok {
+	if c, ok := e.fieldCache.Load(t); ok {
 		return c.(structFields[T])
 	}
-	c, _ := fieldCache.LoadOrStore(t, e.typeFields(t))
+	c, _ := e.fieldCache.LoadOrStore(t, e.typeFields(t))
 	return c.(structFields[T])
 }
 
@@ -239,12 +654,56 @@ func (e *engine[T]) typeFields(t reflect.Type) structFields[T] {
 			}
 
 			f.tag = new(T)
-			if f.omitempty, err = e.Parse(tag, f.tag); err != nil {
+			if f.omitempty, err = e.Parse(tag, f.tag); err == nil && e.numberFormatter != nil {
+				if nf, ok := e.numberFormatter.NumberFormat(sf.Name, f.tag); ok {
+					f.format, err = &nf, nf.Validate()
+				}
+			}
+			if err == nil && e.primitives != nil {
+				if p, order, ok := e.primitives.Primitives(sf.Name, f.tag); ok {
+					if err = p.validate(nil); err == nil {
+						bo := newByteOrder(p, order)
+						f.byteOrder = &bo
+					}
+				}
+			}
+			if err == nil && e.countBinder != nil {
+				if name, ok := e.countBinder.CountField(sf.Name, f.tag); ok {
+					if f.count, err = fs.countField(name, ft); err == nil && f.count.length == nil {
+						f.count.length = f
+					}
+				}
+			}
+			if err == nil && e.presence != nil {
+				if p, ok := e.presence.Presence(sf.Name, f.tag); ok {
+					f.presence, err = e.presenceOf(fs, t, p)
+				}
+			}
+			if err == nil && e.required != nil {
+				f.required = e.required.Required(sf.Name, f.tag)
+			}
+			if err == nil && e.defaulter != nil {
+				if value, encode, ok := e.defaulter.DefaultValue(sf.Name, f.tag); ok {
+					// The default value of a pointer field is parsed into the value it points to.
+					def := reflect.New(ft).Elem()
+					p := def.Addr()
+					if ft.Kind() == reflect.Pointer {
+						p = reflect.New(ft.Elem())
+						def.Set(p)
+					}
+					if err = ParseDefault(value, p.Interface()); err == nil {
+						f.def, f.encodeDef = def, encode
+					}
+				}
+			}
+			if err != nil {
+				f.err = err
 				f.functions = &coders[T]{
 					encoderFunc: invalidTagEncoder[T](tag, err),
 					decoderFunc: invalidTagDecoder[T](tag, err),
 				}
-				return append(fs, f)
+				fs = append(fs, f)
+				continue
 			}
 		}
 
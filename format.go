@@ -0,0 +1,311 @@
+package oxygen
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// NumberFormat describes how numbers are written.
+// The zero value writes integers in base 10 and floats with the 'g' verb and the smallest precision necessary.
+type NumberFormat struct {
+	// Base of integers from 2 to 36, zero means 10.
+	Base int
+	// Verb of floats as in strconv.FormatFloat: 'e', 'E', 'f', 'g', 'G', 'x' or 'X'.
+	// Zero means 'g' with the smallest precision necessary.
+	Verb byte
+	// Precision of floats as in strconv.FormatFloat, it's used only if Verb is set.
+	Precision int
+	// ImpliedDecimals is the number of decimal places of floats written without the decimal point,
+	// for example 12.5 is written as 1250 with two implied decimals. Verb and Precision are ignored if it's set.
+	ImpliedDecimals int
+	// Encoding of the digits, packed and zoned decimals are written in base 10 with ImpliedDecimals,
+	// Base, Verb and Precision are ignored for them.
+	Encoding NumberEncoding
+	// Digits is the number of digits of packed and zoned decimals, they are padded with leading zeros
+	// and numbers with more digits cannot be written.
+	Digits int
+}
+
+// Validate reports whether the format can be used.
+func (f NumberFormat) Validate() error {
+	if f.Base != 0 && (f.Base < 2 || f.Base > 36) {
+		return fmt.Errorf("invalid base %d", f.Base)
+	}
+
+	switch f.Verb {
+	case 0, 'e', 'E', 'f', 'g', 'G', 'x', 'X':
+	default:
+		return fmt.Errorf("invalid float verb %q", f.Verb)
+	}
+
+	if f.ImpliedDecimals < 0 {
+		return fmt.Errorf("invalid number of implied decimals %d", f.ImpliedDecimals)
+	}
+	// Numbers with implied decimals are written in base 10.
+	if f.ImpliedDecimals > 0 && f.Encoding == TextNumber && f.base() != 10 {
+		return fmt.Errorf("implied decimals cannot be written in base %d", f.Base)
+	}
+
+	switch f.Encoding {
+	case TextNumber, PackedDecimal, ZonedDecimal:
+	default:
+		return fmt.Errorf("invalid number encoding %d", f.Encoding)
+	}
+
+	if f.Digits < 0 {
+		return fmt.Errorf("invalid number of digits %d", f.Digits)
+	}
+
+	return nil
+}
+
+func (f NumberFormat) base() int {
+	if f.Base == 0 {
+		return 10
+	}
+	return f.Base
+}
+
+// AppendInt appends the text form of the integer i to dst, CheckInt reports the integers it cannot write.
+func (f NumberFormat) AppendInt(dst []byte, i int64) []byte {
+	if f.Encoding != TextNumber {
+		return f.appendDigits(dst, i, true)
+	}
+	return strconv.AppendInt(dst, i, f.base())
+}
+
+// AppendUint appends the text form of the unsigned integer u to dst, CheckUint reports the integers it cannot write.
+func (f NumberFormat) AppendUint(dst []byte, u uint64) []byte {
+	if f.Encoding != TextNumber {
+		return f.appendMagnitude(dst, u, false, false)
+	}
+	return strconv.AppendUint(dst, u, f.base())
+}
+
+// CheckInt returns an error if AppendInt cannot write the integer i:
+// if it has more digits than Digits when it's written as a packed or a zoned decimal.
+func (f NumberFormat) CheckInt(i int64) error {
+	return f.checkDigits(magnitude(i), i)
+}
+
+// CheckUint returns an error if AppendUint cannot write the unsigned integer u:
+// if it has more digits than Digits when it's written as a packed or a zoned decimal.
+func (f NumberFormat) CheckUint(u uint64) error {
+	return f.checkDigits(u, u)
+}
+
+// AppendFloat appends the text form of the float v of the bitSize to dst, CheckFloat reports the floats it cannot write.
+func (f NumberFormat) AppendFloat(dst []byte, v float64, bitSize int) []byte {
+	switch {
+	case f.Encoding != TextNumber:
+		return f.appendDigits(dst, int64(math.Round(v*f.scale())), true)
+	case f.ImpliedDecimals > 0:
+		return appendImplied(dst, v, f.ImpliedDecimals, bitSize)
+	case f.Verb == 0:
+		return strconv.AppendFloat(dst, v, 'g', -1, bitSize)
+	default:
+		return strconv.AppendFloat(dst, v, f.Verb, f.Precision, bitSize)
+	}
+}
+
+// CheckFloat returns an error if AppendFloat cannot write the float v: if it isn't finite or if it has more digits
+// than Digits when it's written as a packed or a zoned decimal. Any float can be written as text.
+func (f NumberFormat) CheckFloat(v float64) error {
+	if f.Encoding == TextNumber {
+		return nil
+	}
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return fmt.Errorf("cannot write %v as a decimal: %w", v, strconv.ErrRange)
+	}
+	r := math.Abs(math.Round(v * f.scale()))
+	if r >= 1<<63 {
+		return fmt.Errorf("cannot write %v as a decimal: %w", v, strconv.ErrRange)
+	}
+	if f.Digits > 0 && f.Digits < 19 && r >= math.Pow10(f.Digits) {
+		return fmt.Errorf("cannot write %v as a decimal of %d digits: %w", v, f.Digits, strconv.ErrRange)
+	}
+	return nil
+}
+
+// ParseInt interprets the text form of an integer of the bitSize.
+func (f NumberFormat) ParseInt(s string, bitSize int) (int64, error) {
+	if f.Encoding != TextNumber {
+		return f.parseDigitsInt(s, bitSize)
+	}
+	return strconv.ParseInt(s, f.base(), bitSize)
+}
+
+// ParseUint interprets the text form of an unsigned integer of the bitSize.
+func (f NumberFormat) ParseUint(s string, bitSize int) (uint64, error) {
+	if f.Encoding != TextNumber {
+		return f.parseDigitsUint(s, bitSize)
+	}
+	return strconv.ParseUint(s, f.base(), bitSize)
+}
+
+// ParseFloat interprets the text form of a float of the bitSize.
+func (f NumberFormat) ParseFloat(s string, bitSize int) (float64, error) {
+	if f.Encoding != TextNumber {
+		i, err := f.parseDigitsInt(s, 64)
+		if err != nil {
+			err.(*strconv.NumError).Func = "ParseFloat"
+			return 0, err
+		}
+		v := float64(i) / f.scale()
+		if bitSize == 32 {
+			v = float64(float32(v))
+		}
+		return v, nil
+	}
+	if f.ImpliedDecimals > 0 {
+		p, ok := insertPoint(s, f.ImpliedDecimals)
+		if !ok {
+			return 0, &strconv.NumError{Func: "ParseFloat", Num: s, Err: strconv.ErrSyntax}
+		}
+		s = p
+	}
+	return strconv.ParseFloat(s, bitSize)
+}
+
+// appendImplied appends v with d decimal places without the decimal point and leading zeros.
+func appendImplied(dst []byte, v float64, d, bitSize int) []byte {
+	start := len(dst)
+	dst = strconv.AppendFloat(dst, v, 'f', d, bitSize)
+
+	p := bytes.IndexByte(dst[start:], '.')
+	if p < 0 {
+		// NaN and infinities.
+		return dst
+	}
+	p += start
+	dst = append(dst[:p], dst[p+1:]...)
+
+	i := start
+	if dst[i] == '-' {
+		i++
+	}
+	j := i
+	for j < len(dst)-1 && dst[j] == '0' {
+		j++
+	}
+	return append(dst[:i], dst[j:]...)
+}
+
+// insertPoint inserts the decimal point before the last d digits of s.
+func insertPoint(s string, d int) (string, bool) {
+	var sign string
+	if len(s) != 0 && (s[0] == '-' || s[0] == '+') {
+		sign, s = s[:1], s[1:]
+	}
+
+	if len(s) == 0 {
+		return "", false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return "", false
+		}
+	}
+
+	if len(s) <= d {
+		s = strings.Repeat("0", d-len(s)+1) + s
+	}
+	return sign + s[:len(s)-d] + "." + s[len(s)-d:], true
+}
+
+// Primitives selects how bools and numbers are written.
+type Primitives int
+
+const (
+	// Text writes bools and numbers as text, it's the default.
+	Text Primitives = iota
+	// Binary writes bools and numbers as fixed-size values in a byte order,
+	// the size of a number is the size of its type, a bool takes one byte.
+	Binary
+)
+
+func (p Primitives) validate(order binary.ByteOrder) error {
+	switch {
+	case p != Text && p != Binary:
+		return fmt.Errorf("invalid primitives %d", p)
+	case p == Text && order != nil:
+		return errors.New("a byte order requires binary primitives")
+	}
+	return nil
+}
+
+// byteOrder is the byte order of binary primitives, a nil ByteOrder means text primitives.
+type byteOrder struct {
+	binary.ByteOrder
+}
+
+func newByteOrder(p Primitives, order binary.ByteOrder) byteOrder {
+	if p != Binary {
+		return byteOrder{}
+	}
+	if order == nil {
+		order = binary.BigEndian
+	}
+	return byteOrder{order}
+}
+
+// AppendBinary appends the lowest size bytes of u to dst in the byte order, size is 1, 2, 4 or 8.
+func AppendBinary(dst []byte, order binary.ByteOrder, u uint64, size int) []byte {
+	var b [8]byte
+	switch size {
+	case 1:
+		b[0] = byte(u)
+	case 2:
+		order.PutUint16(b[:], uint16(u))
+	case 4:
+		order.PutUint32(b[:], uint32(u))
+	default:
+		order.PutUint64(b[:], u)
+	}
+	return append(dst, b[:size]...)
+}
+
+// ParseBinary returns the unsigned integer written in p in the byte order, the length of p is 1, 2, 4 or 8.
+func ParseBinary(order binary.ByteOrder, p []byte) uint64 {
+	switch len(p) {
+	case 1:
+		return uint64(p[0])
+	case 2:
+		return uint64(order.Uint16(p))
+	case 4:
+		return uint64(order.Uint32(p))
+	default:
+		return order.Uint64(p)
+	}
+}
+
+// signed returns the signed integer of the size bytes stored in u.
+func signed(u uint64, size int) int64 {
+	switch size {
+	case 1:
+		return int64(int8(u))
+	case 2:
+		return int64(int16(u))
+	case 4:
+		return int64(int32(u))
+	default:
+		return int64(u)
+	}
+}
+
+// parseBinaryBool returns the bool written as the byte b.
+func parseBinaryBool(b uint64) (bool, error) {
+	switch b {
+	case 0:
+		return false, nil
+	case 1:
+		return true, nil
+	default:
+		return false, fmt.Errorf("invalid binary bool %#x", b)
+	}
+}
@@ -0,0 +1,91 @@
+package oxygen
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// BeforeMarshaler is implemented by struct types that normalize their value before their fields are encoded.
+// BeforeMarshal is called on a copy of the value, the value being encoded isn't changed.
+type BeforeMarshaler interface {
+	BeforeMarshal() error
+}
+
+// AfterUnmarshaler is implemented by struct types that complete their value after their fields are decoded.
+type AfterUnmarshaler interface {
+	AfterUnmarshal() error
+}
+
+// Validator is implemented by struct types that check their value after their fields are decoded,
+// Validate is called after AfterUnmarshal.
+type Validator interface {
+	Validate() error
+}
+
+var (
+	beforeMarshalerType  = reflect.TypeOf((*BeforeMarshaler)(nil)).Elem()
+	afterUnmarshalerType = reflect.TypeOf((*AfterUnmarshaler)(nil)).Elem()
+	validatorType        = reflect.TypeOf((*Validator)(nil)).Elem()
+)
+
+func beforeMarshalEncoder[T any](s *encodeState[T], v reflect.Value) error {
+	p := reflect.New(v.Type())
+	p.Elem().Set(v)
+
+	if err := p.Interface().(BeforeMarshaler).BeforeMarshal(); err != nil {
+		if len(s.fieldPath) == 0 {
+			s.structName = v.Type().Name()
+		}
+		s.setHookError(v.Type(), fmt.Errorf("BeforeMarshal: %w", err))
+		return errExist
+	}
+
+	return structEncoder(s, p.Elem())
+}
+
+func afterUnmarshalDecoder[T any](s *decodeState[T], v reflect.Value) error {
+	offset := s.consumed()
+	if err := structDecoder(s, v); err != nil {
+		return err
+	}
+
+	p := v.Addr().Interface()
+	if h, ok := p.(AfterUnmarshaler); ok {
+		if err := h.AfterUnmarshal(); err != nil {
+			s.setHookError(v.Type(), offset, fmt.Errorf("AfterUnmarshal: %w", err))
+			return errExist
+		}
+	}
+	if h, ok := p.(Validator); ok {
+		if err := h.Validate(); err != nil {
+			s.setHookError(v.Type(), offset, fmt.Errorf("Validate: %w", err))
+			return errExist
+		}
+	}
+
+	return nil
+}
+
+// setHookError sets the error of a hook of the struct type t, the current path is the path of the struct.
+func (s *encodeState[T]) setHookError(t reflect.Type, err error) {
+	s.err = &MarshalTypeError{
+		Name:   s.name,
+		Struct: s.structName,
+		Field:  s.path(),
+		Type:   t,
+		Err:    err,
+	}
+}
+
+// setHookError sets the error of a hook of the struct type t decoded at the offset,
+// the current path is the path of the struct.
+func (s *decodeState[T]) setHookError(t reflect.Type, offset int64, err error) {
+	s.err = &UnmarshalTypeError{
+		Name:   s.name,
+		Struct: s.structName,
+		Field:  s.path(),
+		Type:   t,
+		Offset: offset,
+		Err:    err,
+	}
+}
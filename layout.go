@@ -0,0 +1,318 @@
+package oxygen
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Measurer describes what function an entity should implement to give the width of fields to Engine.Describe.
+// It's an optional interface, it's called for every field with a tag when a struct type is described.
+type Measurer[T any] interface {
+	// Measure returns the number of bytes the value of the field takes and its offset from the start of the struct,
+	// the offset is -1 if the field follows the field before it. It returns false if the width of the field varies.
+	Measure(fieldName string, tag *T) (offset, width int, ok bool)
+}
+
+// Padder describes what function an entity should implement to give the padding of fields to Engine.Describe.
+// It's an optional interface, it's called for every field with a tag when a struct type is described.
+type Padder[T any] interface {
+	// Padding returns the alignment of the value of the field in its width and the byte filling the rest of the width,
+	// ok is false if the value isn't padded.
+	Padding(fieldName string, tag *T) (align Alignment, filler byte, ok bool)
+}
+
+// Alignment is the alignment of a value padded to the width of its field.
+type Alignment int
+
+const (
+	// NoAlignment is the alignment of values that aren't padded.
+	NoAlignment Alignment = iota
+	// LeftAlignment writes the value at the start of the width, followed by the filler.
+	LeftAlignment
+	// RightAlignment writes the filler followed by the value at the end of the width.
+	RightAlignment
+)
+
+// Coder tells what encodes or decodes the value of a field.
+type Coder int
+
+const (
+	// KindCoder is the engine, by the kind of the type.
+	KindCoder Coder = iota
+	// MethodCoder is a method of the Marshaller or the Unmarshaler interface of the engine.
+	MethodCoder
+	// TextCoder is the MarshalText or the UnmarshalText method.
+	TextCoder
+	// RegisteredCoder is a function registered with RegisterType.
+	RegisteredCoder
+)
+
+// Layout is the tree of the fields of a struct type in the order they are encoded.
+type Layout struct {
+	Type   reflect.Type
+	Fields []FieldLayout
+	// Width is the number of bytes of the encoded struct, the delimiters included, -1 if it varies.
+	Width int
+}
+
+// FieldLayout describes a field of a struct type.
+type FieldLayout struct {
+	Name string
+	// Path is the Go path of the field from the described struct, the names of embedded structs included.
+	Path string
+	Type reflect.Type
+	// Tag is the parsed tag of the field, a *T of the engine, nil if the field has no tag.
+	Tag       any
+	OmitEmpty bool
+	// Promoted is set for the fields of embedded structs, they are encoded inline with the fields of the struct.
+	Promoted         bool
+	Encoder, Decoder Coder
+	// Offset is the position of the field from the start of the described struct and Width is the number of bytes
+	// of its value, they are -1 if they vary.
+	Offset, Width int
+	// Align is the alignment of the value in the width of the field and Filler is the byte padding it.
+	Align  Alignment
+	Filler byte
+	// Fields are the fields of a struct field, of the struct it points to or of its struct elements.
+	// The offsets of the fields of elements are relative to the start of the element.
+	Fields []FieldLayout
+}
+
+// Describe returns the layout of the struct type of the value v, or of the struct v points to.
+func (e *engine[T]) Describe(v any) (Layout, error) {
+	t := reflect.TypeOf(v)
+	if t == nil || unPoint(t).Kind() != reflect.Struct {
+		return Layout{}, fmt.Errorf("%s: Describe(non-struct %v)", e.name, t)
+	}
+	t = unPoint(t)
+
+	d := &describer[T]{engine: e, root: t, visiting: make(map[reflect.Type]bool)}
+	fields, width, err := d.describe(t, nil, 0)
+	if err != nil {
+		return Layout{}, err
+	}
+	return Layout{Type: t, Fields: fields, Width: width}, nil
+}
+
+type describer[T any] struct {
+	*engine[T]
+	root     reflect.Type
+	visiting map[reflect.Type]bool // struct types being described, recursive types aren't expanded
+}
+
+// describe returns the layout of the fields of the struct type t at the offset, -1 if it varies,
+// and the width of the encoded struct. The path is the Go path of the struct.
+func (d *describer[T]) describe(t reflect.Type, path []string, offset int) ([]FieldLayout, int, error) {
+	d.visiting[t] = true
+	defer delete(d.visiting, t)
+
+	start := offset
+	if d.wrap {
+		offset = advance(offset, len(d.structOpener))
+	}
+
+	fs, offset, err := d.fields(t, d.cachedFields(t), path, start, offset, false)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if d.wrap {
+		offset = advance(offset, len(d.structCloser))
+	}
+	if start < 0 || offset < 0 {
+		return fs, -1, nil
+	}
+	return fs, offset - start, nil
+}
+
+// fields returns the layout of the fields f of the struct type t starting at the offset,
+// fields of embedded structs are flattened. The start is the offset of the struct the fields are encoded in.
+// It returns the offset after the last field.
+func (d *describer[T]) fields(t reflect.Type, f structFields[T], path []string, start, offset int, promoted bool) ([]FieldLayout, int, error) {
+	var fs []FieldLayout
+	var sep bool
+
+	for _, fl := range f {
+		sf := t.Field(fl.index)
+		fieldPath := append(path[:len(path):len(path)], fl.name)
+
+		// A field with a predicate that isn't registered is described as conditional,
+		// encoding or decoding it reports the error.
+		var pe *predicateError
+		conditional := fl.presence != nil || errors.As(fl.err, &pe)
+		if fl.err != nil && pe == nil {
+			return nil, 0, &TagError{
+				Name:   d.name,
+				Tag:    sf.Tag.Get(d.name),
+				Struct: d.root.Name(),
+				Field:  strings.Join(fieldPath, "."),
+				Err:    fl.err,
+			}
+		}
+
+		if sep {
+			offset = advance(offset, len(d.valueSeparator))
+		}
+		sep = d.separate
+
+		if fl.embedded != nil {
+			embedded, end, err := d.fields(unPoint(fl.typ), fl.embedded, fieldPath, start, offset, true)
+			if err != nil {
+				return nil, 0, err
+			}
+			fs, offset = append(fs, embedded...), end
+			continue
+		}
+
+		l := FieldLayout{
+			Name:      fl.name,
+			Path:      strings.Join(fieldPath, "."),
+			Type:      fl.typ,
+			OmitEmpty: fl.omitempty,
+			Promoted:  promoted,
+			Width:     -1,
+		}
+		if fl.tag != nil {
+			l.Tag = fl.tag
+		}
+		l.Encoder, l.Decoder = d.codersOf(unPoint(fl.typ))
+
+		measured := -1
+		if m, ok := d.Tag.(Measurer[T]); ok && fl.tag != nil {
+			if at, width, ok := m.Measure(fl.name, fl.tag); ok {
+				if at >= 0 {
+					offset = advance(start, at)
+				}
+				measured = width
+			}
+		}
+		l.Offset = offset
+		if p, ok := d.Tag.(Padder[T]); ok && fl.tag != nil {
+			if align, filler, ok := p.Padding(fl.name, fl.tag); ok {
+				l.Align, l.Filler = align, filler
+			}
+		}
+		l.Width = d.width(fl, unPoint(fl.typ), l.Encoder, measured)
+
+		if st, list := structOf(fl.typ); st != nil && !d.visiting[st] && l.Encoder == KindCoder {
+			at := offset
+			if list != nil {
+				at = 0
+			}
+			fields, width, err := d.describe(st, fieldPath, at)
+			if err != nil {
+				return nil, 0, err
+			}
+			l.Fields = fields
+			switch {
+			case list == nil:
+				l.Width = width
+			case list.Kind() == reflect.Array:
+				l.Width = d.listWidth(list.Len(), width)
+			}
+		}
+
+		// The fields after an optional field or a field of a varying width are at varying offsets.
+		if l.Width < 0 || fl.omitempty || conditional {
+			offset = -1
+		} else {
+			offset = advance(offset, l.Width)
+		}
+		fs = append(fs, l)
+	}
+
+	return fs, offset, nil
+}
+
+// codersOf returns what encodes and decodes the values of the type t, as typeCoders selects it.
+func (e *engine[T]) codersOf(t reflect.Type) (enc, dec Coder) {
+	if t.Kind() != reflect.Pointer {
+		p := reflect.PointerTo(t)
+		if p.Implements(e.marshaller) {
+			enc = MethodCoder
+		} else if e.textMarshaler && p.Implements(textMarshalerType) {
+			enc = TextCoder
+		}
+		if p.Implements(e.unmarshaler) {
+			dec = MethodCoder
+		} else if e.textMarshaler && p.Implements(textUnmarshalerType) {
+			dec = TextCoder
+		}
+	}
+	if c, ok := e.customCoders.Load(t); ok {
+		c := c.(*registered[T])
+		if c.encoderFunc != nil {
+			enc = RegisteredCoder
+		}
+		if c.decoderFunc != nil {
+			dec = RegisteredCoder
+		}
+	}
+	return
+}
+
+// width returns the number of bytes of the value of the type t of the field encoded by enc, -1 if it varies.
+// The measured width is the width given by the Measurer, it's the width of an element for lists.
+func (d *describer[T]) width(f *field[T], t reflect.Type, enc Coder, measured int) int {
+	if enc != KindCoder {
+		return measured
+	}
+
+	switch k := t.Kind(); {
+	case k == reflect.Bool && d.byteOrderOf(f) != nil:
+		return 1
+	case (isInteger(k) || k == reflect.Float32 || k == reflect.Float64) && d.byteOrderOf(f) != nil:
+		return bitSize(k) / 8
+	case k == reflect.Array:
+		elem := unPoint(t.Elem())
+		enc, _ := d.codersOf(elem)
+		if w := d.width(f, elem, enc, measured); w >= 0 {
+			return d.listWidth(t.Len(), w)
+		}
+		return -1
+	case k == reflect.Slice && t.Elem().Kind() != reflect.Uint8, k == reflect.Map, k == reflect.Interface:
+		return -1
+	case k == reflect.Struct && t != decimalType:
+		// The width of a struct is the width of its fields.
+		return -1
+	}
+	return measured
+}
+
+// listWidth returns the number of bytes of a list of n elements of the width.
+func (d *describer[T]) listWidth(n, width int) int {
+	if width < 0 {
+		return -1
+	}
+
+	w := n * width
+	if n > 1 && d.separateElems {
+		w += (n - 1) * len(d.elemSeparator)
+	}
+	if d.wrapList {
+		w += len(d.listOpener) + len(d.listCloser)
+	}
+	return w
+}
+
+// structOf returns the struct type of the values of t, list is the type of the list if they are its elements.
+func structOf(t reflect.Type) (st, list reflect.Type) {
+	t = unPoint(t)
+	if k := t.Kind(); k == reflect.Slice || k == reflect.Array {
+		list, t = t, unPoint(t.Elem())
+	}
+	if t.Kind() != reflect.Struct || t == decimalType {
+		return nil, nil
+	}
+	return t, list
+}
+
+// advance returns the offset after n bytes from the offset, -1 if the offset varies.
+func advance(offset, n int) int {
+	if offset < 0 {
+		return -1
+	}
+	return offset + n
+}
@@ -0,0 +1,262 @@
+package oxygen
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"reflect"
+)
+
+// Option configures an engine created by NewWithOptions.
+type Option func(cfg *Config) error
+
+// NewWithOptions is like New but builds the configuration from options.
+// Unlike New, it validates the configuration and returns an error wrapping ErrInvalidConfig
+// if settings contradict each other or would be silently ignored.
+// WithName, WithMarshaler and WithUnmarshaler are required.
+func NewWithOptions[T any](tag Tag[T], opts ...Option) (Engine, error) {
+	var cfg Config
+	for _, opt := range opts {
+		if err := opt(&cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	return New(tag, cfg), nil
+}
+
+// WithConfig sets all the settings from cfg, the following options override them.
+func WithConfig(cfg Config) Option {
+	return func(c *Config) error {
+		*c = cfg
+		return nil
+	}
+}
+
+// WithName sets the name of the tag.
+func WithName(name string) Option {
+	return func(c *Config) error {
+		c.Name = name
+		return nil
+	}
+}
+
+// WithStructDelimiters sets the StructOpener and StructCloser and whether to remove them when decoding.
+func WithStructDelimiters(opener, closer []byte, unwrap bool) Option {
+	return func(c *Config) error {
+		c.StructOpener, c.StructCloser, c.UnwrapWhenDecoding = opener, closer, unwrap
+		return nil
+	}
+}
+
+// WithSeparator sets the ValueSeparator and whether to remove it when decoding.
+func WithSeparator(sep []byte, remove bool) Option {
+	return func(c *Config) error {
+		c.ValueSeparator, c.RemoveSeparatorWhenDecoding = sep, remove
+		return nil
+	}
+}
+
+// WithListDelimiters sets the ListOpener and ListCloser and whether to remove them when decoding.
+func WithListDelimiters(opener, closer []byte, unwrap bool) Option {
+	return func(c *Config) error {
+		c.ListOpener, c.ListCloser, c.UnwrapListWhenDecoding = opener, closer, unwrap
+		return nil
+	}
+}
+
+// WithElementSeparator sets the ElementSeparator and whether to remove it when decoding.
+func WithElementSeparator(sep []byte, remove bool) Option {
+	return func(c *Config) error {
+		c.ElementSeparator, c.RemoveElementSeparatorWhenDecoding = sep, remove
+		return nil
+	}
+}
+
+// WithMapDelimiters sets the MapOpener and MapCloser and whether to remove them when decoding.
+func WithMapDelimiters(opener, closer []byte, unwrap bool) Option {
+	return func(c *Config) error {
+		c.MapOpener, c.MapCloser, c.UnwrapMapWhenDecoding = opener, closer, unwrap
+		return nil
+	}
+}
+
+// WithKeyValueSeparator sets the KeyValueSeparator and whether to remove it when decoding.
+func WithKeyValueSeparator(sep []byte, remove bool) Option {
+	return func(c *Config) error {
+		c.KeyValueSeparator, c.RemoveKeyValueSeparatorWhenDecoding = sep, remove
+		return nil
+	}
+}
+
+// WithPairSeparator sets the PairSeparator and whether to remove it when decoding.
+func WithPairSeparator(sep []byte, remove bool) Option {
+	return func(c *Config) error {
+		c.PairSeparator, c.RemovePairSeparatorWhenDecoding = sep, remove
+		return nil
+	}
+}
+
+// WithRecordTerminator sets the RecordTerminator.
+func WithRecordTerminator(terminator []byte) Option {
+	return func(c *Config) error {
+		c.RecordTerminator = terminator
+		return nil
+	}
+}
+
+// WithoutTextMarshaler disables encoding.TextMarshaler and encoding.TextUnmarshaler.
+func WithoutTextMarshaler() Option {
+	return func(c *Config) error {
+		c.DisableTextMarshaler = true
+		return nil
+	}
+}
+
+// WithMarshaler sets the Marshaller interface I.
+func WithMarshaler[I any]() Option {
+	return func(c *Config) error {
+		t, err := interfaceOf[I]("WithMarshaler")
+		c.Marshaller = t
+		return err
+	}
+}
+
+// WithUnmarshaler sets the Unmarshaler interface I.
+func WithUnmarshaler[I any]() Option {
+	return func(c *Config) error {
+		t, err := interfaceOf[I]("WithUnmarshaler")
+		c.Unmarshaler = t
+		return err
+	}
+}
+
+// WithNumberFormat sets the format of numbers.
+func WithNumberFormat(format NumberFormat) Option {
+	return func(c *Config) error {
+		c.NumberFormat = format
+		return nil
+	}
+}
+
+// WithPrimitives sets the primitives and the byte order of binary primitives.
+func WithPrimitives(p Primitives, order binary.ByteOrder) Option {
+	return func(c *Config) error {
+		c.Primitives, c.ByteOrder = p, order
+		return nil
+	}
+}
+
+// WithCharset sets the charset of the encoded data.
+func WithCharset(charset *Charset) Option {
+	return func(c *Config) error {
+		c.Charset = charset
+		return nil
+	}
+}
+
+// WithMaxDepth limits the nesting of structs, lists and maps, zero means no limit.
+func WithMaxDepth(depth int) Option {
+	return func(c *Config) error {
+		if depth < 0 {
+			return fmt.Errorf("oxygen: %w: WithMaxDepth(%d) must not be negative", ErrInvalidConfig, depth)
+		}
+		c.MaxDepth = depth
+		return nil
+	}
+}
+
+// WithStrict makes decoding reject trailing data and data ending before a field.
+func WithStrict() Option {
+	return func(c *Config) error {
+		c.Strict = true
+		return nil
+	}
+}
+
+func interfaceOf[I any](option string) (reflect.Type, error) {
+	t := reflect.TypeOf((*I)(nil)).Elem()
+	if t.Kind() != reflect.Interface {
+		return nil, fmt.Errorf("oxygen: %w: %s with non-interface type %s", ErrInvalidConfig, option, t)
+	}
+	return t, nil
+}
+
+// validate reports settings that contradict each other or would be silently ignored.
+func (c *Config) validate() error {
+	invalid := func(format string, args ...any) error {
+		return fmt.Errorf("oxygen: %w: "+format, append([]any{ErrInvalidConfig}, args...)...)
+	}
+
+	if c.Name == "" {
+		return invalid("the name is required")
+	}
+	if c.Marshaller == nil || c.Unmarshaler == nil {
+		return invalid("the Marshaller and Unmarshaler interfaces are required")
+	}
+	if c.Marshaller.Kind() != reflect.Interface || c.Unmarshaler.Kind() != reflect.Interface {
+		return invalid("the Marshaller and Unmarshaler must be interfaces")
+	}
+	if err := c.validateFormats(); err != nil {
+		return invalid("%v", err)
+	}
+	if c.MaxDepth < 0 {
+		return invalid("the max depth must not be negative")
+	}
+
+	for _, d := range []struct {
+		name           string
+		opener, closer []byte
+		unwrap         bool
+		sep            []byte
+		removeSep      bool
+	}{
+		{"struct", c.StructOpener, c.StructCloser, c.UnwrapWhenDecoding, c.ValueSeparator, c.RemoveSeparatorWhenDecoding},
+		{"list", c.ListOpener, c.ListCloser, c.UnwrapListWhenDecoding, c.ElementSeparator, c.RemoveElementSeparatorWhenDecoding},
+		{"map", c.MapOpener, c.MapCloser, c.UnwrapMapWhenDecoding, c.PairSeparator, c.RemovePairSeparatorWhenDecoding},
+	} {
+		if d.unwrap && len(d.opener) == 0 && len(d.closer) == 0 {
+			return invalid("unwrapping a %s requires an opener or a closer", d.name)
+		}
+		if d.removeSep && len(d.sep) == 0 {
+			return invalid("removing the %s separator requires a separator", d.name)
+		}
+		if len(d.sep) != 0 && (bytes.Equal(d.sep, d.opener) || bytes.Equal(d.sep, d.closer)) {
+			return invalid("the %s separator %q is the same as a delimiter", d.name, d.sep)
+		}
+	}
+
+	if c.RemoveKeyValueSeparatorWhenDecoding && len(c.KeyValueSeparator) == 0 {
+		return invalid("removing the key-value separator requires a separator")
+	}
+
+	return nil
+}
+
+// validateFormats reports a number format, primitives or delimiters the engine cannot write,
+// New reports them too.
+func (c *Config) validateFormats() error {
+	if err := c.NumberFormat.Validate(); err != nil {
+		return err
+	}
+	if err := c.Primitives.validate(c.ByteOrder); err != nil {
+		return err
+	}
+	if c.Charset != nil {
+		for _, d := range [][]byte{
+			c.StructOpener, c.StructCloser, c.ValueSeparator,
+			c.ListOpener, c.ListCloser, c.ElementSeparator,
+			c.MapOpener, c.MapCloser, c.KeyValueSeparator, c.PairSeparator,
+			c.RecordTerminator,
+		} {
+			if _, err := c.Charset.Encode(nil, d); err != nil {
+				return fmt.Errorf("the delimiter %q: %v", d, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package oxygen
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Presence is the condition of the presence of a field in the data.
+// The field is present if the field named Field holds one of the Values, or if the predicate named Func reports so.
+type Presence struct {
+	// Field is the name of a field declared before the field in the same struct,
+	// its value is formatted as fmt.Sprint does and compared with Values.
+	Field  string
+	Values []string
+	// Func is the name of a predicate registered with RegisterPredicate, it's used instead of Field if it's set.
+	Func string
+}
+
+type predicate struct {
+	typ reflect.Type // struct type the predicate receives a pointer to
+	fn  func(v any) bool
+}
+
+type predicateRegistry interface {
+	registerPredicate(name string, p predicate)
+	predicate(name string, t reflect.Type) (func(v any) bool, error)
+}
+
+// RegisterPredicate registers a function deciding the presence of fields of the struct type V with the engine,
+// fields select it by the name set in Presence.Func. The function receives the struct holding the field,
+// when decoding the fields declared before the field are already decoded.
+// RegisterPredicate panics if the engine wasn't created by New, V isn't a struct type or the name is already registered.
+func RegisterPredicate[V any](e Engine, name string, fn func(v *V) bool) {
+	r, ok := e.(predicateRegistry)
+	if !ok {
+		panic(fmt.Sprintf("oxygen: RegisterPredicate with an engine of type %T", e))
+	}
+
+	t := reflect.TypeOf((*V)(nil)).Elem()
+	if t.Kind() != reflect.Struct {
+		panic("oxygen: registering predicate " + name + " of non-struct type " + t.String())
+	}
+
+	r.registerPredicate(name, predicate{typ: t, fn: func(v any) bool { return fn(v.(*V)) }})
+}
+
+// Predicate returns the function registered with the engine under the name for the struct type V.
+func Predicate[V any](e Engine, name string) (func(v *V) bool, error) {
+	r, ok := e.(predicateRegistry)
+	if !ok {
+		return nil, fmt.Errorf("oxygen: Predicate with an engine of type %T", e)
+	}
+
+	fn, err := r.predicate(name, reflect.TypeOf((*V)(nil)).Elem())
+	if err != nil {
+		return nil, err
+	}
+	return func(v *V) bool { return fn(v) }, nil
+}
+
+func (e *engine[T]) registerPredicate(name string, p predicate) {
+	if _, dup := e.predicates.LoadOrStore(name, p); dup {
+		panic(e.name + ": registering duplicate predicate " + name)
+	}
+
+	// Drop the cached fields that may refer to the predicate before the registration.
+	e.fieldCache.Range(func(k, _ any) bool {
+		e.fieldCache.Delete(k)
+		return true
+	})
+}
+
+// predicate returns the function registered under the name for the struct type t.
+func (e *engine[T]) predicate(name string, t reflect.Type) (func(v any) bool, error) {
+	p, ok := e.predicates.Load(name)
+	if !ok {
+		return nil, fmt.Errorf("no predicate %s registered", name)
+	}
+	if p.(predicate).typ != t {
+		return nil, fmt.Errorf("predicate %s is registered for %s", name, p.(predicate).typ)
+	}
+	return p.(predicate).fn, nil
+}
+
+// predicateError is the error of a predicate that cannot be resolved for a field, the field is still conditional.
+type predicateError struct {
+	err error
+}
+
+func (e *predicateError) Error() string { return e.err.Error() }
+
+func (e *predicateError) Unwrap() error { return e.err }
+
+// presence is the resolved condition of the presence of a field.
+type presence struct {
+	field  int // index of the field whose value selects the presence
+	values []string
+	fn     func(v any) bool // nil if the presence is selected by the field
+}
+
+// presenceOf resolves the condition p of the presence of a field of the struct type t.
+func (e *engine[T]) presenceOf(f structFields[T], t reflect.Type, p Presence) (*presence, error) {
+	if p.Func != "" {
+		fn, err := e.predicate(p.Func, t)
+		if err != nil {
+			return nil, &predicateError{err: err}
+		}
+		return &presence{fn: fn}, nil
+	}
+
+	for _, c := range f {
+		if c.name == p.Field && c.embedded == nil {
+			return &presence{field: c.index, values: p.Values}, nil
+		}
+	}
+	return nil, fmt.Errorf("no field %s precedes the field", p.Field)
+}
+
+// present reports whether the field is present in the struct v.
+func (p *presence) present(v reflect.Value) bool {
+	if p.fn != nil {
+		if !v.CanAddr() {
+			ptr := reflect.New(v.Type())
+			ptr.Elem().Set(v)
+			v = ptr.Elem()
+		}
+		return p.fn(v.Addr().Interface())
+	}
+
+	s := fmt.Sprint(v.Field(p.field).Interface())
+	for _, value := range p.values {
+		if s == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,254 @@
+package oxygen
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"sync"
+)
+
+// RecordSet reads and writes records of different struct types told apart by a leading code,
+// for example the header, the details and the trailer of a file.
+// Each record is the prefix of its type followed by the struct encoded as Marshal does and the RecordTerminator.
+type RecordSet interface {
+	// Register records the struct type of the value v under the prefix the records of the type start with.
+	// Register panics if v isn't a struct or a pointer to a struct, the prefix is empty,
+	// or the prefix or the type is already registered.
+	Register(prefix string, v any)
+	// Marshal encodes the records of registered types one after another.
+	Marshal(records ...any) ([]byte, error)
+	// Unmarshal decodes the records of the encoded data into new values of their registered types.
+	// The record type is selected by the longest registered prefix the record starts with.
+	Unmarshal(data []byte) ([]any, error)
+	// NewEncoder returns a new encoder that writes records of registered types to w.
+	NewEncoder(w io.Writer) Encoder
+	// NewDecoder returns a new decoder that reads records of registered types from r.
+	NewDecoder(r io.Reader) RecordDecoder
+}
+
+// RecordDecoder reads and decodes records of different types from an input stream.
+type RecordDecoder interface {
+	// Decode reads the next record from the stream and returns a new value of its registered type,
+	// a pointer if the type was registered as a pointer. At the end of the stream, Decode returns io.EOF.
+	Decode() (any, error)
+}
+
+type recordType struct {
+	prefix []byte       // prefix written in the charset
+	typ    reflect.Type // registered type, a struct or a pointer to a struct
+}
+
+type recordSet[T any] struct {
+	*engine[T]
+	mu     sync.RWMutex
+	types  []recordType
+	byType map[reflect.Type]int // index of the registered struct type in types
+}
+
+// NewRecordSet returns a new empty set of record types.
+func (e *engine[T]) NewRecordSet() RecordSet {
+	return &recordSet[T]{engine: e, byType: make(map[reflect.Type]int)}
+}
+
+// Register records the struct type of the value v under the prefix the records of the type start with.
+func (rs *recordSet[T]) Register(prefix string, v any) {
+	t := reflect.TypeOf(v)
+	if t == nil {
+		panic(rs.name + ": attempt to register nil record type")
+	}
+	if unPoint(t).Kind() != reflect.Struct {
+		panic(rs.name + ": attempt to register non-struct record type " + t.String())
+	}
+	if prefix == "" {
+		panic(rs.name + ": registering record type " + t.String() + " with an empty prefix")
+	}
+
+	p := rs.charset.delimiter([]byte(prefix))
+
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	for _, rt := range rs.types {
+		if bytes.Equal(rt.prefix, p) {
+			panic(rs.name + ": registering duplicate record prefix " + prefix)
+		}
+	}
+	if _, dup := rs.byType[unPoint(t)]; dup {
+		panic(rs.name + ": registering duplicate record type " + t.String())
+	}
+
+	rs.byType[unPoint(t)] = len(rs.types)
+	rs.types = append(rs.types, recordType{prefix: p, typ: t})
+}
+
+// prefixOf returns the prefix of the type of the record v.
+func (rs *recordSet[T]) prefixOf(v any) ([]byte, bool) {
+	t := reflect.TypeOf(v)
+	if t == nil {
+		return nil, false
+	}
+
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+
+	i, ok := rs.byType[unPoint(t)]
+	if !ok {
+		return nil, false
+	}
+	return rs.types[i].prefix, true
+}
+
+// match returns the type of the record at the start of data with the longest prefix.
+func (rs *recordSet[T]) match(data []byte) (recordType, bool) {
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+
+	var (
+		best recordType
+		ok   bool
+	)
+	for _, rt := range rs.types {
+		if bytes.HasPrefix(data, rt.prefix) && len(rt.prefix) > len(best.prefix) {
+			best, ok = rt, true
+		}
+	}
+	return best, ok
+}
+
+// Marshal encodes the records of registered types one after another.
+func (rs *recordSet[T]) Marshal(records ...any) ([]byte, error) {
+	var (
+		out []byte
+		err error
+	)
+	for _, v := range records {
+		if out, err = rs.appendRecord(out, v); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// appendRecord appends the record v to dst.
+func (rs *recordSet[T]) appendRecord(dst []byte, v any) ([]byte, error) {
+	prefix, ok := rs.prefixOf(v)
+	if !ok {
+		return dst, &MarshalTypeError{Name: rs.name, Type: reflect.TypeOf(v), Err: ErrUnknownRecord}
+	}
+
+	s := rs.newEncodeState()
+	defer rs.encodeStatePool.Put(s)
+
+	if s.marshal(v); s.err != nil {
+		return dst, s.err
+	}
+
+	dst = append(dst, prefix...)
+	dst = append(dst, s.Bytes()...)
+	return append(dst, rs.terminator...), nil
+}
+
+// Unmarshal decodes the records of the encoded data into new values of their registered types.
+func (rs *recordSet[T]) Unmarshal(data []byte) ([]any, error) {
+	var (
+		records []any
+		offset  int
+	)
+	for offset < len(data) {
+		v, n, err := rs.decodeRecord(data[offset:], int64(offset))
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, v)
+		offset += n
+	}
+	return records, nil
+}
+
+// decodeRecord decodes the record at the start of data and returns the number of bytes it occupies
+// with the RecordTerminator, base is the offset of data in the input.
+func (rs *recordSet[T]) decodeRecord(data []byte, base int64) (any, int, error) {
+	rt, ok := rs.match(data)
+	if !ok {
+		return nil, 0, &SyntaxError{Name: rs.name, Offset: base, Err: ErrUnknownRecord}
+	}
+
+	rv := reflect.New(unPoint(rt.typ))
+
+	s := rs.newDecodeState()
+	defer putDecodeState(s)
+
+	s.init(data[len(rt.prefix):], base+int64(len(rt.prefix)))
+	if s.unmarshal(rv.Interface()); s.err != nil {
+		return nil, 0, s.err
+	}
+
+	n := len(data) - len(s.data)
+	if len(rs.terminator) != 0 && bytes.HasPrefix(data[n:], rs.terminator) {
+		n += len(rs.terminator)
+	} else if len(rs.terminator) != 0 && rs.strict && n != len(data) {
+		s.trailingData()
+		return nil, 0, s.err
+	}
+
+	if rt.typ.Kind() == reflect.Pointer {
+		return rv.Interface(), n, nil
+	}
+	return rv.Elem().Interface(), n, nil
+}
+
+type recordEncoder[T any] struct {
+	*recordSet[T]
+	w io.Writer
+}
+
+// NewEncoder returns a new encoder that writes records of registered types to w.
+func (rs *recordSet[T]) NewEncoder(w io.Writer) Encoder {
+	return &recordEncoder[T]{recordSet: rs, w: w}
+}
+
+// Encode writes the record v to the stream.
+func (enc *recordEncoder[T]) Encode(v any) error {
+	p, err := enc.appendRecord(nil, v)
+	if err != nil {
+		return err
+	}
+	_, err = enc.w.Write(p)
+	return err
+}
+
+type recordDecoder[T any] struct {
+	*recordSet[T]
+	dec    *decoder[T]
+	rest   []byte // records left in the last read data
+	offset int64  // offset of rest in the stream
+}
+
+// NewDecoder returns a new decoder that reads records of registered types from r.
+// If RecordTerminator is empty, the whole stream is read at once and records are decoded one after another.
+func (rs *recordSet[T]) NewDecoder(r io.Reader) RecordDecoder {
+	return &recordDecoder[T]{recordSet: rs, dec: rs.engine.NewDecoder(r).(*decoder[T])}
+}
+
+// Decode reads the next record from the stream and returns a new value of its registered type.
+func (dec *recordDecoder[T]) Decode() (any, error) {
+	for len(dec.rest) == 0 {
+		dec.offset = dec.dec.offset
+		record, err := dec.dec.readRecord()
+		if err != nil {
+			return nil, err
+		}
+		dec.rest = record
+	}
+
+	v, n, err := dec.decodeRecord(dec.rest, dec.offset)
+	if err != nil || len(dec.terminator) != 0 {
+		// A record read up to the RecordTerminator is decoded at once.
+		dec.rest = nil
+		return v, err
+	}
+
+	dec.rest = dec.rest[n:]
+	dec.offset += int64(n)
+	return v, nil
+}
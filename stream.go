@@ -0,0 +1,110 @@
+package oxygen
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+)
+
+// Encoder writes encoded records to an output stream.
+type Encoder interface {
+	// Encode writes the encoding of v followed by the RecordTerminator to the stream.
+	Encode(v any) error
+}
+
+// Decoder reads and decodes records from an input stream.
+type Decoder interface {
+	// Decode reads the next record from the stream and stores the result in the value pointed to by v.
+	// At the end of the stream, Decode returns io.EOF.
+	Decode(v any) error
+}
+
+type encoder[T any] struct {
+	*engine[T]
+	w io.Writer
+}
+
+// NewEncoder returns a new encoder that writes records to w.
+func (e *engine[T]) NewEncoder(w io.Writer) Encoder {
+	return &encoder[T]{engine: e, w: w}
+}
+
+// Encode writes the encoding of v followed by the RecordTerminator to the stream.
+func (enc *encoder[T]) Encode(v any) (err error) {
+	s := enc.newEncodeState()
+	defer enc.encodeStatePool.Put(s)
+
+	if s.marshal(v); s.err != nil {
+		return s.err
+	}
+
+	s.Write(enc.terminator)
+	_, err = enc.w.Write(s.Bytes())
+	return
+}
+
+type decoder[T any] struct {
+	*engine[T]
+	r      *bufio.Reader
+	buf    []byte
+	offset int64 // offset of the next record in the stream
+	eof    bool
+}
+
+// NewDecoder returns a new decoder that reads records from r.
+// If RecordTerminator is empty, the whole stream is treated as a single record.
+func (e *engine[T]) NewDecoder(r io.Reader) Decoder {
+	return &decoder[T]{engine: e, r: bufio.NewReader(r)}
+}
+
+// Decode reads the next record from the stream and stores the result in the value pointed to by v.
+// At the end of the stream, Decode returns io.EOF.
+func (dec *decoder[T]) Decode(v any) error {
+	offset := dec.offset
+	record, err := dec.readRecord()
+	if err != nil {
+		return err
+	}
+	return dec.unmarshalAt(record, offset, v)
+}
+
+// readRecord reads the stream up to the RecordTerminator and returns the record without it.
+func (dec *decoder[T]) readRecord() ([]byte, error) {
+	if dec.eof {
+		return nil, io.EOF
+	}
+
+	dec.buf = dec.buf[:0]
+
+	if len(dec.terminator) == 0 {
+		dec.eof = true
+		b, err := io.ReadAll(dec.r)
+		if err == nil && len(b) == 0 {
+			err = io.EOF
+		}
+		dec.offset += int64(len(b))
+		return b, err
+	}
+
+	last := dec.terminator[len(dec.terminator)-1]
+	for {
+		line, err := dec.r.ReadSlice(last)
+		dec.buf = append(dec.buf, line...)
+		dec.offset += int64(len(line))
+
+		switch {
+		case err == bufio.ErrBufferFull:
+			continue
+		case err == io.EOF:
+			dec.eof = true
+			if len(dec.buf) == 0 {
+				return nil, io.EOF
+			}
+			return dec.buf, nil
+		case err != nil:
+			return nil, err
+		case bytes.HasSuffix(dec.buf, dec.terminator):
+			return dec.buf[:len(dec.buf)-len(dec.terminator)], nil
+		}
+	}
+}
@@ -0,0 +1,8133 @@
+// Code generated by oxygen. DO NOT EDIT.
+
+package test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gromey/oxygen"
+	"github.com/gromey/oxygen/test/records"
+)
+
+var (
+	codecEngine          = &engine{}
+	codecWrap            = len(cfg.StructOpener) != 0 || len(cfg.StructCloser) != 0
+	codecRemoveWrapper   = codecWrap && cfg.UnwrapWhenDecoding
+	codecSeparate        = len(cfg.ValueSeparator) != 0
+	codecRemoveSeparator = codecSeparate && cfg.RemoveSeparatorWhenDecoding
+	codecWrapList        = len(cfg.ListOpener) != 0 || len(cfg.ListCloser) != 0
+	codecUnwrapList      = codecWrapList && cfg.UnwrapListWhenDecoding
+	codecSeparateElems   = len(cfg.ElementSeparator) != 0
+	codecRemoveElemSep   = codecSeparateElems && cfg.RemoveElementSeparatorWhenDecoding
+	codecTextMarshaler   = !cfg.DisableTextMarshaler
+	codecOrder           = codecByteOrder(cfg.Primitives, cfg.ByteOrder)
+	codecStrict          = cfg.Strict
+
+	codecStructOpener     = codecDelimiter(cfg.StructOpener)
+	codecStructCloser     = codecDelimiter(cfg.StructCloser)
+	codecValueSeparator   = codecDelimiter(cfg.ValueSeparator)
+	codecListOpener       = codecDelimiter(cfg.ListOpener)
+	codecListCloser       = codecDelimiter(cfg.ListCloser)
+	codecElementSeparator = codecDelimiter(cfg.ElementSeparator)
+)
+
+// Tags are parsed once when the package is initialized.
+var (
+	codecOrder_ID         = codecParse("ID", "4,0,r", nil, []string{}, new(int))
+	codecOrder_Paid       = codecParse("Paid", "5, ,l", nil, []string{"ID"}, new(bool))
+	codecOrder_Amount     = codecParse("Amount", "6,0,r", nil, []string{"ID", "Paid"}, new(float64))
+	codecOrder_Code       = codecParse("Code", "3,_,l", nil, []string{"ID", "Paid", "Amount"}, new(records.Code))
+	codecOrder_Note       = codecParse("Note", "6,_,l", nil, []string{"ID", "Paid", "Amount", "Code"}, new(string))
+	codecOrder_Raw        = codecParse("Raw", "3, ,l", nil, []string{"ID", "Paid", "Amount", "Code", "Note"}, new([]byte))
+	codecOrder_Pins       = codecParse("Pins", "3,0,r", nil, []string{"ID", "Paid", "Amount", "Code", "Note", "Raw", "Items"}, new([2]uint16))
+	codecOrder_State      = codecParse("State", "3, ,l", nil, []string{"ID", "Paid", "Amount", "Code", "Note", "Raw", "Items", "Pins", "Next"}, new(records.State))
+	codecOrder_Created    = codecParse("Created", "20, ,l", nil, []string{"ID", "Paid", "Amount", "Code", "Note", "Raw", "Items", "Pins", "Next", "State"}, new(time.Time))
+	codecOrder_Tags       = codecParse("Tags", "2", []string{"ID", "State"}, []string{"ID", "Paid", "Amount", "Code", "Note", "Raw", "Items", "Pins", "Next", "State", "Created"}, new([]string))
+	codecOrder_Extra      = codecParse("Extra", "2, ,l", nil, []string{"ID", "Paid", "Amount", "Code", "Note", "Raw", "Items", "Pins", "Next", "State", "Created", "Tags"}, new(string))
+	codecOrder_Cents      = codecParse("Cents", "7,0,r,i2", nil, []string{"ID", "Paid", "Amount", "Code", "Note", "Raw", "Items", "Pins", "Next", "State", "Created", "Tags", "Extra"}, new(float64))
+	codecOrder_Hex        = codecParse("Hex", "4,0,r,x16", nil, []string{"ID", "Paid", "Amount", "Code", "Note", "Raw", "Items", "Pins", "Next", "State", "Created", "Tags", "Extra", "Cents"}, new(uint32))
+	codecOrder_Parts      = codecParse("Parts", "1,0,r", nil, []string{"ID", "Paid", "Amount", "Code", "Note", "Raw", "Items", "Pins", "Next", "State", "Created", "Tags", "Extra", "Cents", "Hex"}, new(uint8))
+	codecOrder_Sizes      = codecParse("Sizes", "2,0,r,,Parts", []string{"ID", "State", "Hex", "Parts"}, []string{"ID", "Paid", "Amount", "Code", "Note", "Raw", "Items", "Pins", "Next", "State", "Created", "Tags", "Extra", "Cents", "Hex", "Parts"}, new([]int16))
+	codecOrder_Port       = codecParse("Port", "0, ,l,le", nil, []string{"ID", "Paid", "Amount", "Code", "Note", "Raw", "Items", "Pins", "Next", "State", "Created", "Tags", "Extra", "Cents", "Hex", "Parts", "Sizes"}, new(int16))
+	codecOrder_Ratio      = codecParse("Ratio", "0, ,l,be", nil, []string{"ID", "Paid", "Amount", "Code", "Note", "Raw", "Items", "Pins", "Next", "State", "Created", "Tags", "Extra", "Cents", "Hex", "Parts", "Sizes", "Port"}, new(float32))
+	codecOrder_Total      = codecParse("Total", "3,_,r,p5.2", nil, []string{"ID", "Paid", "Amount", "Code", "Note", "Raw", "Items", "Pins", "Next", "State", "Created", "Tags", "Extra", "Cents", "Hex", "Parts", "Sizes", "Port", "Ratio"}, new(oxygen.Decimal))
+	codecOrder_Count      = codecParse("Count", "4,0,r,z4", nil, []string{"ID", "Paid", "Amount", "Code", "Note", "Raw", "Items", "Pins", "Next", "State", "Created", "Tags", "Extra", "Cents", "Hex", "Parts", "Sizes", "Port", "Ratio", "Total"}, new(int64))
+	codecLine_Qty         = codecParse("Qty", "2,0,r", nil, []string{}, new(int))
+	codecLine_Price       = codecParse("Price", "3,0,r", nil, []string{"Qty"}, new(uint))
+	codecReading_Value    = codecParse("Value", "3, ,l,p5.2", nil, []string{}, new(float64))
+	codecReading_Count    = codecParse("Count", "3, ,l,p5", nil, []string{"Value"}, new(int32))
+	codecReading_Units    = codecParse("Units", "3, ,l,p5", nil, []string{"Value", "Count"}, new(uint32))
+	codecReading_Total    = codecParse("Total", "3, ,l,p5.2", nil, []string{"Value", "Count", "Units"}, new(oxygen.Decimal))
+	codecPayment_Amount   = codecParse("Amount", "4,0,r", nil, []string{}, new(int))
+	codecPayment_Foreign  = codecParse("Foreign", "1", nil, []string{"Amount"}, new(string))
+	codecPayment_Currency = codecParse("Currency", "3,_,l,,,Foreign=Y", nil, []string{"Amount", "Foreign"}, new(string))
+	codecPayment_Rate     = codecParse("Rate", "4,0,r,,,@rated", nil, []string{"Amount", "Foreign", "Currency"}, new(uint16))
+	codecBatch_ID         = codecParse("ID", "!4,0,r", nil, []string{}, new(int))
+	codecAddress_Street   = codecParse("Street", "6, ,l", nil, []string{}, new(string))
+	codecAddress_Country  = codecParse("Country", "2, ,l,,,,*US", nil, []string{"Street"}, new(string))
+	codecAddress_Floor    = codecParse("Floor", "2,0,r,,,,1", nil, []string{"Street", "Country"}, new(uint8))
+	codecTicket_Code      = codecParse("Code", "3,_,l", nil, []string{}, new(records.Code))
+	codecTimer_Took       = codecParse("Took", "8, ,l", nil, []string{}, new(time.Duration))
+	codecTimer_Best       = codecParse("Best", "5, ,l", nil, []string{"Took"}, new(time.Duration))
+	codecTimer_Laps       = codecParse("Laps", "4, ,l", []string{"Took"}, []string{"Took", "Best"}, new([]time.Duration))
+	codecTimer_Next       = codecParse("Next", "3, ,l", nil, []string{"Took", "Best", "Laps"}, new([]time.Duration))
+	codecNode_ID          = codecParse("ID", "2,0,r", nil, []string{}, new(int))
+	codecHeader_Kind      = codecParse("Kind", "1", nil, []string{}, new(string))
+	codecHeader_Rev       = codecParse("Rev", "2,0,r", nil, []string{"Kind"}, new(uint8))
+	codecLimits_Daily     = codecParse("Daily", "!3,0,r", nil, []string{}, new(int))
+	codecLimits_Monthly   = codecParse("Monthly", "!4,0,r", nil, []string{"Daily"}, new(int))
+	codecItem_Qty         = codecParse("Qty", "!2,0,r", nil, []string{}, new(int))
+	codecItem_Note        = codecParse("Note", "2, ,l", nil, []string{"Qty"}, new(string))
+	codecLeg_From         = codecParse("From", "2,0,r", nil, []string{}, new(int))
+	codecLeg_To           = codecParse("To", "2,0,r", nil, []string{"From"}, new(int))
+)
+
+// MarshalOrder encodes the value v the same way as Marshal does, but without reflection.
+func MarshalOrder(v *records.Order) ([]byte, error) {
+	if v == nil {
+		v = new(records.Order)
+	}
+
+	enc := codecEncoderPool.Get().(*codecEncoder)
+	defer codecEncoderPool.Put(enc)
+	enc.Reset()
+	enc.nesting = 0
+
+	if err := enc.encodeNestedOrder(v, codecWrap); err != nil {
+		return nil, codecRoot(err, "Order")
+	}
+
+	return append([]byte(nil), enc.Bytes()...), nil
+}
+
+// UnmarshalOrder decodes the encoded data the same way as Unmarshal does, but without reflection.
+func UnmarshalOrder(data []byte, v *records.Order) error {
+	dec := &codecDecoder{data: data, size: len(data)}
+	if cfg.Charset != nil {
+		dec.src, dec.text = data, cfg.Charset.Decode(nil, data)
+	}
+	if err := dec.decodeNestedOrder(v, codecRemoveWrapper); err != nil {
+		return codecRoot(err, "Order")
+	}
+	if len(dec.missing) != 0 {
+		return &oxygen.RequiredFieldError{Name: cfg.Name, Struct: "Order", Fields: dec.missing}
+	}
+	if codecStrict && len(dec.data) != 0 {
+		return &oxygen.SyntaxError{Name: cfg.Name, Struct: "Order", Offset: dec.offset(), Err: oxygen.ErrTrailingData}
+	}
+	return nil
+}
+
+// MarshalLine encodes the value v the same way as Marshal does, but without reflection.
+func MarshalLine(v *records.Line) ([]byte, error) {
+	if v == nil {
+		v = new(records.Line)
+	}
+
+	enc := codecEncoderPool.Get().(*codecEncoder)
+	defer codecEncoderPool.Put(enc)
+	enc.Reset()
+	enc.nesting = 0
+
+	if err := enc.encodeNestedLine(v, codecWrap); err != nil {
+		return nil, codecRoot(err, "Line")
+	}
+
+	return append([]byte(nil), enc.Bytes()...), nil
+}
+
+// UnmarshalLine decodes the encoded data the same way as Unmarshal does, but without reflection.
+func UnmarshalLine(data []byte, v *records.Line) error {
+	dec := &codecDecoder{data: data, size: len(data)}
+	if cfg.Charset != nil {
+		dec.src, dec.text = data, cfg.Charset.Decode(nil, data)
+	}
+	if err := dec.decodeNestedLine(v, codecRemoveWrapper); err != nil {
+		return codecRoot(err, "Line")
+	}
+	if len(dec.missing) != 0 {
+		return &oxygen.RequiredFieldError{Name: cfg.Name, Struct: "Line", Fields: dec.missing}
+	}
+	if codecStrict && len(dec.data) != 0 {
+		return &oxygen.SyntaxError{Name: cfg.Name, Struct: "Line", Offset: dec.offset(), Err: oxygen.ErrTrailingData}
+	}
+	return nil
+}
+
+// MarshalReading encodes the value v the same way as Marshal does, but without reflection.
+func MarshalReading(v *records.Reading) ([]byte, error) {
+	if v == nil {
+		v = new(records.Reading)
+	}
+
+	enc := codecEncoderPool.Get().(*codecEncoder)
+	defer codecEncoderPool.Put(enc)
+	enc.Reset()
+	enc.nesting = 0
+
+	if err := enc.encodeNestedReading(v, codecWrap); err != nil {
+		return nil, codecRoot(err, "Reading")
+	}
+
+	return append([]byte(nil), enc.Bytes()...), nil
+}
+
+// UnmarshalReading decodes the encoded data the same way as Unmarshal does, but without reflection.
+func UnmarshalReading(data []byte, v *records.Reading) error {
+	dec := &codecDecoder{data: data, size: len(data)}
+	if cfg.Charset != nil {
+		dec.src, dec.text = data, cfg.Charset.Decode(nil, data)
+	}
+	if err := dec.decodeNestedReading(v, codecRemoveWrapper); err != nil {
+		return codecRoot(err, "Reading")
+	}
+	if len(dec.missing) != 0 {
+		return &oxygen.RequiredFieldError{Name: cfg.Name, Struct: "Reading", Fields: dec.missing}
+	}
+	if codecStrict && len(dec.data) != 0 {
+		return &oxygen.SyntaxError{Name: cfg.Name, Struct: "Reading", Offset: dec.offset(), Err: oxygen.ErrTrailingData}
+	}
+	return nil
+}
+
+// MarshalPayment encodes the value v the same way as Marshal does, but without reflection.
+func MarshalPayment(v *records.Payment) ([]byte, error) {
+	if v == nil {
+		v = new(records.Payment)
+	}
+
+	enc := codecEncoderPool.Get().(*codecEncoder)
+	defer codecEncoderPool.Put(enc)
+	enc.Reset()
+	enc.nesting = 0
+
+	if err := enc.encodeNestedPayment(v, codecWrap); err != nil {
+		return nil, codecRoot(err, "Payment")
+	}
+
+	return append([]byte(nil), enc.Bytes()...), nil
+}
+
+// UnmarshalPayment decodes the encoded data the same way as Unmarshal does, but without reflection.
+func UnmarshalPayment(data []byte, v *records.Payment) error {
+	dec := &codecDecoder{data: data, size: len(data)}
+	if cfg.Charset != nil {
+		dec.src, dec.text = data, cfg.Charset.Decode(nil, data)
+	}
+	if err := dec.decodeNestedPayment(v, codecRemoveWrapper); err != nil {
+		return codecRoot(err, "Payment")
+	}
+	if len(dec.missing) != 0 {
+		return &oxygen.RequiredFieldError{Name: cfg.Name, Struct: "Payment", Fields: dec.missing}
+	}
+	if codecStrict && len(dec.data) != 0 {
+		return &oxygen.SyntaxError{Name: cfg.Name, Struct: "Payment", Offset: dec.offset(), Err: oxygen.ErrTrailingData}
+	}
+	return nil
+}
+
+// MarshalBatch encodes the value v the same way as Marshal does, but without reflection.
+func MarshalBatch(v *records.Batch) ([]byte, error) {
+	if v == nil {
+		v = new(records.Batch)
+	}
+
+	enc := codecEncoderPool.Get().(*codecEncoder)
+	defer codecEncoderPool.Put(enc)
+	enc.Reset()
+	enc.nesting = 0
+
+	if err := enc.encodeNestedBatch(v, codecWrap); err != nil {
+		return nil, codecRoot(err, "Batch")
+	}
+
+	return append([]byte(nil), enc.Bytes()...), nil
+}
+
+// UnmarshalBatch decodes the encoded data the same way as Unmarshal does, but without reflection.
+func UnmarshalBatch(data []byte, v *records.Batch) error {
+	dec := &codecDecoder{data: data, size: len(data)}
+	if cfg.Charset != nil {
+		dec.src, dec.text = data, cfg.Charset.Decode(nil, data)
+	}
+	if err := dec.decodeNestedBatch(v, codecRemoveWrapper); err != nil {
+		return codecRoot(err, "Batch")
+	}
+	if len(dec.missing) != 0 {
+		return &oxygen.RequiredFieldError{Name: cfg.Name, Struct: "Batch", Fields: dec.missing}
+	}
+	if codecStrict && len(dec.data) != 0 {
+		return &oxygen.SyntaxError{Name: cfg.Name, Struct: "Batch", Offset: dec.offset(), Err: oxygen.ErrTrailingData}
+	}
+	return nil
+}
+
+// MarshalAddress encodes the value v the same way as Marshal does, but without reflection.
+func MarshalAddress(v *records.Address) ([]byte, error) {
+	if v == nil {
+		v = new(records.Address)
+	}
+
+	enc := codecEncoderPool.Get().(*codecEncoder)
+	defer codecEncoderPool.Put(enc)
+	enc.Reset()
+	enc.nesting = 0
+
+	if err := enc.encodeNestedAddress(v, codecWrap); err != nil {
+		return nil, codecRoot(err, "Address")
+	}
+
+	return append([]byte(nil), enc.Bytes()...), nil
+}
+
+// UnmarshalAddress decodes the encoded data the same way as Unmarshal does, but without reflection.
+func UnmarshalAddress(data []byte, v *records.Address) error {
+	dec := &codecDecoder{data: data, size: len(data)}
+	if cfg.Charset != nil {
+		dec.src, dec.text = data, cfg.Charset.Decode(nil, data)
+	}
+	if err := dec.decodeNestedAddress(v, codecRemoveWrapper); err != nil {
+		return codecRoot(err, "Address")
+	}
+	if len(dec.missing) != 0 {
+		return &oxygen.RequiredFieldError{Name: cfg.Name, Struct: "Address", Fields: dec.missing}
+	}
+	if codecStrict && len(dec.data) != 0 {
+		return &oxygen.SyntaxError{Name: cfg.Name, Struct: "Address", Offset: dec.offset(), Err: oxygen.ErrTrailingData}
+	}
+	return nil
+}
+
+// MarshalTicket encodes the value v the same way as Marshal does, but without reflection.
+func MarshalTicket(v *records.Ticket) ([]byte, error) {
+	if v == nil {
+		v = new(records.Ticket)
+	}
+
+	enc := codecEncoderPool.Get().(*codecEncoder)
+	defer codecEncoderPool.Put(enc)
+	enc.Reset()
+	enc.nesting = 0
+
+	if err := enc.encodeNestedTicket(v, codecWrap); err != nil {
+		return nil, codecRoot(err, "Ticket")
+	}
+
+	return append([]byte(nil), enc.Bytes()...), nil
+}
+
+// UnmarshalTicket decodes the encoded data the same way as Unmarshal does, but without reflection.
+func UnmarshalTicket(data []byte, v *records.Ticket) error {
+	dec := &codecDecoder{data: data, size: len(data)}
+	if cfg.Charset != nil {
+		dec.src, dec.text = data, cfg.Charset.Decode(nil, data)
+	}
+	if err := dec.decodeNestedTicket(v, codecRemoveWrapper); err != nil {
+		return codecRoot(err, "Ticket")
+	}
+	if len(dec.missing) != 0 {
+		return &oxygen.RequiredFieldError{Name: cfg.Name, Struct: "Ticket", Fields: dec.missing}
+	}
+	if codecStrict && len(dec.data) != 0 {
+		return &oxygen.SyntaxError{Name: cfg.Name, Struct: "Ticket", Offset: dec.offset(), Err: oxygen.ErrTrailingData}
+	}
+	return nil
+}
+
+// MarshalTimer encodes the value v the same way as Marshal does, but without reflection.
+func MarshalTimer(v *records.Timer) ([]byte, error) {
+	if v == nil {
+		v = new(records.Timer)
+	}
+
+	enc := codecEncoderPool.Get().(*codecEncoder)
+	defer codecEncoderPool.Put(enc)
+	enc.Reset()
+	enc.nesting = 0
+
+	if err := enc.encodeNestedTimer(v, codecWrap); err != nil {
+		return nil, codecRoot(err, "Timer")
+	}
+
+	return append([]byte(nil), enc.Bytes()...), nil
+}
+
+// UnmarshalTimer decodes the encoded data the same way as Unmarshal does, but without reflection.
+func UnmarshalTimer(data []byte, v *records.Timer) error {
+	dec := &codecDecoder{data: data, size: len(data)}
+	if cfg.Charset != nil {
+		dec.src, dec.text = data, cfg.Charset.Decode(nil, data)
+	}
+	if err := dec.decodeNestedTimer(v, codecRemoveWrapper); err != nil {
+		return codecRoot(err, "Timer")
+	}
+	if len(dec.missing) != 0 {
+		return &oxygen.RequiredFieldError{Name: cfg.Name, Struct: "Timer", Fields: dec.missing}
+	}
+	if codecStrict && len(dec.data) != 0 {
+		return &oxygen.SyntaxError{Name: cfg.Name, Struct: "Timer", Offset: dec.offset(), Err: oxygen.ErrTrailingData}
+	}
+	return nil
+}
+
+// MarshalNode encodes the value v the same way as Marshal does, but without reflection.
+func MarshalNode(v *records.Node) ([]byte, error) {
+	if v == nil {
+		v = new(records.Node)
+	}
+
+	enc := codecEncoderPool.Get().(*codecEncoder)
+	defer codecEncoderPool.Put(enc)
+	enc.Reset()
+	enc.nesting = 0
+
+	if err := enc.encodeNestedNode(v, codecWrap); err != nil {
+		return nil, codecRoot(err, "Node")
+	}
+
+	return append([]byte(nil), enc.Bytes()...), nil
+}
+
+// UnmarshalNode decodes the encoded data the same way as Unmarshal does, but without reflection.
+func UnmarshalNode(data []byte, v *records.Node) error {
+	dec := &codecDecoder{data: data, size: len(data)}
+	if cfg.Charset != nil {
+		dec.src, dec.text = data, cfg.Charset.Decode(nil, data)
+	}
+	if err := dec.decodeNestedNode(v, codecRemoveWrapper); err != nil {
+		return codecRoot(err, "Node")
+	}
+	if len(dec.missing) != 0 {
+		return &oxygen.RequiredFieldError{Name: cfg.Name, Struct: "Node", Fields: dec.missing}
+	}
+	if codecStrict && len(dec.data) != 0 {
+		return &oxygen.SyntaxError{Name: cfg.Name, Struct: "Node", Offset: dec.offset(), Err: oxygen.ErrTrailingData}
+	}
+	return nil
+}
+
+func codecSiblingOrder(v *records.Order, name string) any {
+	switch name {
+	case "ID":
+		return v.ID
+	case "Paid":
+		return v.Paid
+	case "Amount":
+		return v.Amount
+	case "Code":
+		return v.Code
+	case "Note":
+		return v.Note
+	case "Raw":
+		return v.Raw
+	case "Items":
+		return v.Items
+	case "Pins":
+		return v.Pins
+	case "Next":
+		return v.Next
+	case "State":
+		return v.State
+	case "Created":
+		return v.Created
+	case "Tags":
+		return v.Tags
+	case "Extra":
+		return v.Extra
+	case "Cents":
+		return v.Cents
+	case "Hex":
+		return v.Hex
+	case "Parts":
+		return v.Parts
+	case "Sizes":
+		return v.Sizes
+	case "Port":
+		return v.Port
+	case "Ratio":
+		return v.Ratio
+	case "Total":
+		return v.Total
+	case "Count":
+		return v.Count
+	}
+	return nil
+}
+
+func codecRemainingOrder(v *records.Order, from int, fn func(string, *codecTag, codecFields) bool) bool {
+	for i := from; i < 22; i++ {
+		switch i {
+		case 0: // Header
+			if !codecRemainingHeader(&v.Header, 0, fn) {
+				return false
+			}
+		case 1: // ID
+			if ok, err := codecPresent(&codecOrder_ID, v, codecSiblingOrder); (ok || err != nil) && !fn("ID", &codecOrder_ID, nil) {
+				return false
+			}
+		case 2: // Paid
+			if ok, err := codecPresent(&codecOrder_Paid, v, codecSiblingOrder); (ok || err != nil) && !fn("Paid", &codecOrder_Paid, nil) {
+				return false
+			}
+		case 3: // Amount
+			if ok, err := codecPresent(&codecOrder_Amount, v, codecSiblingOrder); (ok || err != nil) && !fn("Amount", &codecOrder_Amount, nil) {
+				return false
+			}
+		case 4: // Code
+			if ok, err := codecPresent(&codecOrder_Code, v, codecSiblingOrder); (ok || err != nil) && !fn("Code", &codecOrder_Code, nil) {
+				return false
+			}
+		case 5: // Note
+			if ok, err := codecPresent(&codecOrder_Note, v, codecSiblingOrder); (ok || err != nil) && !fn("Note", &codecOrder_Note, nil) {
+				return false
+			}
+		case 6: // Raw
+			if ok, err := codecPresent(&codecOrder_Raw, v, codecSiblingOrder); (ok || err != nil) && !fn("Raw", &codecOrder_Raw, nil) {
+				return false
+			}
+		case 7: // Items
+			if !fn("Items", nil, nil) {
+				return false
+			}
+		case 8: // Pins
+			if ok, err := codecPresent(&codecOrder_Pins, v, codecSiblingOrder); (ok || err != nil) && !fn("Pins", &codecOrder_Pins, nil) {
+				return false
+			}
+		case 9: // Next
+			if !fn("Next", nil, nil) {
+				return false
+			}
+		case 10: // State
+			if ok, err := codecPresent(&codecOrder_State, v, codecSiblingOrder); (ok || err != nil) && !fn("State", &codecOrder_State, nil) {
+				return false
+			}
+		case 11: // Created
+			var nested codecFields
+			if !codecTextMarshaler {
+				nested = codecNested[time.Time](func(fn func(string, *codecTag, codecFields) bool) bool {
+					return codecRemainingTimeTime(&v.Created, 0, fn)
+				})
+			}
+			if ok, err := codecPresent(&codecOrder_Created, v, codecSiblingOrder); (ok || err != nil) && !fn("Created", &codecOrder_Created, nested) {
+				return false
+			}
+		case 12: // Tags
+			if ok, err := codecPresent(&codecOrder_Tags, v, codecSiblingOrder); (ok || err != nil) && !fn("Tags", &codecOrder_Tags, nil) {
+				return false
+			}
+		case 13: // Extra
+			if ok, err := codecPresent(&codecOrder_Extra, v, codecSiblingOrder); (ok || err != nil) && !fn("Extra", &codecOrder_Extra, nil) {
+				return false
+			}
+		case 14: // Cents
+			if ok, err := codecPresent(&codecOrder_Cents, v, codecSiblingOrder); (ok || err != nil) && !fn("Cents", &codecOrder_Cents, nil) {
+				return false
+			}
+		case 15: // Hex
+			if ok, err := codecPresent(&codecOrder_Hex, v, codecSiblingOrder); (ok || err != nil) && !fn("Hex", &codecOrder_Hex, nil) {
+				return false
+			}
+		case 16: // Parts
+			if ok, err := codecPresent(&codecOrder_Parts, v, codecSiblingOrder); (ok || err != nil) && !fn("Parts", &codecOrder_Parts, nil) {
+				return false
+			}
+		case 17: // Sizes
+			if ok, err := codecPresent(&codecOrder_Sizes, v, codecSiblingOrder); (ok || err != nil) && !fn("Sizes", &codecOrder_Sizes, nil) {
+				return false
+			}
+		case 18: // Port
+			if ok, err := codecPresent(&codecOrder_Port, v, codecSiblingOrder); (ok || err != nil) && !fn("Port", &codecOrder_Port, nil) {
+				return false
+			}
+		case 19: // Ratio
+			if ok, err := codecPresent(&codecOrder_Ratio, v, codecSiblingOrder); (ok || err != nil) && !fn("Ratio", &codecOrder_Ratio, nil) {
+				return false
+			}
+		case 20: // Total
+			if ok, err := codecPresent(&codecOrder_Total, v, codecSiblingOrder); (ok || err != nil) && !fn("Total", &codecOrder_Total, nil) {
+				return false
+			}
+		case 21: // Count
+			if ok, err := codecPresent(&codecOrder_Count, v, codecSiblingOrder); (ok || err != nil) && !fn("Count", &codecOrder_Count, nil) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (enc *codecEncoder) encodeOrder(v *records.Order, wrap bool) error {
+	sep := false
+	if wrap {
+		enc.Write(codecStructOpener)
+	}
+	// Header
+	if sep {
+		enc.Write(codecValueSeparator)
+	}
+	sep = codecSeparate
+	if err := enc.encodeHeader(&v.Header, false); err != nil {
+		return err
+	}
+	// ID
+	c1 := v.ID
+	switch "ID" {
+	case codecOrder_Tags.count:
+		n, err := codecLength[int](len(v.Tags))
+		if err != nil {
+			return codecMarshalError(err, "ID", codecTypeOf[int])
+		}
+		c1 = n
+	case codecOrder_Sizes.count:
+		n, err := codecLength[int](len(v.Sizes))
+		if err != nil {
+			return codecMarshalError(err, "ID", codecTypeOf[int])
+		}
+		c1 = n
+	}
+	if codecOrder_ID.encodeDef && c1 == 0 {
+		c1 = *codecOrder_ID.def.(*int)
+	}
+	if ok, err := codecPresent(&codecOrder_ID, v, codecSiblingOrder); err != nil {
+		return &oxygen.TagError{Name: cfg.Name, Tag: "4,0,r", Field: "ID", Err: err}
+	} else if ok && !(codecOrder_ID.omit && c1 == 0) {
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if codecOrder_ID.err != nil {
+			return &oxygen.TagError{Name: cfg.Name, Tag: "4,0,r", Field: "ID", Err: codecOrder_ID.err}
+		}
+		if fn2, _ := codecRegistered[int](); fn2 != nil {
+			p, err := fn2("ID", codecOrder_ID.tag, c1)
+			if err != nil {
+				return codecMarshalError(err, "ID", codecTypeOf[int])
+			}
+			if err := enc.value("ID", codecOrder_ID.tag, p, false); err != nil {
+				return codecMarshalError(err, "ID", codecTypeOf[int])
+			}
+		} else {
+			if o := codecOrder_ID.order; o != nil {
+				enc.Write(oxygen.AppendBinary(enc.scratch[:0], o, uint64(c1), strconv.IntSize/8))
+			} else {
+				if err := codecOrder_ID.format.CheckInt(int64(c1)); err != nil {
+					return codecMarshalError(err, "ID", codecTypeOf[int])
+				}
+				if err := enc.value("ID", codecOrder_ID.tag, codecOrder_ID.format.AppendInt(enc.scratch[:0], int64(c1)), codecOrder_ID.format.Encoding == oxygen.PackedDecimal); err != nil {
+					return codecMarshalError(err, "ID", codecTypeOf[int])
+				}
+			}
+		}
+	}
+	// Paid
+	d7 := v.Paid
+	if codecOrder_Paid.encodeDef && !d7 {
+		d7 = *codecOrder_Paid.def.(*bool)
+	}
+	if ok, err := codecPresent(&codecOrder_Paid, v, codecSiblingOrder); err != nil {
+		return &oxygen.TagError{Name: cfg.Name, Tag: "5, ,l", Field: "Paid", Err: err}
+	} else if ok && !(codecOrder_Paid.omit && !d7) {
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if codecOrder_Paid.err != nil {
+			return &oxygen.TagError{Name: cfg.Name, Tag: "5, ,l", Field: "Paid", Err: codecOrder_Paid.err}
+		}
+		if fn8, _ := codecRegistered[bool](); fn8 != nil {
+			p, err := fn8("Paid", codecOrder_Paid.tag, d7)
+			if err != nil {
+				return codecMarshalError(err, "Paid", codecTypeOf[bool])
+			}
+			if err := enc.value("Paid", codecOrder_Paid.tag, p, false); err != nil {
+				return codecMarshalError(err, "Paid", codecTypeOf[bool])
+			}
+		} else {
+			if o := codecOrder_Paid.order; o != nil {
+				enc.Write(oxygen.AppendBinary(enc.scratch[:0], o, codecBinaryBool(bool(d7)), 1))
+			} else {
+				if err := enc.value("Paid", codecOrder_Paid.tag, strconv.AppendBool(enc.scratch[:0], bool(d7)), false); err != nil {
+					return codecMarshalError(err, "Paid", codecTypeOf[bool])
+				}
+			}
+		}
+	}
+	// Amount
+	d13 := v.Amount
+	if codecOrder_Amount.encodeDef && d13 == 0 {
+		d13 = *codecOrder_Amount.def.(*float64)
+	}
+	if ok, err := codecPresent(&codecOrder_Amount, v, codecSiblingOrder); err != nil {
+		return &oxygen.TagError{Name: cfg.Name, Tag: "6,0,r", Field: "Amount", Err: err}
+	} else if ok && !(codecOrder_Amount.omit && d13 == 0) {
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if codecOrder_Amount.err != nil {
+			return &oxygen.TagError{Name: cfg.Name, Tag: "6,0,r", Field: "Amount", Err: codecOrder_Amount.err}
+		}
+		if fn14, _ := codecRegistered[float64](); fn14 != nil {
+			p, err := fn14("Amount", codecOrder_Amount.tag, d13)
+			if err != nil {
+				return codecMarshalError(err, "Amount", codecTypeOf[float64])
+			}
+			if err := enc.value("Amount", codecOrder_Amount.tag, p, false); err != nil {
+				return codecMarshalError(err, "Amount", codecTypeOf[float64])
+			}
+		} else {
+			if o := codecOrder_Amount.order; o != nil {
+				enc.Write(oxygen.AppendBinary(enc.scratch[:0], o, codecFloatBits(float64(d13), 8), 8))
+			} else {
+				if err := codecOrder_Amount.format.CheckFloat(float64(d13)); err != nil {
+					return codecMarshalError(err, "Amount", codecTypeOf[float64])
+				}
+				if err := enc.value("Amount", codecOrder_Amount.tag, codecOrder_Amount.format.AppendFloat(enc.scratch[:0], float64(d13), 64), codecOrder_Amount.format.Encoding == oxygen.PackedDecimal); err != nil {
+					return codecMarshalError(err, "Amount", codecTypeOf[float64])
+				}
+			}
+		}
+	}
+	// Code
+	d19 := v.Code
+	if codecOrder_Code.encodeDef && len(d19) == 0 {
+		d19 = *codecOrder_Code.def.(*records.Code)
+	}
+	if ok, err := codecPresent(&codecOrder_Code, v, codecSiblingOrder); err != nil {
+		return &oxygen.TagError{Name: cfg.Name, Tag: "3,_,l", Field: "Code", Err: err}
+	} else if ok && !(codecOrder_Code.omit && len(d19) == 0) {
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if codecOrder_Code.err != nil {
+			return &oxygen.TagError{Name: cfg.Name, Tag: "3,_,l", Field: "Code", Err: codecOrder_Code.err}
+		}
+		if fn20, _ := codecRegistered[records.Code](); fn20 != nil {
+			p, err := fn20("Code", codecOrder_Code.tag, d19)
+			if err != nil {
+				return codecMarshalError(err, "Code", codecTypeOf[records.Code])
+			}
+			if err := enc.value("Code", codecOrder_Code.tag, p, false); err != nil {
+				return codecMarshalError(err, "Code", codecTypeOf[records.Code])
+			}
+		} else {
+			if err := enc.value("Code", codecOrder_Code.tag, append(enc.scratch[:0], string(d19)...), false); err != nil {
+				return codecMarshalError(err, "Code", codecTypeOf[records.Code])
+			}
+		}
+	}
+	// Note
+	d24 := v.Note
+	if codecOrder_Note.encodeDef && d24 == nil {
+		d24 = codecOrder_Note.def.(*string)
+	}
+	if ok, err := codecPresent(&codecOrder_Note, v, codecSiblingOrder); err != nil {
+		return &oxygen.TagError{Name: cfg.Name, Tag: "6,_,l", Field: "Note", Err: err}
+	} else if ok && !(codecOrder_Note.omit && d24 == nil) {
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if codecOrder_Note.err != nil {
+			return &oxygen.TagError{Name: cfg.Name, Tag: "6,_,l", Field: "Note", Err: codecOrder_Note.err}
+		}
+		if fn25, _ := codecRegistered[*string](); fn25 != nil {
+			p, err := fn25("Note", codecOrder_Note.tag, d24)
+			if err != nil {
+				return codecMarshalError(err, "Note", codecTypeOf[*string])
+			}
+			if err := enc.value("Note", codecOrder_Note.tag, p, false); err != nil {
+				return codecMarshalError(err, "Note", codecTypeOf[*string])
+			}
+		} else {
+			p26 := d24
+			if p26 == nil {
+				p26 = new(string)
+			}
+			if fn27, _ := codecRegistered[string](); fn27 != nil {
+				p, err := fn27("Note", codecOrder_Note.tag, (*p26))
+				if err != nil {
+					return codecMarshalError(err, "Note", codecTypeOf[*string])
+				}
+				if err := enc.value("Note", codecOrder_Note.tag, p, false); err != nil {
+					return codecMarshalError(err, "Note", codecTypeOf[*string])
+				}
+			} else {
+				if err := enc.value("Note", codecOrder_Note.tag, append(enc.scratch[:0], string((*p26))...), false); err != nil {
+					return codecMarshalError(err, "Note", codecTypeOf[*string])
+				}
+			}
+		}
+	}
+	// Raw
+	if ok, err := codecPresent(&codecOrder_Raw, v, codecSiblingOrder); err != nil {
+		return &oxygen.TagError{Name: cfg.Name, Tag: "3, ,l", Field: "Raw", Err: err}
+	} else if ok && !(codecOrder_Raw.omit && len(v.Raw) == 0) {
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if codecOrder_Raw.err != nil {
+			return &oxygen.TagError{Name: cfg.Name, Tag: "3, ,l", Field: "Raw", Err: codecOrder_Raw.err}
+		}
+		if fn37, _ := codecRegistered[[]byte](); fn37 != nil {
+			p, err := fn37("Raw", codecOrder_Raw.tag, v.Raw)
+			if err != nil {
+				return codecMarshalError(err, "Raw", codecTypeOf[[]byte])
+			}
+			if err := enc.value("Raw", codecOrder_Raw.tag, p, false); err != nil {
+				return codecMarshalError(err, "Raw", codecTypeOf[[]byte])
+			}
+		} else {
+			if err := enc.value("Raw", codecOrder_Raw.tag, []byte(v.Raw), false); err != nil {
+				return codecMarshalError(err, "Raw", codecTypeOf[[]byte])
+			}
+		}
+	}
+	// Items
+	{
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if fn41, _ := codecRegistered[[]records.Line](); fn41 != nil {
+			p, err := fn41("Items", nil, v.Items)
+			if err != nil {
+				return codecMarshalError(err, "Items", codecTypeOf[[]records.Line])
+			}
+			if err := enc.value("Items", nil, p, false); err != nil {
+				return codecMarshalError(err, "Items", codecTypeOf[[]records.Line])
+			}
+		} else {
+			if err := enc.enter(); err != nil {
+				return codecMarshalError(err, "Items", codecTypeOf[[]records.Line])
+			}
+			if codecWrapList {
+				enc.Write(codecListOpener)
+			}
+			for i42 := range v.Items {
+				if i42 > 0 && codecSeparateElems {
+					enc.Write(codecElementSeparator)
+				}
+				if fn43, _ := codecRegistered[records.Line](); fn43 != nil {
+					p, err := fn43("Items", nil, v.Items[i42])
+					if err != nil {
+						return codecMarshalError(err, "Items", codecTypeOf[[]records.Line])
+					}
+					if err := enc.value("Items", nil, p, false); err != nil {
+						return codecMarshalError(err, "Items", codecTypeOf[[]records.Line])
+					}
+				} else {
+					if err := enc.encodeNestedLine(&v.Items[i42], codecWrap); err != nil {
+						return codecMarshalError(err, "Items", codecTypeOf[[]records.Line])
+					}
+				}
+			}
+			if codecWrapList {
+				enc.Write(codecListCloser)
+			}
+			enc.leave()
+		}
+	}
+	// Pins
+	if ok, err := codecPresent(&codecOrder_Pins, v, codecSiblingOrder); err != nil {
+		return &oxygen.TagError{Name: cfg.Name, Tag: "3,0,r", Field: "Pins", Err: err}
+	} else if ok && !(codecOrder_Pins.omit && len(v.Pins) == 0) {
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if codecOrder_Pins.err != nil {
+			return &oxygen.TagError{Name: cfg.Name, Tag: "3,0,r", Field: "Pins", Err: codecOrder_Pins.err}
+		}
+		if fn53, _ := codecRegistered[[2]uint16](); fn53 != nil {
+			p, err := fn53("Pins", codecOrder_Pins.tag, v.Pins)
+			if err != nil {
+				return codecMarshalError(err, "Pins", codecTypeOf[[2]uint16])
+			}
+			if err := enc.value("Pins", codecOrder_Pins.tag, p, false); err != nil {
+				return codecMarshalError(err, "Pins", codecTypeOf[[2]uint16])
+			}
+		} else {
+			if err := enc.enter(); err != nil {
+				return codecMarshalError(err, "Pins", codecTypeOf[[2]uint16])
+			}
+			if codecWrapList {
+				enc.Write(codecListOpener)
+			}
+			for i54 := range v.Pins {
+				if i54 > 0 && codecSeparateElems {
+					enc.Write(codecElementSeparator)
+				}
+				if fn55, _ := codecRegistered[uint16](); fn55 != nil {
+					p, err := fn55("Pins", codecOrder_Pins.tag, v.Pins[i54])
+					if err != nil {
+						return codecMarshalError(err, "Pins", codecTypeOf[[2]uint16])
+					}
+					if err := enc.value("Pins", codecOrder_Pins.tag, p, false); err != nil {
+						return codecMarshalError(err, "Pins", codecTypeOf[[2]uint16])
+					}
+				} else {
+					if o := codecOrder_Pins.order; o != nil {
+						enc.Write(oxygen.AppendBinary(enc.scratch[:0], o, uint64(v.Pins[i54]), 2))
+					} else {
+						if err := codecOrder_Pins.format.CheckUint(uint64(v.Pins[i54])); err != nil {
+							return codecMarshalError(err, "Pins", codecTypeOf[[2]uint16])
+						}
+						if err := enc.value("Pins", codecOrder_Pins.tag, codecOrder_Pins.format.AppendUint(enc.scratch[:0], uint64(v.Pins[i54])), codecOrder_Pins.format.Encoding == oxygen.PackedDecimal); err != nil {
+							return codecMarshalError(err, "Pins", codecTypeOf[[2]uint16])
+						}
+					}
+				}
+			}
+			if codecWrapList {
+				enc.Write(codecListCloser)
+			}
+			enc.leave()
+		}
+	}
+	// Next
+	{
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if fn65, _ := codecRegistered[*records.Line](); fn65 != nil {
+			p, err := fn65("Next", nil, v.Next)
+			if err != nil {
+				return codecMarshalError(err, "Next", codecTypeOf[*records.Line])
+			}
+			if err := enc.value("Next", nil, p, false); err != nil {
+				return codecMarshalError(err, "Next", codecTypeOf[*records.Line])
+			}
+		} else {
+			p66 := v.Next
+			if p66 == nil {
+				p66 = new(records.Line)
+			}
+			if fn67, _ := codecRegistered[records.Line](); fn67 != nil {
+				p, err := fn67("Next", nil, (*p66))
+				if err != nil {
+					return codecMarshalError(err, "Next", codecTypeOf[*records.Line])
+				}
+				if err := enc.value("Next", nil, p, false); err != nil {
+					return codecMarshalError(err, "Next", codecTypeOf[*records.Line])
+				}
+			} else {
+				if err := enc.encodeNestedLine(p66, codecWrap); err != nil {
+					return codecMarshalError(err, "Next", codecTypeOf[*records.Line])
+				}
+			}
+		}
+	}
+	// State
+	c74 := v.State
+	switch "State" {
+	case codecOrder_Tags.count:
+		n, err := codecLength[records.State](len(v.Tags))
+		if err != nil {
+			return codecMarshalError(err, "State", codecTypeOf[records.State])
+		}
+		c74 = n
+	case codecOrder_Sizes.count:
+		n, err := codecLength[records.State](len(v.Sizes))
+		if err != nil {
+			return codecMarshalError(err, "State", codecTypeOf[records.State])
+		}
+		c74 = n
+	}
+	if codecOrder_State.encodeDef && c74 == 0 {
+		c74 = *codecOrder_State.def.(*records.State)
+	}
+	if ok, err := codecPresent(&codecOrder_State, v, codecSiblingOrder); err != nil {
+		return &oxygen.TagError{Name: cfg.Name, Tag: "3, ,l", Field: "State", Err: err}
+	} else if ok && !(codecOrder_State.omit && c74 == 0) {
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if codecOrder_State.err != nil {
+			return &oxygen.TagError{Name: cfg.Name, Tag: "3, ,l", Field: "State", Err: codecOrder_State.err}
+		}
+		if fn75, _ := codecRegistered[records.State](); fn75 != nil {
+			p, err := fn75("State", codecOrder_State.tag, c74)
+			if err != nil {
+				return codecMarshalError(err, "State", codecTypeOf[records.State])
+			}
+			if err := enc.value("State", codecOrder_State.tag, p, false); err != nil {
+				return codecMarshalError(err, "State", codecTypeOf[records.State])
+			}
+		} else {
+			v76 := c74
+			p, err := (&v76).MarshalTEST()
+			if err != nil {
+				return codecMarshalError(err, "State", codecTypeOf[records.State])
+			}
+			if err := enc.value("State", codecOrder_State.tag, p, false); err != nil {
+				return codecMarshalError(err, "State", codecTypeOf[records.State])
+			}
+		}
+	}
+	// Created
+	d81 := v.Created
+	if codecOrder_Created.encodeDef && d81 == *new(time.Time) {
+		d81 = *codecOrder_Created.def.(*time.Time)
+	}
+	if ok, err := codecPresent(&codecOrder_Created, v, codecSiblingOrder); err != nil {
+		return &oxygen.TagError{Name: cfg.Name, Tag: "20, ,l", Field: "Created", Err: err}
+	} else if ok {
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if codecOrder_Created.err != nil {
+			return &oxygen.TagError{Name: cfg.Name, Tag: "20, ,l", Field: "Created", Err: codecOrder_Created.err}
+		}
+		if fn82, _ := codecRegistered[time.Time](); fn82 != nil {
+			p, err := fn82("Created", codecOrder_Created.tag, d81)
+			if err != nil {
+				return codecMarshalError(err, "Created", codecTypeOf[time.Time])
+			}
+			if err := enc.value("Created", codecOrder_Created.tag, p, false); err != nil {
+				return codecMarshalError(err, "Created", codecTypeOf[time.Time])
+			}
+		} else {
+			if codecTextMarshaler {
+				v83 := d81
+				p, err := (&v83).MarshalText()
+				if err != nil {
+					return codecMarshalError(err, "Created", codecTypeOf[time.Time])
+				}
+				if err := enc.value("Created", codecOrder_Created.tag, p, false); err != nil {
+					return codecMarshalError(err, "Created", codecTypeOf[time.Time])
+				}
+			} else {
+				if err := enc.encodeNestedTimeTime(&d81, codecWrap); err != nil {
+					return codecMarshalError(err, "Created", codecTypeOf[time.Time])
+				}
+			}
+		}
+	}
+	// Tags
+	switch codecOrder_Tags.count {
+	case "ID":
+		if len(v.Tags) != int(c1) {
+			return codecMarshalError(fmt.Errorf("length %d of the slice differs from the count %d", len(v.Tags), int(c1)), "Tags", codecTypeOf[[]string])
+		}
+	case "State":
+		if len(v.Tags) != int(c74) {
+			return codecMarshalError(fmt.Errorf("length %d of the slice differs from the count %d", len(v.Tags), int(c74)), "Tags", codecTypeOf[[]string])
+		}
+	}
+	if ok, err := codecPresent(&codecOrder_Tags, v, codecSiblingOrder); err != nil {
+		return &oxygen.TagError{Name: cfg.Name, Tag: "2", Field: "Tags", Err: err}
+	} else if ok && !(codecOrder_Tags.omit && len(v.Tags) == 0) {
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if codecOrder_Tags.err != nil {
+			return &oxygen.TagError{Name: cfg.Name, Tag: "2", Field: "Tags", Err: codecOrder_Tags.err}
+		}
+		if fn89, _ := codecRegistered[[]string](); fn89 != nil {
+			p, err := fn89("Tags", codecOrder_Tags.tag, v.Tags)
+			if err != nil {
+				return codecMarshalError(err, "Tags", codecTypeOf[[]string])
+			}
+			if err := enc.value("Tags", codecOrder_Tags.tag, p, false); err != nil {
+				return codecMarshalError(err, "Tags", codecTypeOf[[]string])
+			}
+		} else {
+			if err := enc.enter(); err != nil {
+				return codecMarshalError(err, "Tags", codecTypeOf[[]string])
+			}
+			if codecWrapList {
+				enc.Write(codecListOpener)
+			}
+			for i90 := range v.Tags {
+				if i90 > 0 && codecSeparateElems {
+					enc.Write(codecElementSeparator)
+				}
+				if fn91, _ := codecRegistered[string](); fn91 != nil {
+					p, err := fn91("Tags", codecOrder_Tags.tag, v.Tags[i90])
+					if err != nil {
+						return codecMarshalError(err, "Tags", codecTypeOf[[]string])
+					}
+					if err := enc.value("Tags", codecOrder_Tags.tag, p, false); err != nil {
+						return codecMarshalError(err, "Tags", codecTypeOf[[]string])
+					}
+				} else {
+					if err := enc.value("Tags", codecOrder_Tags.tag, append(enc.scratch[:0], string(v.Tags[i90])...), false); err != nil {
+						return codecMarshalError(err, "Tags", codecTypeOf[[]string])
+					}
+				}
+			}
+			if codecWrapList {
+				enc.Write(codecListCloser)
+			}
+			enc.leave()
+		}
+	}
+	// Extra
+	d107 := v.Extra
+	if codecOrder_Extra.encodeDef && len(d107) == 0 {
+		d107 = *codecOrder_Extra.def.(*string)
+	}
+	if ok, err := codecPresent(&codecOrder_Extra, v, codecSiblingOrder); err != nil {
+		return &oxygen.TagError{Name: cfg.Name, Tag: "2, ,l", Field: "Extra", Err: err}
+	} else if ok && !(codecOrder_Extra.omit && len(d107) == 0) {
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if codecOrder_Extra.err != nil {
+			return &oxygen.TagError{Name: cfg.Name, Tag: "2, ,l", Field: "Extra", Err: codecOrder_Extra.err}
+		}
+		if fn108, _ := codecRegistered[string](); fn108 != nil {
+			p, err := fn108("Extra", codecOrder_Extra.tag, d107)
+			if err != nil {
+				return codecMarshalError(err, "Extra", codecTypeOf[string])
+			}
+			if err := enc.value("Extra", codecOrder_Extra.tag, p, false); err != nil {
+				return codecMarshalError(err, "Extra", codecTypeOf[string])
+			}
+		} else {
+			if err := enc.value("Extra", codecOrder_Extra.tag, append(enc.scratch[:0], string(d107)...), false); err != nil {
+				return codecMarshalError(err, "Extra", codecTypeOf[string])
+			}
+		}
+	}
+	// Cents
+	d112 := v.Cents
+	if codecOrder_Cents.encodeDef && d112 == 0 {
+		d112 = *codecOrder_Cents.def.(*float64)
+	}
+	if ok, err := codecPresent(&codecOrder_Cents, v, codecSiblingOrder); err != nil {
+		return &oxygen.TagError{Name: cfg.Name, Tag: "7,0,r,i2", Field: "Cents", Err: err}
+	} else if ok && !(codecOrder_Cents.omit && d112 == 0) {
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if codecOrder_Cents.err != nil {
+			return &oxygen.TagError{Name: cfg.Name, Tag: "7,0,r,i2", Field: "Cents", Err: codecOrder_Cents.err}
+		}
+		if fn113, _ := codecRegistered[float64](); fn113 != nil {
+			p, err := fn113("Cents", codecOrder_Cents.tag, d112)
+			if err != nil {
+				return codecMarshalError(err, "Cents", codecTypeOf[float64])
+			}
+			if err := enc.value("Cents", codecOrder_Cents.tag, p, false); err != nil {
+				return codecMarshalError(err, "Cents", codecTypeOf[float64])
+			}
+		} else {
+			if o := codecOrder_Cents.order; o != nil {
+				enc.Write(oxygen.AppendBinary(enc.scratch[:0], o, codecFloatBits(float64(d112), 8), 8))
+			} else {
+				if err := codecOrder_Cents.format.CheckFloat(float64(d112)); err != nil {
+					return codecMarshalError(err, "Cents", codecTypeOf[float64])
+				}
+				if err := enc.value("Cents", codecOrder_Cents.tag, codecOrder_Cents.format.AppendFloat(enc.scratch[:0], float64(d112), 64), codecOrder_Cents.format.Encoding == oxygen.PackedDecimal); err != nil {
+					return codecMarshalError(err, "Cents", codecTypeOf[float64])
+				}
+			}
+		}
+	}
+	// Hex
+	c118 := v.Hex
+	switch "Hex" {
+	case codecOrder_Sizes.count:
+		n, err := codecLength[uint32](len(v.Sizes))
+		if err != nil {
+			return codecMarshalError(err, "Hex", codecTypeOf[uint32])
+		}
+		c118 = n
+	}
+	if codecOrder_Hex.encodeDef && c118 == 0 {
+		c118 = *codecOrder_Hex.def.(*uint32)
+	}
+	if ok, err := codecPresent(&codecOrder_Hex, v, codecSiblingOrder); err != nil {
+		return &oxygen.TagError{Name: cfg.Name, Tag: "4,0,r,x16", Field: "Hex", Err: err}
+	} else if ok && !(codecOrder_Hex.omit && c118 == 0) {
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if codecOrder_Hex.err != nil {
+			return &oxygen.TagError{Name: cfg.Name, Tag: "4,0,r,x16", Field: "Hex", Err: codecOrder_Hex.err}
+		}
+		if fn119, _ := codecRegistered[uint32](); fn119 != nil {
+			p, err := fn119("Hex", codecOrder_Hex.tag, c118)
+			if err != nil {
+				return codecMarshalError(err, "Hex", codecTypeOf[uint32])
+			}
+			if err := enc.value("Hex", codecOrder_Hex.tag, p, false); err != nil {
+				return codecMarshalError(err, "Hex", codecTypeOf[uint32])
+			}
+		} else {
+			if o := codecOrder_Hex.order; o != nil {
+				enc.Write(oxygen.AppendBinary(enc.scratch[:0], o, uint64(c118), 4))
+			} else {
+				if err := codecOrder_Hex.format.CheckUint(uint64(c118)); err != nil {
+					return codecMarshalError(err, "Hex", codecTypeOf[uint32])
+				}
+				if err := enc.value("Hex", codecOrder_Hex.tag, codecOrder_Hex.format.AppendUint(enc.scratch[:0], uint64(c118)), codecOrder_Hex.format.Encoding == oxygen.PackedDecimal); err != nil {
+					return codecMarshalError(err, "Hex", codecTypeOf[uint32])
+				}
+			}
+		}
+	}
+	// Parts
+	c124 := v.Parts
+	switch "Parts" {
+	case codecOrder_Sizes.count:
+		n, err := codecLength[uint8](len(v.Sizes))
+		if err != nil {
+			return codecMarshalError(err, "Parts", codecTypeOf[uint8])
+		}
+		c124 = n
+	}
+	if codecOrder_Parts.encodeDef && c124 == 0 {
+		c124 = *codecOrder_Parts.def.(*uint8)
+	}
+	if ok, err := codecPresent(&codecOrder_Parts, v, codecSiblingOrder); err != nil {
+		return &oxygen.TagError{Name: cfg.Name, Tag: "1,0,r", Field: "Parts", Err: err}
+	} else if ok && !(codecOrder_Parts.omit && c124 == 0) {
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if codecOrder_Parts.err != nil {
+			return &oxygen.TagError{Name: cfg.Name, Tag: "1,0,r", Field: "Parts", Err: codecOrder_Parts.err}
+		}
+		if fn125, _ := codecRegistered[uint8](); fn125 != nil {
+			p, err := fn125("Parts", codecOrder_Parts.tag, c124)
+			if err != nil {
+				return codecMarshalError(err, "Parts", codecTypeOf[uint8])
+			}
+			if err := enc.value("Parts", codecOrder_Parts.tag, p, false); err != nil {
+				return codecMarshalError(err, "Parts", codecTypeOf[uint8])
+			}
+		} else {
+			if o := codecOrder_Parts.order; o != nil {
+				enc.Write(oxygen.AppendBinary(enc.scratch[:0], o, uint64(c124), 1))
+			} else {
+				if err := codecOrder_Parts.format.CheckUint(uint64(c124)); err != nil {
+					return codecMarshalError(err, "Parts", codecTypeOf[uint8])
+				}
+				if err := enc.value("Parts", codecOrder_Parts.tag, codecOrder_Parts.format.AppendUint(enc.scratch[:0], uint64(c124)), codecOrder_Parts.format.Encoding == oxygen.PackedDecimal); err != nil {
+					return codecMarshalError(err, "Parts", codecTypeOf[uint8])
+				}
+			}
+		}
+	}
+	// Sizes
+	switch codecOrder_Sizes.count {
+	case "ID":
+		if len(v.Sizes) != int(c1) {
+			return codecMarshalError(fmt.Errorf("length %d of the slice differs from the count %d", len(v.Sizes), int(c1)), "Sizes", codecTypeOf[[]int16])
+		}
+	case "State":
+		if len(v.Sizes) != int(c74) {
+			return codecMarshalError(fmt.Errorf("length %d of the slice differs from the count %d", len(v.Sizes), int(c74)), "Sizes", codecTypeOf[[]int16])
+		}
+	case "Hex":
+		if len(v.Sizes) != int(c118) {
+			return codecMarshalError(fmt.Errorf("length %d of the slice differs from the count %d", len(v.Sizes), int(c118)), "Sizes", codecTypeOf[[]int16])
+		}
+	case "Parts":
+		if len(v.Sizes) != int(c124) {
+			return codecMarshalError(fmt.Errorf("length %d of the slice differs from the count %d", len(v.Sizes), int(c124)), "Sizes", codecTypeOf[[]int16])
+		}
+	}
+	if ok, err := codecPresent(&codecOrder_Sizes, v, codecSiblingOrder); err != nil {
+		return &oxygen.TagError{Name: cfg.Name, Tag: "2,0,r,,Parts", Field: "Sizes", Err: err}
+	} else if ok && !(codecOrder_Sizes.omit && len(v.Sizes) == 0) {
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if codecOrder_Sizes.err != nil {
+			return &oxygen.TagError{Name: cfg.Name, Tag: "2,0,r,,Parts", Field: "Sizes", Err: codecOrder_Sizes.err}
+		}
+		if fn130, _ := codecRegistered[[]int16](); fn130 != nil {
+			p, err := fn130("Sizes", codecOrder_Sizes.tag, v.Sizes)
+			if err != nil {
+				return codecMarshalError(err, "Sizes", codecTypeOf[[]int16])
+			}
+			if err := enc.value("Sizes", codecOrder_Sizes.tag, p, false); err != nil {
+				return codecMarshalError(err, "Sizes", codecTypeOf[[]int16])
+			}
+		} else {
+			if err := enc.enter(); err != nil {
+				return codecMarshalError(err, "Sizes", codecTypeOf[[]int16])
+			}
+			if codecWrapList {
+				enc.Write(codecListOpener)
+			}
+			for i131 := range v.Sizes {
+				if i131 > 0 && codecSeparateElems {
+					enc.Write(codecElementSeparator)
+				}
+				if fn132, _ := codecRegistered[int16](); fn132 != nil {
+					p, err := fn132("Sizes", codecOrder_Sizes.tag, v.Sizes[i131])
+					if err != nil {
+						return codecMarshalError(err, "Sizes", codecTypeOf[[]int16])
+					}
+					if err := enc.value("Sizes", codecOrder_Sizes.tag, p, false); err != nil {
+						return codecMarshalError(err, "Sizes", codecTypeOf[[]int16])
+					}
+				} else {
+					if o := codecOrder_Sizes.order; o != nil {
+						enc.Write(oxygen.AppendBinary(enc.scratch[:0], o, uint64(v.Sizes[i131]), 2))
+					} else {
+						if err := codecOrder_Sizes.format.CheckInt(int64(v.Sizes[i131])); err != nil {
+							return codecMarshalError(err, "Sizes", codecTypeOf[[]int16])
+						}
+						if err := enc.value("Sizes", codecOrder_Sizes.tag, codecOrder_Sizes.format.AppendInt(enc.scratch[:0], int64(v.Sizes[i131])), codecOrder_Sizes.format.Encoding == oxygen.PackedDecimal); err != nil {
+							return codecMarshalError(err, "Sizes", codecTypeOf[[]int16])
+						}
+					}
+				}
+			}
+			if codecWrapList {
+				enc.Write(codecListCloser)
+			}
+			enc.leave()
+		}
+	}
+	// Port
+	d150 := v.Port
+	if codecOrder_Port.encodeDef && d150 == 0 {
+		d150 = *codecOrder_Port.def.(*int16)
+	}
+	if ok, err := codecPresent(&codecOrder_Port, v, codecSiblingOrder); err != nil {
+		return &oxygen.TagError{Name: cfg.Name, Tag: "0, ,l,le", Field: "Port", Err: err}
+	} else if ok && !(codecOrder_Port.omit && d150 == 0) {
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if codecOrder_Port.err != nil {
+			return &oxygen.TagError{Name: cfg.Name, Tag: "0, ,l,le", Field: "Port", Err: codecOrder_Port.err}
+		}
+		if fn151, _ := codecRegistered[int16](); fn151 != nil {
+			p, err := fn151("Port", codecOrder_Port.tag, d150)
+			if err != nil {
+				return codecMarshalError(err, "Port", codecTypeOf[int16])
+			}
+			if err := enc.value("Port", codecOrder_Port.tag, p, false); err != nil {
+				return codecMarshalError(err, "Port", codecTypeOf[int16])
+			}
+		} else {
+			if o := codecOrder_Port.order; o != nil {
+				enc.Write(oxygen.AppendBinary(enc.scratch[:0], o, uint64(d150), 2))
+			} else {
+				if err := codecOrder_Port.format.CheckInt(int64(d150)); err != nil {
+					return codecMarshalError(err, "Port", codecTypeOf[int16])
+				}
+				if err := enc.value("Port", codecOrder_Port.tag, codecOrder_Port.format.AppendInt(enc.scratch[:0], int64(d150)), codecOrder_Port.format.Encoding == oxygen.PackedDecimal); err != nil {
+					return codecMarshalError(err, "Port", codecTypeOf[int16])
+				}
+			}
+		}
+	}
+	// Ratio
+	d156 := v.Ratio
+	if codecOrder_Ratio.encodeDef && d156 == 0 {
+		d156 = *codecOrder_Ratio.def.(*float32)
+	}
+	if ok, err := codecPresent(&codecOrder_Ratio, v, codecSiblingOrder); err != nil {
+		return &oxygen.TagError{Name: cfg.Name, Tag: "0, ,l,be", Field: "Ratio", Err: err}
+	} else if ok && !(codecOrder_Ratio.omit && d156 == 0) {
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if codecOrder_Ratio.err != nil {
+			return &oxygen.TagError{Name: cfg.Name, Tag: "0, ,l,be", Field: "Ratio", Err: codecOrder_Ratio.err}
+		}
+		if fn157, _ := codecRegistered[float32](); fn157 != nil {
+			p, err := fn157("Ratio", codecOrder_Ratio.tag, d156)
+			if err != nil {
+				return codecMarshalError(err, "Ratio", codecTypeOf[float32])
+			}
+			if err := enc.value("Ratio", codecOrder_Ratio.tag, p, false); err != nil {
+				return codecMarshalError(err, "Ratio", codecTypeOf[float32])
+			}
+		} else {
+			if o := codecOrder_Ratio.order; o != nil {
+				enc.Write(oxygen.AppendBinary(enc.scratch[:0], o, codecFloatBits(float64(d156), 4), 4))
+			} else {
+				if err := codecOrder_Ratio.format.CheckFloat(float64(d156)); err != nil {
+					return codecMarshalError(err, "Ratio", codecTypeOf[float32])
+				}
+				if err := enc.value("Ratio", codecOrder_Ratio.tag, codecOrder_Ratio.format.AppendFloat(enc.scratch[:0], float64(d156), 32), codecOrder_Ratio.format.Encoding == oxygen.PackedDecimal); err != nil {
+					return codecMarshalError(err, "Ratio", codecTypeOf[float32])
+				}
+			}
+		}
+	}
+	// Total
+	d162 := v.Total
+	if codecOrder_Total.encodeDef && d162 == *new(oxygen.Decimal) {
+		d162 = *codecOrder_Total.def.(*oxygen.Decimal)
+	}
+	if ok, err := codecPresent(&codecOrder_Total, v, codecSiblingOrder); err != nil {
+		return &oxygen.TagError{Name: cfg.Name, Tag: "3,_,r,p5.2", Field: "Total", Err: err}
+	} else if ok {
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if codecOrder_Total.err != nil {
+			return &oxygen.TagError{Name: cfg.Name, Tag: "3,_,r,p5.2", Field: "Total", Err: codecOrder_Total.err}
+		}
+		if fn163, _ := codecRegistered[oxygen.Decimal](); fn163 != nil {
+			p, err := fn163("Total", codecOrder_Total.tag, d162)
+			if err != nil {
+				return codecMarshalError(err, "Total", codecTypeOf[oxygen.Decimal])
+			}
+			if err := enc.value("Total", codecOrder_Total.tag, p, false); err != nil {
+				return codecMarshalError(err, "Total", codecTypeOf[oxygen.Decimal])
+			}
+		} else {
+			if err := codecOrder_Total.format.CheckDecimal(d162); err != nil {
+				return codecMarshalError(err, "Total", codecTypeOf[oxygen.Decimal])
+			}
+			if err := enc.value("Total", codecOrder_Total.tag, codecOrder_Total.format.AppendDecimal(enc.scratch[:0], d162), codecOrder_Total.format.Encoding == oxygen.PackedDecimal); err != nil {
+				return codecMarshalError(err, "Total", codecTypeOf[oxygen.Decimal])
+			}
+		}
+	}
+	// Count
+	d167 := v.Count
+	if codecOrder_Count.encodeDef && d167 == 0 {
+		d167 = *codecOrder_Count.def.(*int64)
+	}
+	if ok, err := codecPresent(&codecOrder_Count, v, codecSiblingOrder); err != nil {
+		return &oxygen.TagError{Name: cfg.Name, Tag: "4,0,r,z4", Field: "Count", Err: err}
+	} else if ok && !(codecOrder_Count.omit && d167 == 0) {
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if codecOrder_Count.err != nil {
+			return &oxygen.TagError{Name: cfg.Name, Tag: "4,0,r,z4", Field: "Count", Err: codecOrder_Count.err}
+		}
+		if fn168, _ := codecRegistered[int64](); fn168 != nil {
+			p, err := fn168("Count", codecOrder_Count.tag, d167)
+			if err != nil {
+				return codecMarshalError(err, "Count", codecTypeOf[int64])
+			}
+			if err := enc.value("Count", codecOrder_Count.tag, p, false); err != nil {
+				return codecMarshalError(err, "Count", codecTypeOf[int64])
+			}
+		} else {
+			if o := codecOrder_Count.order; o != nil {
+				enc.Write(oxygen.AppendBinary(enc.scratch[:0], o, uint64(d167), 8))
+			} else {
+				if err := codecOrder_Count.format.CheckInt(int64(d167)); err != nil {
+					return codecMarshalError(err, "Count", codecTypeOf[int64])
+				}
+				if err := enc.value("Count", codecOrder_Count.tag, codecOrder_Count.format.AppendInt(enc.scratch[:0], int64(d167)), codecOrder_Count.format.Encoding == oxygen.PackedDecimal); err != nil {
+					return codecMarshalError(err, "Count", codecTypeOf[int64])
+				}
+			}
+		}
+	}
+	if wrap {
+		enc.Write(codecStructCloser)
+	}
+	return nil
+}
+
+func (dec *codecDecoder) decodeOrder(v *records.Order, unwrap bool) error {
+	if unwrap {
+		if err := dec.removePrefix(codecStructOpener); err != nil {
+			return err
+		}
+	}
+	sep := false
+	for i := 0; i < 22; i++ {
+		if len(dec.data) == 0 || unwrap && bytes.HasPrefix(dec.data, codecStructCloser) {
+			remaining := func(fn func(string, *codecTag, codecFields) bool) bool {
+				return codecRemainingOrder(v, i, fn)
+			}
+			if codecStrict {
+				if name := codecTruncated(remaining); name != "" {
+					return &oxygen.SyntaxError{Name: cfg.Name, Field: name, Offset: dec.offset(), Err: oxygen.ErrTruncated}
+				}
+			}
+			remaining(dec.required)
+			break
+		}
+		switch i {
+		case 0: // Header
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return err
+				}
+			}
+			sep = codecRemoveSeparator
+			if err := dec.decodeHeader(&v.Header, false); err != nil {
+				return err
+			}
+		case 1: // ID
+			if ok, err := codecPresent(&codecOrder_ID, v, codecSiblingOrder); err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "4,0,r", Field: "ID", Err: err}
+			} else if !ok {
+				continue
+			}
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "ID", codecTypeOf[int])
+				}
+			}
+			sep = codecRemoveSeparator
+			if codecOrder_ID.err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "4,0,r", Field: "ID", Err: codecOrder_ID.err}
+			}
+			if _, fn3 := codecRegistered[int](); fn3 != nil {
+				{
+					off4 := dec.offset()
+					p, err := dec.value("ID", codecOrder_ID.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off4, err), "ID", codecTypeOf[int])
+					}
+					if len(p) != 0 {
+						r, err := fn3("ID", codecOrder_ID.tag, p)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off4, err), "ID", codecTypeOf[int])
+						}
+						v.ID = r
+					} else if codecOrder_ID.def != nil {
+						v.ID = *codecOrder_ID.def.(*int)
+					}
+				}
+			} else {
+				if o := codecOrder_ID.order; o != nil {
+					off5 := dec.offset()
+					u, err := dec.binary(o, strconv.IntSize/8)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off5, err), "ID", codecTypeOf[int])
+					}
+					v.ID = int(codecSigned(u, strconv.IntSize/8))
+				} else {
+					{
+						off6 := dec.offset()
+						p, err := dec.value("ID", codecOrder_ID.tag, codecOrder_ID.format.Encoding == oxygen.PackedDecimal)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off6, err), "ID", codecTypeOf[int])
+						}
+						if len(p) != 0 {
+							r, err := codecOrder_ID.format.ParseInt(string(p), strconv.IntSize)
+							v.ID = int(r)
+							if err != nil {
+								return codecUnmarshalError(dec.typeError(off6, err), "ID", codecTypeOf[int])
+							}
+						} else if codecOrder_ID.def != nil {
+							v.ID = *codecOrder_ID.def.(*int)
+						}
+					}
+				}
+			}
+		case 2: // Paid
+			if ok, err := codecPresent(&codecOrder_Paid, v, codecSiblingOrder); err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "5, ,l", Field: "Paid", Err: err}
+			} else if !ok {
+				continue
+			}
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "Paid", codecTypeOf[bool])
+				}
+			}
+			sep = codecRemoveSeparator
+			if codecOrder_Paid.err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "5, ,l", Field: "Paid", Err: codecOrder_Paid.err}
+			}
+			if _, fn9 := codecRegistered[bool](); fn9 != nil {
+				{
+					off10 := dec.offset()
+					p, err := dec.value("Paid", codecOrder_Paid.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off10, err), "Paid", codecTypeOf[bool])
+					}
+					if len(p) != 0 {
+						r, err := fn9("Paid", codecOrder_Paid.tag, p)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off10, err), "Paid", codecTypeOf[bool])
+						}
+						v.Paid = r
+					} else if codecOrder_Paid.def != nil {
+						v.Paid = *codecOrder_Paid.def.(*bool)
+					}
+				}
+			} else {
+				if o := codecOrder_Paid.order; o != nil {
+					off11 := dec.offset()
+					u, err := dec.binary(o, 1)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off11, err), "Paid", codecTypeOf[bool])
+					}
+					r, err := codecParseBinaryBool(u)
+					v.Paid = bool(r)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off11, err), "Paid", codecTypeOf[bool])
+					}
+				} else {
+					{
+						off12 := dec.offset()
+						p, err := dec.value("Paid", codecOrder_Paid.tag, false)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off12, err), "Paid", codecTypeOf[bool])
+						}
+						if len(p) != 0 {
+							r, err := strconv.ParseBool(string(p))
+							v.Paid = bool(r)
+							if err != nil {
+								return codecUnmarshalError(dec.typeError(off12, err), "Paid", codecTypeOf[bool])
+							}
+						} else if codecOrder_Paid.def != nil {
+							v.Paid = *codecOrder_Paid.def.(*bool)
+						}
+					}
+				}
+			}
+		case 3: // Amount
+			if ok, err := codecPresent(&codecOrder_Amount, v, codecSiblingOrder); err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "6,0,r", Field: "Amount", Err: err}
+			} else if !ok {
+				continue
+			}
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "Amount", codecTypeOf[float64])
+				}
+			}
+			sep = codecRemoveSeparator
+			if codecOrder_Amount.err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "6,0,r", Field: "Amount", Err: codecOrder_Amount.err}
+			}
+			if _, fn15 := codecRegistered[float64](); fn15 != nil {
+				{
+					off16 := dec.offset()
+					p, err := dec.value("Amount", codecOrder_Amount.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off16, err), "Amount", codecTypeOf[float64])
+					}
+					if len(p) != 0 {
+						r, err := fn15("Amount", codecOrder_Amount.tag, p)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off16, err), "Amount", codecTypeOf[float64])
+						}
+						v.Amount = r
+					} else if codecOrder_Amount.def != nil {
+						v.Amount = *codecOrder_Amount.def.(*float64)
+					}
+				}
+			} else {
+				if o := codecOrder_Amount.order; o != nil {
+					off17 := dec.offset()
+					u, err := dec.binary(o, 8)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off17, err), "Amount", codecTypeOf[float64])
+					}
+					v.Amount = float64(codecFloatFrom(u, 8))
+				} else {
+					{
+						off18 := dec.offset()
+						p, err := dec.value("Amount", codecOrder_Amount.tag, codecOrder_Amount.format.Encoding == oxygen.PackedDecimal)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off18, err), "Amount", codecTypeOf[float64])
+						}
+						if len(p) != 0 {
+							r, err := codecOrder_Amount.format.ParseFloat(string(p), 64)
+							v.Amount = float64(r)
+							if err != nil {
+								return codecUnmarshalError(dec.typeError(off18, err), "Amount", codecTypeOf[float64])
+							}
+						} else if codecOrder_Amount.def != nil {
+							v.Amount = *codecOrder_Amount.def.(*float64)
+						}
+					}
+				}
+			}
+		case 4: // Code
+			if ok, err := codecPresent(&codecOrder_Code, v, codecSiblingOrder); err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "3,_,l", Field: "Code", Err: err}
+			} else if !ok {
+				continue
+			}
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "Code", codecTypeOf[records.Code])
+				}
+			}
+			sep = codecRemoveSeparator
+			if codecOrder_Code.err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "3,_,l", Field: "Code", Err: codecOrder_Code.err}
+			}
+			if _, fn21 := codecRegistered[records.Code](); fn21 != nil {
+				{
+					off22 := dec.offset()
+					p, err := dec.value("Code", codecOrder_Code.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off22, err), "Code", codecTypeOf[records.Code])
+					}
+					if len(p) != 0 {
+						r, err := fn21("Code", codecOrder_Code.tag, p)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off22, err), "Code", codecTypeOf[records.Code])
+						}
+						v.Code = r
+					} else if codecOrder_Code.def != nil {
+						v.Code = *codecOrder_Code.def.(*records.Code)
+					}
+				}
+			} else {
+				{
+					off23 := dec.offset()
+					p, err := dec.value("Code", codecOrder_Code.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off23, err), "Code", codecTypeOf[records.Code])
+					}
+					if len(p) != 0 {
+						v.Code = records.Code(p)
+					} else if codecOrder_Code.def != nil {
+						v.Code = *codecOrder_Code.def.(*records.Code)
+					}
+				}
+			}
+		case 5: // Note
+			if ok, err := codecPresent(&codecOrder_Note, v, codecSiblingOrder); err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "6,_,l", Field: "Note", Err: err}
+			} else if !ok {
+				continue
+			}
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "Note", codecTypeOf[*string])
+				}
+			}
+			sep = codecRemoveSeparator
+			if codecOrder_Note.err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "6,_,l", Field: "Note", Err: codecOrder_Note.err}
+			}
+			if _, fn28 := codecRegistered[*string](); fn28 != nil {
+				{
+					off29 := dec.offset()
+					p, err := dec.value("Note", codecOrder_Note.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off29, err), "Note", codecTypeOf[*string])
+					}
+					if len(p) != 0 {
+						r, err := fn28("Note", codecOrder_Note.tag, p)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off29, err), "Note", codecTypeOf[*string])
+						}
+						v.Note = r
+					} else if codecOrder_Note.def != nil {
+						d30 := *codecOrder_Note.def.(*string)
+						v.Note = &d30
+					}
+				}
+			} else {
+				{
+					p31 := v.Note
+					if p31 == nil {
+						p31 = new(string)
+					}
+					if _, fn32 := codecRegistered[string](); fn32 != nil {
+						{
+							off33 := dec.offset()
+							p, err := dec.value("Note", codecOrder_Note.tag, false)
+							if err != nil {
+								return codecUnmarshalError(dec.typeError(off33, err), "Note", codecTypeOf[*string])
+							}
+							if len(p) != 0 {
+								r, err := fn32("Note", codecOrder_Note.tag, p)
+								if err != nil {
+									return codecUnmarshalError(dec.typeError(off33, err), "Note", codecTypeOf[*string])
+								}
+								(*p31) = r
+							} else if codecOrder_Note.def != nil {
+								d34 := *codecOrder_Note.def.(*string)
+								v.Note = &d34
+							}
+						}
+					} else {
+						{
+							off35 := dec.offset()
+							p, err := dec.value("Note", codecOrder_Note.tag, false)
+							if err != nil {
+								return codecUnmarshalError(dec.typeError(off35, err), "Note", codecTypeOf[*string])
+							}
+							if len(p) != 0 {
+								(*p31) = string(p)
+							} else if codecOrder_Note.def != nil {
+								d36 := *codecOrder_Note.def.(*string)
+								v.Note = &d36
+							}
+						}
+					}
+					if v.Note == nil && !(len((*p31)) == 0) {
+						v.Note = p31
+					}
+				}
+			}
+		case 6: // Raw
+			if ok, err := codecPresent(&codecOrder_Raw, v, codecSiblingOrder); err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "3, ,l", Field: "Raw", Err: err}
+			} else if !ok {
+				continue
+			}
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "Raw", codecTypeOf[[]byte])
+				}
+			}
+			sep = codecRemoveSeparator
+			if codecOrder_Raw.err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "3, ,l", Field: "Raw", Err: codecOrder_Raw.err}
+			}
+			if _, fn38 := codecRegistered[[]byte](); fn38 != nil {
+				{
+					off39 := dec.offset()
+					p, err := dec.value("Raw", codecOrder_Raw.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off39, err), "Raw", codecTypeOf[[]byte])
+					}
+					if len(p) != 0 {
+						r, err := fn38("Raw", codecOrder_Raw.tag, p)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off39, err), "Raw", codecTypeOf[[]byte])
+						}
+						v.Raw = r
+					}
+				}
+			} else {
+				{
+					off40 := dec.offset()
+					p, err := dec.value("Raw", codecOrder_Raw.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off40, err), "Raw", codecTypeOf[[]byte])
+					}
+					if len(p) != 0 {
+						v.Raw = append([]byte(nil), p...)
+					}
+				}
+			}
+		case 7: // Items
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "Items", codecTypeOf[[]records.Line])
+				}
+			}
+			sep = codecRemoveSeparator
+			if _, fn44 := codecRegistered[[]records.Line](); fn44 != nil {
+				{
+					off45 := dec.offset()
+					p, err := dec.value("Items", nil, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off45, err), "Items", codecTypeOf[[]records.Line])
+					}
+					if len(p) != 0 {
+						r, err := fn44("Items", nil, p)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off45, err), "Items", codecTypeOf[[]records.Line])
+						}
+						v.Items = r
+					}
+				}
+			} else {
+				{
+					if err := dec.enter(); err != nil {
+						return codecUnmarshalError(dec.typeError(dec.offset(), err), "Items", codecTypeOf[[]records.Line])
+					}
+					if codecUnwrapList {
+						if err := dec.removePrefix(codecListOpener); err != nil {
+							return codecUnmarshalError(err, "Items", codecTypeOf[[]records.Line])
+						}
+					}
+					var z47 records.Line
+					n46 := 0
+					m48 := len(dec.missing)
+					for ; ; n46++ {
+						if dec.endOf(codecUnwrapList, codecListCloser) {
+							break
+						}
+						left49 := len(dec.data)
+						if n46 > 0 && codecRemoveElemSep {
+							if err := dec.removePrefix(codecElementSeparator); err != nil {
+								return codecUnmarshalError(err, "Items", codecTypeOf[[]records.Line])
+							}
+						}
+						if n46 < len(v.Items) {
+							v.Items[n46] = z47
+						} else {
+							v.Items = append(v.Items, z47)
+						}
+						if _, fn50 := codecRegistered[records.Line](); fn50 != nil {
+							{
+								off51 := dec.offset()
+								p, err := dec.value("Items", nil, false)
+								if err != nil {
+									return codecUnmarshalError(dec.typeError(off51, err), "Items", codecTypeOf[[]records.Line])
+								}
+								if len(p) != 0 {
+									r, err := fn50("Items", nil, p)
+									if err != nil {
+										return codecUnmarshalError(dec.typeError(off51, err), "Items", codecTypeOf[[]records.Line])
+									}
+									v.Items[n46] = r
+								}
+							}
+						} else {
+							n52 := len(dec.missing)
+							if err := dec.decodeNestedLine(&v.Items[n46], codecRemoveWrapper); err != nil {
+								return codecUnmarshalError(err, "Items", codecTypeOf[[]records.Line])
+							}
+							dec.prefix(n52, "["+strconv.Itoa(n46)+"]")
+						}
+						if len(dec.data) == left49 {
+							break
+						}
+					}
+					if codecUnwrapList {
+						if err := dec.removePrefix(codecListCloser); err != nil {
+							return codecUnmarshalError(err, "Items", codecTypeOf[[]records.Line])
+						}
+					}
+					dec.leave()
+					dec.prefix(m48, "Items")
+					if v.Items != nil {
+						v.Items = v.Items[:n46]
+					}
+				}
+			}
+		case 8: // Pins
+			if ok, err := codecPresent(&codecOrder_Pins, v, codecSiblingOrder); err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "3,0,r", Field: "Pins", Err: err}
+			} else if !ok {
+				continue
+			}
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "Pins", codecTypeOf[[2]uint16])
+				}
+			}
+			sep = codecRemoveSeparator
+			if codecOrder_Pins.err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "3,0,r", Field: "Pins", Err: codecOrder_Pins.err}
+			}
+			if _, fn56 := codecRegistered[[2]uint16](); fn56 != nil {
+				{
+					off57 := dec.offset()
+					p, err := dec.value("Pins", codecOrder_Pins.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off57, err), "Pins", codecTypeOf[[2]uint16])
+					}
+					if len(p) != 0 {
+						r, err := fn56("Pins", codecOrder_Pins.tag, p)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off57, err), "Pins", codecTypeOf[[2]uint16])
+						}
+						v.Pins = r
+					}
+				}
+			} else {
+				{
+					if err := dec.enter(); err != nil {
+						return codecUnmarshalError(dec.typeError(dec.offset(), err), "Pins", codecTypeOf[[2]uint16])
+					}
+					if codecUnwrapList {
+						if err := dec.removePrefix(codecListOpener); err != nil {
+							return codecUnmarshalError(err, "Pins", codecTypeOf[[2]uint16])
+						}
+					}
+					var z59 uint16
+					n58 := 0
+					for ; n58 < len(v.Pins); n58++ {
+						if dec.endOf(codecUnwrapList, codecListCloser) {
+							break
+						}
+						left60 := len(dec.data)
+						if n58 > 0 && codecRemoveElemSep {
+							if err := dec.removePrefix(codecElementSeparator); err != nil {
+								return codecUnmarshalError(err, "Pins", codecTypeOf[[2]uint16])
+							}
+						}
+						v.Pins[n58] = z59
+						if _, fn61 := codecRegistered[uint16](); fn61 != nil {
+							{
+								off62 := dec.offset()
+								p, err := dec.value("Pins", codecOrder_Pins.tag, false)
+								if err != nil {
+									return codecUnmarshalError(dec.typeError(off62, err), "Pins", codecTypeOf[[2]uint16])
+								}
+								if len(p) != 0 {
+									r, err := fn61("Pins", codecOrder_Pins.tag, p)
+									if err != nil {
+										return codecUnmarshalError(dec.typeError(off62, err), "Pins", codecTypeOf[[2]uint16])
+									}
+									v.Pins[n58] = r
+								}
+							}
+						} else {
+							if o := codecOrder_Pins.order; o != nil {
+								off63 := dec.offset()
+								u, err := dec.binary(o, 2)
+								if err != nil {
+									return codecUnmarshalError(dec.typeError(off63, err), "Pins", codecTypeOf[[2]uint16])
+								}
+								v.Pins[n58] = uint16(u)
+							} else {
+								{
+									off64 := dec.offset()
+									p, err := dec.value("Pins", codecOrder_Pins.tag, codecOrder_Pins.format.Encoding == oxygen.PackedDecimal)
+									if err != nil {
+										return codecUnmarshalError(dec.typeError(off64, err), "Pins", codecTypeOf[[2]uint16])
+									}
+									if len(p) != 0 {
+										r, err := codecOrder_Pins.format.ParseUint(string(p), 16)
+										v.Pins[n58] = uint16(r)
+										if err != nil {
+											return codecUnmarshalError(dec.typeError(off64, err), "Pins", codecTypeOf[[2]uint16])
+										}
+									}
+								}
+							}
+						}
+						if len(dec.data) == left60 {
+							break
+						}
+					}
+					if codecUnwrapList {
+						if err := dec.removePrefix(codecListCloser); err != nil {
+							return codecUnmarshalError(err, "Pins", codecTypeOf[[2]uint16])
+						}
+					}
+					dec.leave()
+					for ; n58 < len(v.Pins); n58++ {
+						v.Pins[n58] = z59
+					}
+				}
+			}
+		case 9: // Next
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "Next", codecTypeOf[*records.Line])
+				}
+			}
+			sep = codecRemoveSeparator
+			if _, fn68 := codecRegistered[*records.Line](); fn68 != nil {
+				{
+					off69 := dec.offset()
+					p, err := dec.value("Next", nil, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off69, err), "Next", codecTypeOf[*records.Line])
+					}
+					if len(p) != 0 {
+						r, err := fn68("Next", nil, p)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off69, err), "Next", codecTypeOf[*records.Line])
+						}
+						v.Next = r
+					}
+				}
+			} else {
+				{
+					p70 := v.Next
+					if p70 == nil {
+						p70 = new(records.Line)
+					}
+					if _, fn71 := codecRegistered[records.Line](); fn71 != nil {
+						{
+							off72 := dec.offset()
+							p, err := dec.value("Next", nil, false)
+							if err != nil {
+								return codecUnmarshalError(dec.typeError(off72, err), "Next", codecTypeOf[*records.Line])
+							}
+							if len(p) != 0 {
+								r, err := fn71("Next", nil, p)
+								if err != nil {
+									return codecUnmarshalError(dec.typeError(off72, err), "Next", codecTypeOf[*records.Line])
+								}
+								(*p70) = r
+							}
+						}
+					} else {
+						n73 := len(dec.missing)
+						if err := dec.decodeNestedLine(p70, codecRemoveWrapper); err != nil {
+							return codecUnmarshalError(err, "Next", codecTypeOf[*records.Line])
+						}
+						dec.prefix(n73, "Next")
+					}
+					if v.Next == nil {
+						v.Next = p70
+					}
+				}
+			}
+		case 10: // State
+			if ok, err := codecPresent(&codecOrder_State, v, codecSiblingOrder); err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "3, ,l", Field: "State", Err: err}
+			} else if !ok {
+				continue
+			}
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "State", codecTypeOf[records.State])
+				}
+			}
+			sep = codecRemoveSeparator
+			if codecOrder_State.err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "3, ,l", Field: "State", Err: codecOrder_State.err}
+			}
+			if _, fn77 := codecRegistered[records.State](); fn77 != nil {
+				{
+					off78 := dec.offset()
+					p, err := dec.value("State", codecOrder_State.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off78, err), "State", codecTypeOf[records.State])
+					}
+					if len(p) != 0 {
+						r, err := fn77("State", codecOrder_State.tag, p)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off78, err), "State", codecTypeOf[records.State])
+						}
+						v.State = r
+					} else if codecOrder_State.def != nil {
+						v.State = *codecOrder_State.def.(*records.State)
+					}
+				}
+			} else {
+				{
+					off79 := dec.offset()
+					p, err := dec.value("State", codecOrder_State.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off79, err), "State", codecTypeOf[records.State])
+					}
+					if len(p) != 0 {
+						var v80 records.State
+						if err = (&v80).UnmarshalTEST(p); err != nil {
+							return codecUnmarshalError(dec.typeError(off79, err), "State", codecTypeOf[records.State])
+						}
+						v.State = v80
+					} else if codecOrder_State.def != nil {
+						v.State = *codecOrder_State.def.(*records.State)
+					}
+				}
+			}
+		case 11: // Created
+			if ok, err := codecPresent(&codecOrder_Created, v, codecSiblingOrder); err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "20, ,l", Field: "Created", Err: err}
+			} else if !ok {
+				continue
+			}
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "Created", codecTypeOf[time.Time])
+				}
+			}
+			sep = codecRemoveSeparator
+			if codecOrder_Created.err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "20, ,l", Field: "Created", Err: codecOrder_Created.err}
+			}
+			if _, fn84 := codecRegistered[time.Time](); fn84 != nil {
+				{
+					off85 := dec.offset()
+					p, err := dec.value("Created", codecOrder_Created.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off85, err), "Created", codecTypeOf[time.Time])
+					}
+					if len(p) != 0 {
+						r, err := fn84("Created", codecOrder_Created.tag, p)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off85, err), "Created", codecTypeOf[time.Time])
+						}
+						v.Created = r
+					} else if codecOrder_Created.def != nil {
+						v.Created = *codecOrder_Created.def.(*time.Time)
+					}
+				}
+			} else {
+				if codecTextMarshaler {
+					{
+						off86 := dec.offset()
+						p, err := dec.value("Created", codecOrder_Created.tag, false)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off86, err), "Created", codecTypeOf[time.Time])
+						}
+						if len(p) != 0 {
+							var v87 time.Time
+							if err = (&v87).UnmarshalText(p); err != nil {
+								return codecUnmarshalError(dec.typeError(off86, err), "Created", codecTypeOf[time.Time])
+							}
+							v.Created = v87
+						} else if codecOrder_Created.def != nil {
+							v.Created = *codecOrder_Created.def.(*time.Time)
+						}
+					}
+				} else {
+					n88 := len(dec.missing)
+					if err := dec.decodeNestedTimeTime(&v.Created, codecRemoveWrapper); err != nil {
+						return codecUnmarshalError(err, "Created", codecTypeOf[time.Time])
+					}
+					dec.prefix(n88, "Created")
+				}
+			}
+		case 12: // Tags
+			if ok, err := codecPresent(&codecOrder_Tags, v, codecSiblingOrder); err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "2", Field: "Tags", Err: err}
+			} else if !ok {
+				continue
+			}
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "Tags", codecTypeOf[[]string])
+				}
+			}
+			sep = codecRemoveSeparator
+			if codecOrder_Tags.err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "2", Field: "Tags", Err: codecOrder_Tags.err}
+			}
+			if codecOrder_Tags.count == "" {
+				if _, fn92 := codecRegistered[[]string](); fn92 != nil {
+					{
+						off93 := dec.offset()
+						p, err := dec.value("Tags", codecOrder_Tags.tag, false)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off93, err), "Tags", codecTypeOf[[]string])
+						}
+						if len(p) != 0 {
+							r, err := fn92("Tags", codecOrder_Tags.tag, p)
+							if err != nil {
+								return codecUnmarshalError(dec.typeError(off93, err), "Tags", codecTypeOf[[]string])
+							}
+							v.Tags = r
+						}
+					}
+				} else {
+					{
+						if err := dec.enter(); err != nil {
+							return codecUnmarshalError(dec.typeError(dec.offset(), err), "Tags", codecTypeOf[[]string])
+						}
+						if codecUnwrapList {
+							if err := dec.removePrefix(codecListOpener); err != nil {
+								return codecUnmarshalError(err, "Tags", codecTypeOf[[]string])
+							}
+						}
+						var z95 string
+						n94 := 0
+						for ; ; n94++ {
+							if dec.endOf(codecUnwrapList, codecListCloser) {
+								break
+							}
+							left96 := len(dec.data)
+							if n94 > 0 && codecRemoveElemSep {
+								if err := dec.removePrefix(codecElementSeparator); err != nil {
+									return codecUnmarshalError(err, "Tags", codecTypeOf[[]string])
+								}
+							}
+							if n94 < len(v.Tags) {
+								v.Tags[n94] = z95
+							} else {
+								v.Tags = append(v.Tags, z95)
+							}
+							if _, fn97 := codecRegistered[string](); fn97 != nil {
+								{
+									off98 := dec.offset()
+									p, err := dec.value("Tags", codecOrder_Tags.tag, false)
+									if err != nil {
+										return codecUnmarshalError(dec.typeError(off98, err), "Tags", codecTypeOf[[]string])
+									}
+									if len(p) != 0 {
+										r, err := fn97("Tags", codecOrder_Tags.tag, p)
+										if err != nil {
+											return codecUnmarshalError(dec.typeError(off98, err), "Tags", codecTypeOf[[]string])
+										}
+										v.Tags[n94] = r
+									}
+								}
+							} else {
+								{
+									off99 := dec.offset()
+									p, err := dec.value("Tags", codecOrder_Tags.tag, false)
+									if err != nil {
+										return codecUnmarshalError(dec.typeError(off99, err), "Tags", codecTypeOf[[]string])
+									}
+									if len(p) != 0 {
+										v.Tags[n94] = string(p)
+									}
+								}
+							}
+							if len(dec.data) == left96 {
+								break
+							}
+						}
+						if codecUnwrapList {
+							if err := dec.removePrefix(codecListCloser); err != nil {
+								return codecUnmarshalError(err, "Tags", codecTypeOf[[]string])
+							}
+						}
+						dec.leave()
+						if v.Tags != nil {
+							v.Tags = v.Tags[:n94]
+						}
+					}
+				}
+			} else {
+				off := dec.offset()
+				var want uint64
+				var err error
+				switch codecOrder_Tags.count {
+				case "ID":
+					want, err = codecCount(v.ID)
+				case "State":
+					want, err = codecCount(v.State)
+				}
+				if err != nil {
+					return codecUnmarshalError(dec.typeError(off, err), "Tags", codecTypeOf[[]string])
+				}
+				{
+					if err := dec.enter(); err != nil {
+						return codecUnmarshalError(dec.typeError(dec.offset(), err), "Tags", codecTypeOf[[]string])
+					}
+					if codecUnwrapList {
+						if err := dec.removePrefix(codecListOpener); err != nil {
+							return codecUnmarshalError(err, "Tags", codecTypeOf[[]string])
+						}
+					}
+					var z101 string
+					n100 := 0
+					limit102 := math.MaxInt32
+					if want < uint64(limit102) {
+						limit102 = int(want)
+					}
+					v.Tags = nil
+					for ; n100 < limit102; n100++ {
+						if dec.endOf(codecUnwrapList, codecListCloser) {
+							break
+						}
+						left103 := len(dec.data)
+						if n100 > 0 && codecRemoveElemSep {
+							if err := dec.removePrefix(codecElementSeparator); err != nil {
+								return codecUnmarshalError(err, "Tags", codecTypeOf[[]string])
+							}
+						}
+						if n100 < len(v.Tags) {
+							v.Tags[n100] = z101
+						} else {
+							v.Tags = append(v.Tags, z101)
+						}
+						if _, fn104 := codecRegistered[string](); fn104 != nil {
+							{
+								off105 := dec.offset()
+								p, err := dec.value("Tags", codecOrder_Tags.tag, false)
+								if err != nil {
+									return codecUnmarshalError(dec.typeError(off105, err), "Tags", codecTypeOf[[]string])
+								}
+								if len(p) != 0 {
+									r, err := fn104("Tags", codecOrder_Tags.tag, p)
+									if err != nil {
+										return codecUnmarshalError(dec.typeError(off105, err), "Tags", codecTypeOf[[]string])
+									}
+									v.Tags[n100] = r
+								}
+							}
+						} else {
+							{
+								off106 := dec.offset()
+								p, err := dec.value("Tags", codecOrder_Tags.tag, false)
+								if err != nil {
+									return codecUnmarshalError(dec.typeError(off106, err), "Tags", codecTypeOf[[]string])
+								}
+								if len(p) != 0 {
+									v.Tags[n100] = string(p)
+								}
+							}
+						}
+						if len(dec.data) == left103 {
+							break
+						}
+					}
+					if codecUnwrapList {
+						if err := dec.removePrefix(codecListCloser); err != nil {
+							return codecUnmarshalError(err, "Tags", codecTypeOf[[]string])
+						}
+					}
+					dec.leave()
+					if v.Tags != nil {
+						v.Tags = v.Tags[:n100]
+					}
+					if uint64(n100) != want {
+						return codecUnmarshalError(dec.typeError(dec.offset(), fmt.Errorf("found %d elements out of %d", n100, want)), "Tags", codecTypeOf[[]string])
+					}
+				}
+			}
+		case 13: // Extra
+			if ok, err := codecPresent(&codecOrder_Extra, v, codecSiblingOrder); err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "2, ,l", Field: "Extra", Err: err}
+			} else if !ok {
+				continue
+			}
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "Extra", codecTypeOf[string])
+				}
+			}
+			sep = codecRemoveSeparator
+			if codecOrder_Extra.err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "2, ,l", Field: "Extra", Err: codecOrder_Extra.err}
+			}
+			if _, fn109 := codecRegistered[string](); fn109 != nil {
+				{
+					off110 := dec.offset()
+					p, err := dec.value("Extra", codecOrder_Extra.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off110, err), "Extra", codecTypeOf[string])
+					}
+					if len(p) != 0 {
+						r, err := fn109("Extra", codecOrder_Extra.tag, p)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off110, err), "Extra", codecTypeOf[string])
+						}
+						v.Extra = r
+					} else if codecOrder_Extra.def != nil {
+						v.Extra = *codecOrder_Extra.def.(*string)
+					}
+				}
+			} else {
+				{
+					off111 := dec.offset()
+					p, err := dec.value("Extra", codecOrder_Extra.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off111, err), "Extra", codecTypeOf[string])
+					}
+					if len(p) != 0 {
+						v.Extra = string(p)
+					} else if codecOrder_Extra.def != nil {
+						v.Extra = *codecOrder_Extra.def.(*string)
+					}
+				}
+			}
+		case 14: // Cents
+			if ok, err := codecPresent(&codecOrder_Cents, v, codecSiblingOrder); err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "7,0,r,i2", Field: "Cents", Err: err}
+			} else if !ok {
+				continue
+			}
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "Cents", codecTypeOf[float64])
+				}
+			}
+			sep = codecRemoveSeparator
+			if codecOrder_Cents.err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "7,0,r,i2", Field: "Cents", Err: codecOrder_Cents.err}
+			}
+			if _, fn114 := codecRegistered[float64](); fn114 != nil {
+				{
+					off115 := dec.offset()
+					p, err := dec.value("Cents", codecOrder_Cents.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off115, err), "Cents", codecTypeOf[float64])
+					}
+					if len(p) != 0 {
+						r, err := fn114("Cents", codecOrder_Cents.tag, p)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off115, err), "Cents", codecTypeOf[float64])
+						}
+						v.Cents = r
+					} else if codecOrder_Cents.def != nil {
+						v.Cents = *codecOrder_Cents.def.(*float64)
+					}
+				}
+			} else {
+				if o := codecOrder_Cents.order; o != nil {
+					off116 := dec.offset()
+					u, err := dec.binary(o, 8)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off116, err), "Cents", codecTypeOf[float64])
+					}
+					v.Cents = float64(codecFloatFrom(u, 8))
+				} else {
+					{
+						off117 := dec.offset()
+						p, err := dec.value("Cents", codecOrder_Cents.tag, codecOrder_Cents.format.Encoding == oxygen.PackedDecimal)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off117, err), "Cents", codecTypeOf[float64])
+						}
+						if len(p) != 0 {
+							r, err := codecOrder_Cents.format.ParseFloat(string(p), 64)
+							v.Cents = float64(r)
+							if err != nil {
+								return codecUnmarshalError(dec.typeError(off117, err), "Cents", codecTypeOf[float64])
+							}
+						} else if codecOrder_Cents.def != nil {
+							v.Cents = *codecOrder_Cents.def.(*float64)
+						}
+					}
+				}
+			}
+		case 15: // Hex
+			if ok, err := codecPresent(&codecOrder_Hex, v, codecSiblingOrder); err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "4,0,r,x16", Field: "Hex", Err: err}
+			} else if !ok {
+				continue
+			}
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "Hex", codecTypeOf[uint32])
+				}
+			}
+			sep = codecRemoveSeparator
+			if codecOrder_Hex.err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "4,0,r,x16", Field: "Hex", Err: codecOrder_Hex.err}
+			}
+			if _, fn120 := codecRegistered[uint32](); fn120 != nil {
+				{
+					off121 := dec.offset()
+					p, err := dec.value("Hex", codecOrder_Hex.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off121, err), "Hex", codecTypeOf[uint32])
+					}
+					if len(p) != 0 {
+						r, err := fn120("Hex", codecOrder_Hex.tag, p)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off121, err), "Hex", codecTypeOf[uint32])
+						}
+						v.Hex = r
+					} else if codecOrder_Hex.def != nil {
+						v.Hex = *codecOrder_Hex.def.(*uint32)
+					}
+				}
+			} else {
+				if o := codecOrder_Hex.order; o != nil {
+					off122 := dec.offset()
+					u, err := dec.binary(o, 4)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off122, err), "Hex", codecTypeOf[uint32])
+					}
+					v.Hex = uint32(u)
+				} else {
+					{
+						off123 := dec.offset()
+						p, err := dec.value("Hex", codecOrder_Hex.tag, codecOrder_Hex.format.Encoding == oxygen.PackedDecimal)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off123, err), "Hex", codecTypeOf[uint32])
+						}
+						if len(p) != 0 {
+							r, err := codecOrder_Hex.format.ParseUint(string(p), 32)
+							v.Hex = uint32(r)
+							if err != nil {
+								return codecUnmarshalError(dec.typeError(off123, err), "Hex", codecTypeOf[uint32])
+							}
+						} else if codecOrder_Hex.def != nil {
+							v.Hex = *codecOrder_Hex.def.(*uint32)
+						}
+					}
+				}
+			}
+		case 16: // Parts
+			if ok, err := codecPresent(&codecOrder_Parts, v, codecSiblingOrder); err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "1,0,r", Field: "Parts", Err: err}
+			} else if !ok {
+				continue
+			}
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "Parts", codecTypeOf[uint8])
+				}
+			}
+			sep = codecRemoveSeparator
+			if codecOrder_Parts.err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "1,0,r", Field: "Parts", Err: codecOrder_Parts.err}
+			}
+			if _, fn126 := codecRegistered[uint8](); fn126 != nil {
+				{
+					off127 := dec.offset()
+					p, err := dec.value("Parts", codecOrder_Parts.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off127, err), "Parts", codecTypeOf[uint8])
+					}
+					if len(p) != 0 {
+						r, err := fn126("Parts", codecOrder_Parts.tag, p)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off127, err), "Parts", codecTypeOf[uint8])
+						}
+						v.Parts = r
+					} else if codecOrder_Parts.def != nil {
+						v.Parts = *codecOrder_Parts.def.(*uint8)
+					}
+				}
+			} else {
+				if o := codecOrder_Parts.order; o != nil {
+					off128 := dec.offset()
+					u, err := dec.binary(o, 1)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off128, err), "Parts", codecTypeOf[uint8])
+					}
+					v.Parts = uint8(u)
+				} else {
+					{
+						off129 := dec.offset()
+						p, err := dec.value("Parts", codecOrder_Parts.tag, codecOrder_Parts.format.Encoding == oxygen.PackedDecimal)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off129, err), "Parts", codecTypeOf[uint8])
+						}
+						if len(p) != 0 {
+							r, err := codecOrder_Parts.format.ParseUint(string(p), 8)
+							v.Parts = uint8(r)
+							if err != nil {
+								return codecUnmarshalError(dec.typeError(off129, err), "Parts", codecTypeOf[uint8])
+							}
+						} else if codecOrder_Parts.def != nil {
+							v.Parts = *codecOrder_Parts.def.(*uint8)
+						}
+					}
+				}
+			}
+		case 17: // Sizes
+			if ok, err := codecPresent(&codecOrder_Sizes, v, codecSiblingOrder); err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "2,0,r,,Parts", Field: "Sizes", Err: err}
+			} else if !ok {
+				continue
+			}
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "Sizes", codecTypeOf[[]int16])
+				}
+			}
+			sep = codecRemoveSeparator
+			if codecOrder_Sizes.err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "2,0,r,,Parts", Field: "Sizes", Err: codecOrder_Sizes.err}
+			}
+			if codecOrder_Sizes.count == "" {
+				if _, fn133 := codecRegistered[[]int16](); fn133 != nil {
+					{
+						off134 := dec.offset()
+						p, err := dec.value("Sizes", codecOrder_Sizes.tag, false)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off134, err), "Sizes", codecTypeOf[[]int16])
+						}
+						if len(p) != 0 {
+							r, err := fn133("Sizes", codecOrder_Sizes.tag, p)
+							if err != nil {
+								return codecUnmarshalError(dec.typeError(off134, err), "Sizes", codecTypeOf[[]int16])
+							}
+							v.Sizes = r
+						}
+					}
+				} else {
+					{
+						if err := dec.enter(); err != nil {
+							return codecUnmarshalError(dec.typeError(dec.offset(), err), "Sizes", codecTypeOf[[]int16])
+						}
+						if codecUnwrapList {
+							if err := dec.removePrefix(codecListOpener); err != nil {
+								return codecUnmarshalError(err, "Sizes", codecTypeOf[[]int16])
+							}
+						}
+						var z136 int16
+						n135 := 0
+						for ; ; n135++ {
+							if dec.endOf(codecUnwrapList, codecListCloser) {
+								break
+							}
+							left137 := len(dec.data)
+							if n135 > 0 && codecRemoveElemSep {
+								if err := dec.removePrefix(codecElementSeparator); err != nil {
+									return codecUnmarshalError(err, "Sizes", codecTypeOf[[]int16])
+								}
+							}
+							if n135 < len(v.Sizes) {
+								v.Sizes[n135] = z136
+							} else {
+								v.Sizes = append(v.Sizes, z136)
+							}
+							if _, fn138 := codecRegistered[int16](); fn138 != nil {
+								{
+									off139 := dec.offset()
+									p, err := dec.value("Sizes", codecOrder_Sizes.tag, false)
+									if err != nil {
+										return codecUnmarshalError(dec.typeError(off139, err), "Sizes", codecTypeOf[[]int16])
+									}
+									if len(p) != 0 {
+										r, err := fn138("Sizes", codecOrder_Sizes.tag, p)
+										if err != nil {
+											return codecUnmarshalError(dec.typeError(off139, err), "Sizes", codecTypeOf[[]int16])
+										}
+										v.Sizes[n135] = r
+									}
+								}
+							} else {
+								if o := codecOrder_Sizes.order; o != nil {
+									off140 := dec.offset()
+									u, err := dec.binary(o, 2)
+									if err != nil {
+										return codecUnmarshalError(dec.typeError(off140, err), "Sizes", codecTypeOf[[]int16])
+									}
+									v.Sizes[n135] = int16(codecSigned(u, 2))
+								} else {
+									{
+										off141 := dec.offset()
+										p, err := dec.value("Sizes", codecOrder_Sizes.tag, codecOrder_Sizes.format.Encoding == oxygen.PackedDecimal)
+										if err != nil {
+											return codecUnmarshalError(dec.typeError(off141, err), "Sizes", codecTypeOf[[]int16])
+										}
+										if len(p) != 0 {
+											r, err := codecOrder_Sizes.format.ParseInt(string(p), 16)
+											v.Sizes[n135] = int16(r)
+											if err != nil {
+												return codecUnmarshalError(dec.typeError(off141, err), "Sizes", codecTypeOf[[]int16])
+											}
+										}
+									}
+								}
+							}
+							if len(dec.data) == left137 {
+								break
+							}
+						}
+						if codecUnwrapList {
+							if err := dec.removePrefix(codecListCloser); err != nil {
+								return codecUnmarshalError(err, "Sizes", codecTypeOf[[]int16])
+							}
+						}
+						dec.leave()
+						if v.Sizes != nil {
+							v.Sizes = v.Sizes[:n135]
+						}
+					}
+				}
+			} else {
+				off := dec.offset()
+				var want uint64
+				var err error
+				switch codecOrder_Sizes.count {
+				case "ID":
+					want, err = codecCount(v.ID)
+				case "State":
+					want, err = codecCount(v.State)
+				case "Hex":
+					want, err = codecCount(v.Hex)
+				case "Parts":
+					want, err = codecCount(v.Parts)
+				}
+				if err != nil {
+					return codecUnmarshalError(dec.typeError(off, err), "Sizes", codecTypeOf[[]int16])
+				}
+				{
+					if err := dec.enter(); err != nil {
+						return codecUnmarshalError(dec.typeError(dec.offset(), err), "Sizes", codecTypeOf[[]int16])
+					}
+					if codecUnwrapList {
+						if err := dec.removePrefix(codecListOpener); err != nil {
+							return codecUnmarshalError(err, "Sizes", codecTypeOf[[]int16])
+						}
+					}
+					var z143 int16
+					n142 := 0
+					limit144 := math.MaxInt32
+					if want < uint64(limit144) {
+						limit144 = int(want)
+					}
+					v.Sizes = nil
+					for ; n142 < limit144; n142++ {
+						if dec.endOf(codecUnwrapList, codecListCloser) {
+							break
+						}
+						left145 := len(dec.data)
+						if n142 > 0 && codecRemoveElemSep {
+							if err := dec.removePrefix(codecElementSeparator); err != nil {
+								return codecUnmarshalError(err, "Sizes", codecTypeOf[[]int16])
+							}
+						}
+						if n142 < len(v.Sizes) {
+							v.Sizes[n142] = z143
+						} else {
+							v.Sizes = append(v.Sizes, z143)
+						}
+						if _, fn146 := codecRegistered[int16](); fn146 != nil {
+							{
+								off147 := dec.offset()
+								p, err := dec.value("Sizes", codecOrder_Sizes.tag, false)
+								if err != nil {
+									return codecUnmarshalError(dec.typeError(off147, err), "Sizes", codecTypeOf[[]int16])
+								}
+								if len(p) != 0 {
+									r, err := fn146("Sizes", codecOrder_Sizes.tag, p)
+									if err != nil {
+										return codecUnmarshalError(dec.typeError(off147, err), "Sizes", codecTypeOf[[]int16])
+									}
+									v.Sizes[n142] = r
+								}
+							}
+						} else {
+							if o := codecOrder_Sizes.order; o != nil {
+								off148 := dec.offset()
+								u, err := dec.binary(o, 2)
+								if err != nil {
+									return codecUnmarshalError(dec.typeError(off148, err), "Sizes", codecTypeOf[[]int16])
+								}
+								v.Sizes[n142] = int16(codecSigned(u, 2))
+							} else {
+								{
+									off149 := dec.offset()
+									p, err := dec.value("Sizes", codecOrder_Sizes.tag, codecOrder_Sizes.format.Encoding == oxygen.PackedDecimal)
+									if err != nil {
+										return codecUnmarshalError(dec.typeError(off149, err), "Sizes", codecTypeOf[[]int16])
+									}
+									if len(p) != 0 {
+										r, err := codecOrder_Sizes.format.ParseInt(string(p), 16)
+										v.Sizes[n142] = int16(r)
+										if err != nil {
+											return codecUnmarshalError(dec.typeError(off149, err), "Sizes", codecTypeOf[[]int16])
+										}
+									}
+								}
+							}
+						}
+						if len(dec.data) == left145 {
+							break
+						}
+					}
+					if codecUnwrapList {
+						if err := dec.removePrefix(codecListCloser); err != nil {
+							return codecUnmarshalError(err, "Sizes", codecTypeOf[[]int16])
+						}
+					}
+					dec.leave()
+					if v.Sizes != nil {
+						v.Sizes = v.Sizes[:n142]
+					}
+					if uint64(n142) != want {
+						return codecUnmarshalError(dec.typeError(dec.offset(), fmt.Errorf("found %d elements out of %d", n142, want)), "Sizes", codecTypeOf[[]int16])
+					}
+				}
+			}
+		case 18: // Port
+			if ok, err := codecPresent(&codecOrder_Port, v, codecSiblingOrder); err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "0, ,l,le", Field: "Port", Err: err}
+			} else if !ok {
+				continue
+			}
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "Port", codecTypeOf[int16])
+				}
+			}
+			sep = codecRemoveSeparator
+			if codecOrder_Port.err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "0, ,l,le", Field: "Port", Err: codecOrder_Port.err}
+			}
+			if _, fn152 := codecRegistered[int16](); fn152 != nil {
+				{
+					off153 := dec.offset()
+					p, err := dec.value("Port", codecOrder_Port.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off153, err), "Port", codecTypeOf[int16])
+					}
+					if len(p) != 0 {
+						r, err := fn152("Port", codecOrder_Port.tag, p)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off153, err), "Port", codecTypeOf[int16])
+						}
+						v.Port = r
+					} else if codecOrder_Port.def != nil {
+						v.Port = *codecOrder_Port.def.(*int16)
+					}
+				}
+			} else {
+				if o := codecOrder_Port.order; o != nil {
+					off154 := dec.offset()
+					u, err := dec.binary(o, 2)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off154, err), "Port", codecTypeOf[int16])
+					}
+					v.Port = int16(codecSigned(u, 2))
+				} else {
+					{
+						off155 := dec.offset()
+						p, err := dec.value("Port", codecOrder_Port.tag, codecOrder_Port.format.Encoding == oxygen.PackedDecimal)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off155, err), "Port", codecTypeOf[int16])
+						}
+						if len(p) != 0 {
+							r, err := codecOrder_Port.format.ParseInt(string(p), 16)
+							v.Port = int16(r)
+							if err != nil {
+								return codecUnmarshalError(dec.typeError(off155, err), "Port", codecTypeOf[int16])
+							}
+						} else if codecOrder_Port.def != nil {
+							v.Port = *codecOrder_Port.def.(*int16)
+						}
+					}
+				}
+			}
+		case 19: // Ratio
+			if ok, err := codecPresent(&codecOrder_Ratio, v, codecSiblingOrder); err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "0, ,l,be", Field: "Ratio", Err: err}
+			} else if !ok {
+				continue
+			}
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "Ratio", codecTypeOf[float32])
+				}
+			}
+			sep = codecRemoveSeparator
+			if codecOrder_Ratio.err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "0, ,l,be", Field: "Ratio", Err: codecOrder_Ratio.err}
+			}
+			if _, fn158 := codecRegistered[float32](); fn158 != nil {
+				{
+					off159 := dec.offset()
+					p, err := dec.value("Ratio", codecOrder_Ratio.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off159, err), "Ratio", codecTypeOf[float32])
+					}
+					if len(p) != 0 {
+						r, err := fn158("Ratio", codecOrder_Ratio.tag, p)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off159, err), "Ratio", codecTypeOf[float32])
+						}
+						v.Ratio = r
+					} else if codecOrder_Ratio.def != nil {
+						v.Ratio = *codecOrder_Ratio.def.(*float32)
+					}
+				}
+			} else {
+				if o := codecOrder_Ratio.order; o != nil {
+					off160 := dec.offset()
+					u, err := dec.binary(o, 4)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off160, err), "Ratio", codecTypeOf[float32])
+					}
+					v.Ratio = float32(codecFloatFrom(u, 4))
+				} else {
+					{
+						off161 := dec.offset()
+						p, err := dec.value("Ratio", codecOrder_Ratio.tag, codecOrder_Ratio.format.Encoding == oxygen.PackedDecimal)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off161, err), "Ratio", codecTypeOf[float32])
+						}
+						if len(p) != 0 {
+							r, err := codecOrder_Ratio.format.ParseFloat(string(p), 32)
+							v.Ratio = float32(r)
+							if err != nil {
+								return codecUnmarshalError(dec.typeError(off161, err), "Ratio", codecTypeOf[float32])
+							}
+						} else if codecOrder_Ratio.def != nil {
+							v.Ratio = *codecOrder_Ratio.def.(*float32)
+						}
+					}
+				}
+			}
+		case 20: // Total
+			if ok, err := codecPresent(&codecOrder_Total, v, codecSiblingOrder); err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "3,_,r,p5.2", Field: "Total", Err: err}
+			} else if !ok {
+				continue
+			}
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "Total", codecTypeOf[oxygen.Decimal])
+				}
+			}
+			sep = codecRemoveSeparator
+			if codecOrder_Total.err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "3,_,r,p5.2", Field: "Total", Err: codecOrder_Total.err}
+			}
+			if _, fn164 := codecRegistered[oxygen.Decimal](); fn164 != nil {
+				{
+					off165 := dec.offset()
+					p, err := dec.value("Total", codecOrder_Total.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off165, err), "Total", codecTypeOf[oxygen.Decimal])
+					}
+					if len(p) != 0 {
+						r, err := fn164("Total", codecOrder_Total.tag, p)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off165, err), "Total", codecTypeOf[oxygen.Decimal])
+						}
+						v.Total = r
+					} else if codecOrder_Total.def != nil {
+						v.Total = *codecOrder_Total.def.(*oxygen.Decimal)
+					}
+				}
+			} else {
+				{
+					off166 := dec.offset()
+					p, err := dec.value("Total", codecOrder_Total.tag, codecOrder_Total.format.Encoding == oxygen.PackedDecimal)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off166, err), "Total", codecTypeOf[oxygen.Decimal])
+					}
+					if len(p) != 0 {
+						r, err := codecOrder_Total.format.ParseDecimal(string(p))
+						v.Total = r
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off166, err), "Total", codecTypeOf[oxygen.Decimal])
+						}
+					} else if codecOrder_Total.def != nil {
+						v.Total = *codecOrder_Total.def.(*oxygen.Decimal)
+					}
+				}
+			}
+		case 21: // Count
+			if ok, err := codecPresent(&codecOrder_Count, v, codecSiblingOrder); err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "4,0,r,z4", Field: "Count", Err: err}
+			} else if !ok {
+				continue
+			}
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "Count", codecTypeOf[int64])
+				}
+			}
+			sep = codecRemoveSeparator
+			if codecOrder_Count.err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "4,0,r,z4", Field: "Count", Err: codecOrder_Count.err}
+			}
+			if _, fn169 := codecRegistered[int64](); fn169 != nil {
+				{
+					off170 := dec.offset()
+					p, err := dec.value("Count", codecOrder_Count.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off170, err), "Count", codecTypeOf[int64])
+					}
+					if len(p) != 0 {
+						r, err := fn169("Count", codecOrder_Count.tag, p)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off170, err), "Count", codecTypeOf[int64])
+						}
+						v.Count = r
+					} else if codecOrder_Count.def != nil {
+						v.Count = *codecOrder_Count.def.(*int64)
+					}
+				}
+			} else {
+				if o := codecOrder_Count.order; o != nil {
+					off171 := dec.offset()
+					u, err := dec.binary(o, 8)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off171, err), "Count", codecTypeOf[int64])
+					}
+					v.Count = int64(codecSigned(u, 8))
+				} else {
+					{
+						off172 := dec.offset()
+						p, err := dec.value("Count", codecOrder_Count.tag, codecOrder_Count.format.Encoding == oxygen.PackedDecimal)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off172, err), "Count", codecTypeOf[int64])
+						}
+						if len(p) != 0 {
+							r, err := codecOrder_Count.format.ParseInt(string(p), 64)
+							v.Count = int64(r)
+							if err != nil {
+								return codecUnmarshalError(dec.typeError(off172, err), "Count", codecTypeOf[int64])
+							}
+						} else if codecOrder_Count.def != nil {
+							v.Count = *codecOrder_Count.def.(*int64)
+						}
+					}
+				}
+			}
+		}
+	}
+	if unwrap {
+		if codecStrict && len(dec.data) != 0 && !bytes.HasPrefix(dec.data, codecStructCloser) {
+			return &oxygen.SyntaxError{Name: cfg.Name, Offset: dec.offset(), Err: oxygen.ErrTrailingData}
+		}
+		if i := bytes.Index(dec.data, codecStructCloser); i > 0 {
+			dec.data = dec.data[i:]
+		}
+		if err := dec.removePrefix(codecStructCloser); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (enc *codecEncoder) encodeNestedOrder(v *records.Order, wrap bool) error {
+	if err := enc.enter(); err != nil {
+		return err
+	}
+	err := enc.encodeOrder(v, wrap)
+	enc.leave()
+	return err
+}
+
+func (dec *codecDecoder) decodeNestedOrder(v *records.Order, unwrap bool) error {
+	if err := dec.enter(); err != nil {
+		return dec.typeError(dec.offset(), err)
+	}
+	err := dec.decodeOrder(v, unwrap)
+	dec.leave()
+	return err
+}
+
+func codecSiblingLine(v *records.Line, name string) any {
+	switch name {
+	case "Qty":
+		return v.Qty
+	case "Price":
+		return v.Price
+	}
+	return nil
+}
+
+func codecRemainingLine(v *records.Line, from int, fn func(string, *codecTag, codecFields) bool) bool {
+	for i := from; i < 2; i++ {
+		switch i {
+		case 0: // Qty
+			if ok, err := codecPresent(&codecLine_Qty, v, codecSiblingLine); (ok || err != nil) && !fn("Qty", &codecLine_Qty, nil) {
+				return false
+			}
+		case 1: // Price
+			if ok, err := codecPresent(&codecLine_Price, v, codecSiblingLine); (ok || err != nil) && !fn("Price", &codecLine_Price, nil) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (enc *codecEncoder) encodeLine(v *records.Line, wrap bool) error {
+	sep := false
+	if wrap {
+		enc.Write(codecStructOpener)
+	}
+	// Qty
+	d173 := v.Qty
+	if codecLine_Qty.encodeDef && d173 == 0 {
+		d173 = *codecLine_Qty.def.(*int)
+	}
+	if ok, err := codecPresent(&codecLine_Qty, v, codecSiblingLine); err != nil {
+		return &oxygen.TagError{Name: cfg.Name, Tag: "2,0,r", Field: "Qty", Err: err}
+	} else if ok && !(codecLine_Qty.omit && d173 == 0) {
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if codecLine_Qty.err != nil {
+			return &oxygen.TagError{Name: cfg.Name, Tag: "2,0,r", Field: "Qty", Err: codecLine_Qty.err}
+		}
+		if fn174, _ := codecRegistered[int](); fn174 != nil {
+			p, err := fn174("Qty", codecLine_Qty.tag, d173)
+			if err != nil {
+				return codecMarshalError(err, "Qty", codecTypeOf[int])
+			}
+			if err := enc.value("Qty", codecLine_Qty.tag, p, false); err != nil {
+				return codecMarshalError(err, "Qty", codecTypeOf[int])
+			}
+		} else {
+			if o := codecLine_Qty.order; o != nil {
+				enc.Write(oxygen.AppendBinary(enc.scratch[:0], o, uint64(d173), strconv.IntSize/8))
+			} else {
+				if err := codecLine_Qty.format.CheckInt(int64(d173)); err != nil {
+					return codecMarshalError(err, "Qty", codecTypeOf[int])
+				}
+				if err := enc.value("Qty", codecLine_Qty.tag, codecLine_Qty.format.AppendInt(enc.scratch[:0], int64(d173)), codecLine_Qty.format.Encoding == oxygen.PackedDecimal); err != nil {
+					return codecMarshalError(err, "Qty", codecTypeOf[int])
+				}
+			}
+		}
+	}
+	// Price
+	d179 := v.Price
+	if codecLine_Price.encodeDef && d179 == nil {
+		d179 = codecLine_Price.def.(*uint)
+	}
+	if ok, err := codecPresent(&codecLine_Price, v, codecSiblingLine); err != nil {
+		return &oxygen.TagError{Name: cfg.Name, Tag: "3,0,r", Field: "Price", Err: err}
+	} else if ok && !(codecLine_Price.omit && d179 == nil) {
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if codecLine_Price.err != nil {
+			return &oxygen.TagError{Name: cfg.Name, Tag: "3,0,r", Field: "Price", Err: codecLine_Price.err}
+		}
+		if fn180, _ := codecRegistered[*uint](); fn180 != nil {
+			p, err := fn180("Price", codecLine_Price.tag, d179)
+			if err != nil {
+				return codecMarshalError(err, "Price", codecTypeOf[*uint])
+			}
+			if err := enc.value("Price", codecLine_Price.tag, p, false); err != nil {
+				return codecMarshalError(err, "Price", codecTypeOf[*uint])
+			}
+		} else {
+			p181 := d179
+			if p181 == nil {
+				p181 = new(uint)
+			}
+			if fn182, _ := codecRegistered[uint](); fn182 != nil {
+				p, err := fn182("Price", codecLine_Price.tag, (*p181))
+				if err != nil {
+					return codecMarshalError(err, "Price", codecTypeOf[*uint])
+				}
+				if err := enc.value("Price", codecLine_Price.tag, p, false); err != nil {
+					return codecMarshalError(err, "Price", codecTypeOf[*uint])
+				}
+			} else {
+				if o := codecLine_Price.order; o != nil {
+					enc.Write(oxygen.AppendBinary(enc.scratch[:0], o, uint64((*p181)), strconv.IntSize/8))
+				} else {
+					if err := codecLine_Price.format.CheckUint(uint64((*p181))); err != nil {
+						return codecMarshalError(err, "Price", codecTypeOf[*uint])
+					}
+					if err := enc.value("Price", codecLine_Price.tag, codecLine_Price.format.AppendUint(enc.scratch[:0], uint64((*p181))), codecLine_Price.format.Encoding == oxygen.PackedDecimal); err != nil {
+						return codecMarshalError(err, "Price", codecTypeOf[*uint])
+					}
+				}
+			}
+		}
+	}
+	if wrap {
+		enc.Write(codecStructCloser)
+	}
+	return nil
+}
+
+func (dec *codecDecoder) decodeLine(v *records.Line, unwrap bool) error {
+	if unwrap {
+		if err := dec.removePrefix(codecStructOpener); err != nil {
+			return err
+		}
+	}
+	sep := false
+	for i := 0; i < 2; i++ {
+		if len(dec.data) == 0 || unwrap && bytes.HasPrefix(dec.data, codecStructCloser) {
+			remaining := func(fn func(string, *codecTag, codecFields) bool) bool {
+				return codecRemainingLine(v, i, fn)
+			}
+			if codecStrict {
+				if name := codecTruncated(remaining); name != "" {
+					return &oxygen.SyntaxError{Name: cfg.Name, Field: name, Offset: dec.offset(), Err: oxygen.ErrTruncated}
+				}
+			}
+			remaining(dec.required)
+			break
+		}
+		switch i {
+		case 0: // Qty
+			if ok, err := codecPresent(&codecLine_Qty, v, codecSiblingLine); err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "2,0,r", Field: "Qty", Err: err}
+			} else if !ok {
+				continue
+			}
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "Qty", codecTypeOf[int])
+				}
+			}
+			sep = codecRemoveSeparator
+			if codecLine_Qty.err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "2,0,r", Field: "Qty", Err: codecLine_Qty.err}
+			}
+			if _, fn175 := codecRegistered[int](); fn175 != nil {
+				{
+					off176 := dec.offset()
+					p, err := dec.value("Qty", codecLine_Qty.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off176, err), "Qty", codecTypeOf[int])
+					}
+					if len(p) != 0 {
+						r, err := fn175("Qty", codecLine_Qty.tag, p)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off176, err), "Qty", codecTypeOf[int])
+						}
+						v.Qty = r
+					} else if codecLine_Qty.def != nil {
+						v.Qty = *codecLine_Qty.def.(*int)
+					}
+				}
+			} else {
+				if o := codecLine_Qty.order; o != nil {
+					off177 := dec.offset()
+					u, err := dec.binary(o, strconv.IntSize/8)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off177, err), "Qty", codecTypeOf[int])
+					}
+					v.Qty = int(codecSigned(u, strconv.IntSize/8))
+				} else {
+					{
+						off178 := dec.offset()
+						p, err := dec.value("Qty", codecLine_Qty.tag, codecLine_Qty.format.Encoding == oxygen.PackedDecimal)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off178, err), "Qty", codecTypeOf[int])
+						}
+						if len(p) != 0 {
+							r, err := codecLine_Qty.format.ParseInt(string(p), strconv.IntSize)
+							v.Qty = int(r)
+							if err != nil {
+								return codecUnmarshalError(dec.typeError(off178, err), "Qty", codecTypeOf[int])
+							}
+						} else if codecLine_Qty.def != nil {
+							v.Qty = *codecLine_Qty.def.(*int)
+						}
+					}
+				}
+			}
+		case 1: // Price
+			if ok, err := codecPresent(&codecLine_Price, v, codecSiblingLine); err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "3,0,r", Field: "Price", Err: err}
+			} else if !ok {
+				continue
+			}
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "Price", codecTypeOf[*uint])
+				}
+			}
+			sep = codecRemoveSeparator
+			if codecLine_Price.err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "3,0,r", Field: "Price", Err: codecLine_Price.err}
+			}
+			if _, fn183 := codecRegistered[*uint](); fn183 != nil {
+				{
+					off184 := dec.offset()
+					p, err := dec.value("Price", codecLine_Price.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off184, err), "Price", codecTypeOf[*uint])
+					}
+					if len(p) != 0 {
+						r, err := fn183("Price", codecLine_Price.tag, p)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off184, err), "Price", codecTypeOf[*uint])
+						}
+						v.Price = r
+					} else if codecLine_Price.def != nil {
+						d185 := *codecLine_Price.def.(*uint)
+						v.Price = &d185
+					}
+				}
+			} else {
+				{
+					p186 := v.Price
+					if p186 == nil {
+						p186 = new(uint)
+					}
+					if _, fn187 := codecRegistered[uint](); fn187 != nil {
+						{
+							off188 := dec.offset()
+							p, err := dec.value("Price", codecLine_Price.tag, false)
+							if err != nil {
+								return codecUnmarshalError(dec.typeError(off188, err), "Price", codecTypeOf[*uint])
+							}
+							if len(p) != 0 {
+								r, err := fn187("Price", codecLine_Price.tag, p)
+								if err != nil {
+									return codecUnmarshalError(dec.typeError(off188, err), "Price", codecTypeOf[*uint])
+								}
+								(*p186) = r
+							} else if codecLine_Price.def != nil {
+								d189 := *codecLine_Price.def.(*uint)
+								v.Price = &d189
+							}
+						}
+					} else {
+						if o := codecLine_Price.order; o != nil {
+							off190 := dec.offset()
+							u, err := dec.binary(o, strconv.IntSize/8)
+							if err != nil {
+								return codecUnmarshalError(dec.typeError(off190, err), "Price", codecTypeOf[*uint])
+							}
+							(*p186) = uint(u)
+						} else {
+							{
+								off191 := dec.offset()
+								p, err := dec.value("Price", codecLine_Price.tag, codecLine_Price.format.Encoding == oxygen.PackedDecimal)
+								if err != nil {
+									return codecUnmarshalError(dec.typeError(off191, err), "Price", codecTypeOf[*uint])
+								}
+								if len(p) != 0 {
+									r, err := codecLine_Price.format.ParseUint(string(p), strconv.IntSize)
+									(*p186) = uint(r)
+									if err != nil {
+										return codecUnmarshalError(dec.typeError(off191, err), "Price", codecTypeOf[*uint])
+									}
+								} else if codecLine_Price.def != nil {
+									d192 := *codecLine_Price.def.(*uint)
+									v.Price = &d192
+								}
+							}
+						}
+					}
+					if v.Price == nil && !((*p186) == 0) {
+						v.Price = p186
+					}
+				}
+			}
+		}
+	}
+	if unwrap {
+		if codecStrict && len(dec.data) != 0 && !bytes.HasPrefix(dec.data, codecStructCloser) {
+			return &oxygen.SyntaxError{Name: cfg.Name, Offset: dec.offset(), Err: oxygen.ErrTrailingData}
+		}
+		if i := bytes.Index(dec.data, codecStructCloser); i > 0 {
+			dec.data = dec.data[i:]
+		}
+		if err := dec.removePrefix(codecStructCloser); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (enc *codecEncoder) encodeNestedLine(v *records.Line, wrap bool) error {
+	if err := enc.enter(); err != nil {
+		return err
+	}
+	err := enc.encodeLine(v, wrap)
+	enc.leave()
+	return err
+}
+
+func (dec *codecDecoder) decodeNestedLine(v *records.Line, unwrap bool) error {
+	if err := dec.enter(); err != nil {
+		return dec.typeError(dec.offset(), err)
+	}
+	err := dec.decodeLine(v, unwrap)
+	dec.leave()
+	return err
+}
+
+func codecSiblingReading(v *records.Reading, name string) any {
+	switch name {
+	case "Value":
+		return v.Value
+	case "Count":
+		return v.Count
+	case "Units":
+		return v.Units
+	case "Total":
+		return v.Total
+	}
+	return nil
+}
+
+func codecRemainingReading(v *records.Reading, from int, fn func(string, *codecTag, codecFields) bool) bool {
+	for i := from; i < 4; i++ {
+		switch i {
+		case 0: // Value
+			if ok, err := codecPresent(&codecReading_Value, v, codecSiblingReading); (ok || err != nil) && !fn("Value", &codecReading_Value, nil) {
+				return false
+			}
+		case 1: // Count
+			if ok, err := codecPresent(&codecReading_Count, v, codecSiblingReading); (ok || err != nil) && !fn("Count", &codecReading_Count, nil) {
+				return false
+			}
+		case 2: // Units
+			if ok, err := codecPresent(&codecReading_Units, v, codecSiblingReading); (ok || err != nil) && !fn("Units", &codecReading_Units, nil) {
+				return false
+			}
+		case 3: // Total
+			if ok, err := codecPresent(&codecReading_Total, v, codecSiblingReading); (ok || err != nil) && !fn("Total", &codecReading_Total, nil) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (enc *codecEncoder) encodeReading(v *records.Reading, wrap bool) error {
+	sep := false
+	if wrap {
+		enc.Write(codecStructOpener)
+	}
+	// Value
+	d193 := v.Value
+	if codecReading_Value.encodeDef && d193 == 0 {
+		d193 = *codecReading_Value.def.(*float64)
+	}
+	if ok, err := codecPresent(&codecReading_Value, v, codecSiblingReading); err != nil {
+		return &oxygen.TagError{Name: cfg.Name, Tag: "3, ,l,p5.2", Field: "Value", Err: err}
+	} else if ok && !(codecReading_Value.omit && d193 == 0) {
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if codecReading_Value.err != nil {
+			return &oxygen.TagError{Name: cfg.Name, Tag: "3, ,l,p5.2", Field: "Value", Err: codecReading_Value.err}
+		}
+		if fn194, _ := codecRegistered[float64](); fn194 != nil {
+			p, err := fn194("Value", codecReading_Value.tag, d193)
+			if err != nil {
+				return codecMarshalError(err, "Value", codecTypeOf[float64])
+			}
+			if err := enc.value("Value", codecReading_Value.tag, p, false); err != nil {
+				return codecMarshalError(err, "Value", codecTypeOf[float64])
+			}
+		} else {
+			if o := codecReading_Value.order; o != nil {
+				enc.Write(oxygen.AppendBinary(enc.scratch[:0], o, codecFloatBits(float64(d193), 8), 8))
+			} else {
+				if err := codecReading_Value.format.CheckFloat(float64(d193)); err != nil {
+					return codecMarshalError(err, "Value", codecTypeOf[float64])
+				}
+				if err := enc.value("Value", codecReading_Value.tag, codecReading_Value.format.AppendFloat(enc.scratch[:0], float64(d193), 64), codecReading_Value.format.Encoding == oxygen.PackedDecimal); err != nil {
+					return codecMarshalError(err, "Value", codecTypeOf[float64])
+				}
+			}
+		}
+	}
+	// Count
+	d199 := v.Count
+	if codecReading_Count.encodeDef && d199 == 0 {
+		d199 = *codecReading_Count.def.(*int32)
+	}
+	if ok, err := codecPresent(&codecReading_Count, v, codecSiblingReading); err != nil {
+		return &oxygen.TagError{Name: cfg.Name, Tag: "3, ,l,p5", Field: "Count", Err: err}
+	} else if ok && !(codecReading_Count.omit && d199 == 0) {
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if codecReading_Count.err != nil {
+			return &oxygen.TagError{Name: cfg.Name, Tag: "3, ,l,p5", Field: "Count", Err: codecReading_Count.err}
+		}
+		if fn200, _ := codecRegistered[int32](); fn200 != nil {
+			p, err := fn200("Count", codecReading_Count.tag, d199)
+			if err != nil {
+				return codecMarshalError(err, "Count", codecTypeOf[int32])
+			}
+			if err := enc.value("Count", codecReading_Count.tag, p, false); err != nil {
+				return codecMarshalError(err, "Count", codecTypeOf[int32])
+			}
+		} else {
+			if o := codecReading_Count.order; o != nil {
+				enc.Write(oxygen.AppendBinary(enc.scratch[:0], o, uint64(d199), 4))
+			} else {
+				if err := codecReading_Count.format.CheckInt(int64(d199)); err != nil {
+					return codecMarshalError(err, "Count", codecTypeOf[int32])
+				}
+				if err := enc.value("Count", codecReading_Count.tag, codecReading_Count.format.AppendInt(enc.scratch[:0], int64(d199)), codecReading_Count.format.Encoding == oxygen.PackedDecimal); err != nil {
+					return codecMarshalError(err, "Count", codecTypeOf[int32])
+				}
+			}
+		}
+	}
+	// Units
+	d205 := v.Units
+	if codecReading_Units.encodeDef && d205 == 0 {
+		d205 = *codecReading_Units.def.(*uint32)
+	}
+	if ok, err := codecPresent(&codecReading_Units, v, codecSiblingReading); err != nil {
+		return &oxygen.TagError{Name: cfg.Name, Tag: "3, ,l,p5", Field: "Units", Err: err}
+	} else if ok && !(codecReading_Units.omit && d205 == 0) {
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if codecReading_Units.err != nil {
+			return &oxygen.TagError{Name: cfg.Name, Tag: "3, ,l,p5", Field: "Units", Err: codecReading_Units.err}
+		}
+		if fn206, _ := codecRegistered[uint32](); fn206 != nil {
+			p, err := fn206("Units", codecReading_Units.tag, d205)
+			if err != nil {
+				return codecMarshalError(err, "Units", codecTypeOf[uint32])
+			}
+			if err := enc.value("Units", codecReading_Units.tag, p, false); err != nil {
+				return codecMarshalError(err, "Units", codecTypeOf[uint32])
+			}
+		} else {
+			if o := codecReading_Units.order; o != nil {
+				enc.Write(oxygen.AppendBinary(enc.scratch[:0], o, uint64(d205), 4))
+			} else {
+				if err := codecReading_Units.format.CheckUint(uint64(d205)); err != nil {
+					return codecMarshalError(err, "Units", codecTypeOf[uint32])
+				}
+				if err := enc.value("Units", codecReading_Units.tag, codecReading_Units.format.AppendUint(enc.scratch[:0], uint64(d205)), codecReading_Units.format.Encoding == oxygen.PackedDecimal); err != nil {
+					return codecMarshalError(err, "Units", codecTypeOf[uint32])
+				}
+			}
+		}
+	}
+	// Total
+	d211 := v.Total
+	if codecReading_Total.encodeDef && d211 == *new(oxygen.Decimal) {
+		d211 = *codecReading_Total.def.(*oxygen.Decimal)
+	}
+	if ok, err := codecPresent(&codecReading_Total, v, codecSiblingReading); err != nil {
+		return &oxygen.TagError{Name: cfg.Name, Tag: "3, ,l,p5.2", Field: "Total", Err: err}
+	} else if ok {
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if codecReading_Total.err != nil {
+			return &oxygen.TagError{Name: cfg.Name, Tag: "3, ,l,p5.2", Field: "Total", Err: codecReading_Total.err}
+		}
+		if fn212, _ := codecRegistered[oxygen.Decimal](); fn212 != nil {
+			p, err := fn212("Total", codecReading_Total.tag, d211)
+			if err != nil {
+				return codecMarshalError(err, "Total", codecTypeOf[oxygen.Decimal])
+			}
+			if err := enc.value("Total", codecReading_Total.tag, p, false); err != nil {
+				return codecMarshalError(err, "Total", codecTypeOf[oxygen.Decimal])
+			}
+		} else {
+			if err := codecReading_Total.format.CheckDecimal(d211); err != nil {
+				return codecMarshalError(err, "Total", codecTypeOf[oxygen.Decimal])
+			}
+			if err := enc.value("Total", codecReading_Total.tag, codecReading_Total.format.AppendDecimal(enc.scratch[:0], d211), codecReading_Total.format.Encoding == oxygen.PackedDecimal); err != nil {
+				return codecMarshalError(err, "Total", codecTypeOf[oxygen.Decimal])
+			}
+		}
+	}
+	if wrap {
+		enc.Write(codecStructCloser)
+	}
+	return nil
+}
+
+func (dec *codecDecoder) decodeReading(v *records.Reading, unwrap bool) error {
+	if unwrap {
+		if err := dec.removePrefix(codecStructOpener); err != nil {
+			return err
+		}
+	}
+	sep := false
+	for i := 0; i < 4; i++ {
+		if len(dec.data) == 0 || unwrap && bytes.HasPrefix(dec.data, codecStructCloser) {
+			remaining := func(fn func(string, *codecTag, codecFields) bool) bool {
+				return codecRemainingReading(v, i, fn)
+			}
+			if codecStrict {
+				if name := codecTruncated(remaining); name != "" {
+					return &oxygen.SyntaxError{Name: cfg.Name, Field: name, Offset: dec.offset(), Err: oxygen.ErrTruncated}
+				}
+			}
+			remaining(dec.required)
+			break
+		}
+		switch i {
+		case 0: // Value
+			if ok, err := codecPresent(&codecReading_Value, v, codecSiblingReading); err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "3, ,l,p5.2", Field: "Value", Err: err}
+			} else if !ok {
+				continue
+			}
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "Value", codecTypeOf[float64])
+				}
+			}
+			sep = codecRemoveSeparator
+			if codecReading_Value.err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "3, ,l,p5.2", Field: "Value", Err: codecReading_Value.err}
+			}
+			if _, fn195 := codecRegistered[float64](); fn195 != nil {
+				{
+					off196 := dec.offset()
+					p, err := dec.value("Value", codecReading_Value.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off196, err), "Value", codecTypeOf[float64])
+					}
+					if len(p) != 0 {
+						r, err := fn195("Value", codecReading_Value.tag, p)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off196, err), "Value", codecTypeOf[float64])
+						}
+						v.Value = r
+					} else if codecReading_Value.def != nil {
+						v.Value = *codecReading_Value.def.(*float64)
+					}
+				}
+			} else {
+				if o := codecReading_Value.order; o != nil {
+					off197 := dec.offset()
+					u, err := dec.binary(o, 8)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off197, err), "Value", codecTypeOf[float64])
+					}
+					v.Value = float64(codecFloatFrom(u, 8))
+				} else {
+					{
+						off198 := dec.offset()
+						p, err := dec.value("Value", codecReading_Value.tag, codecReading_Value.format.Encoding == oxygen.PackedDecimal)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off198, err), "Value", codecTypeOf[float64])
+						}
+						if len(p) != 0 {
+							r, err := codecReading_Value.format.ParseFloat(string(p), 64)
+							v.Value = float64(r)
+							if err != nil {
+								return codecUnmarshalError(dec.typeError(off198, err), "Value", codecTypeOf[float64])
+							}
+						} else if codecReading_Value.def != nil {
+							v.Value = *codecReading_Value.def.(*float64)
+						}
+					}
+				}
+			}
+		case 1: // Count
+			if ok, err := codecPresent(&codecReading_Count, v, codecSiblingReading); err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "3, ,l,p5", Field: "Count", Err: err}
+			} else if !ok {
+				continue
+			}
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "Count", codecTypeOf[int32])
+				}
+			}
+			sep = codecRemoveSeparator
+			if codecReading_Count.err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "3, ,l,p5", Field: "Count", Err: codecReading_Count.err}
+			}
+			if _, fn201 := codecRegistered[int32](); fn201 != nil {
+				{
+					off202 := dec.offset()
+					p, err := dec.value("Count", codecReading_Count.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off202, err), "Count", codecTypeOf[int32])
+					}
+					if len(p) != 0 {
+						r, err := fn201("Count", codecReading_Count.tag, p)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off202, err), "Count", codecTypeOf[int32])
+						}
+						v.Count = r
+					} else if codecReading_Count.def != nil {
+						v.Count = *codecReading_Count.def.(*int32)
+					}
+				}
+			} else {
+				if o := codecReading_Count.order; o != nil {
+					off203 := dec.offset()
+					u, err := dec.binary(o, 4)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off203, err), "Count", codecTypeOf[int32])
+					}
+					v.Count = int32(codecSigned(u, 4))
+				} else {
+					{
+						off204 := dec.offset()
+						p, err := dec.value("Count", codecReading_Count.tag, codecReading_Count.format.Encoding == oxygen.PackedDecimal)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off204, err), "Count", codecTypeOf[int32])
+						}
+						if len(p) != 0 {
+							r, err := codecReading_Count.format.ParseInt(string(p), 32)
+							v.Count = int32(r)
+							if err != nil {
+								return codecUnmarshalError(dec.typeError(off204, err), "Count", codecTypeOf[int32])
+							}
+						} else if codecReading_Count.def != nil {
+							v.Count = *codecReading_Count.def.(*int32)
+						}
+					}
+				}
+			}
+		case 2: // Units
+			if ok, err := codecPresent(&codecReading_Units, v, codecSiblingReading); err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "3, ,l,p5", Field: "Units", Err: err}
+			} else if !ok {
+				continue
+			}
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "Units", codecTypeOf[uint32])
+				}
+			}
+			sep = codecRemoveSeparator
+			if codecReading_Units.err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "3, ,l,p5", Field: "Units", Err: codecReading_Units.err}
+			}
+			if _, fn207 := codecRegistered[uint32](); fn207 != nil {
+				{
+					off208 := dec.offset()
+					p, err := dec.value("Units", codecReading_Units.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off208, err), "Units", codecTypeOf[uint32])
+					}
+					if len(p) != 0 {
+						r, err := fn207("Units", codecReading_Units.tag, p)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off208, err), "Units", codecTypeOf[uint32])
+						}
+						v.Units = r
+					} else if codecReading_Units.def != nil {
+						v.Units = *codecReading_Units.def.(*uint32)
+					}
+				}
+			} else {
+				if o := codecReading_Units.order; o != nil {
+					off209 := dec.offset()
+					u, err := dec.binary(o, 4)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off209, err), "Units", codecTypeOf[uint32])
+					}
+					v.Units = uint32(u)
+				} else {
+					{
+						off210 := dec.offset()
+						p, err := dec.value("Units", codecReading_Units.tag, codecReading_Units.format.Encoding == oxygen.PackedDecimal)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off210, err), "Units", codecTypeOf[uint32])
+						}
+						if len(p) != 0 {
+							r, err := codecReading_Units.format.ParseUint(string(p), 32)
+							v.Units = uint32(r)
+							if err != nil {
+								return codecUnmarshalError(dec.typeError(off210, err), "Units", codecTypeOf[uint32])
+							}
+						} else if codecReading_Units.def != nil {
+							v.Units = *codecReading_Units.def.(*uint32)
+						}
+					}
+				}
+			}
+		case 3: // Total
+			if ok, err := codecPresent(&codecReading_Total, v, codecSiblingReading); err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "3, ,l,p5.2", Field: "Total", Err: err}
+			} else if !ok {
+				continue
+			}
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "Total", codecTypeOf[oxygen.Decimal])
+				}
+			}
+			sep = codecRemoveSeparator
+			if codecReading_Total.err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "3, ,l,p5.2", Field: "Total", Err: codecReading_Total.err}
+			}
+			if _, fn213 := codecRegistered[oxygen.Decimal](); fn213 != nil {
+				{
+					off214 := dec.offset()
+					p, err := dec.value("Total", codecReading_Total.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off214, err), "Total", codecTypeOf[oxygen.Decimal])
+					}
+					if len(p) != 0 {
+						r, err := fn213("Total", codecReading_Total.tag, p)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off214, err), "Total", codecTypeOf[oxygen.Decimal])
+						}
+						v.Total = r
+					} else if codecReading_Total.def != nil {
+						v.Total = *codecReading_Total.def.(*oxygen.Decimal)
+					}
+				}
+			} else {
+				{
+					off215 := dec.offset()
+					p, err := dec.value("Total", codecReading_Total.tag, codecReading_Total.format.Encoding == oxygen.PackedDecimal)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off215, err), "Total", codecTypeOf[oxygen.Decimal])
+					}
+					if len(p) != 0 {
+						r, err := codecReading_Total.format.ParseDecimal(string(p))
+						v.Total = r
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off215, err), "Total", codecTypeOf[oxygen.Decimal])
+						}
+					} else if codecReading_Total.def != nil {
+						v.Total = *codecReading_Total.def.(*oxygen.Decimal)
+					}
+				}
+			}
+		}
+	}
+	if unwrap {
+		if codecStrict && len(dec.data) != 0 && !bytes.HasPrefix(dec.data, codecStructCloser) {
+			return &oxygen.SyntaxError{Name: cfg.Name, Offset: dec.offset(), Err: oxygen.ErrTrailingData}
+		}
+		if i := bytes.Index(dec.data, codecStructCloser); i > 0 {
+			dec.data = dec.data[i:]
+		}
+		if err := dec.removePrefix(codecStructCloser); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (enc *codecEncoder) encodeNestedReading(v *records.Reading, wrap bool) error {
+	if err := enc.enter(); err != nil {
+		return err
+	}
+	err := enc.encodeReading(v, wrap)
+	enc.leave()
+	return err
+}
+
+func (dec *codecDecoder) decodeNestedReading(v *records.Reading, unwrap bool) error {
+	if err := dec.enter(); err != nil {
+		return dec.typeError(dec.offset(), err)
+	}
+	err := dec.decodeReading(v, unwrap)
+	dec.leave()
+	return err
+}
+
+func codecSiblingPayment(v *records.Payment, name string) any {
+	switch name {
+	case "Amount":
+		return v.Amount
+	case "Foreign":
+		return v.Foreign
+	case "Currency":
+		return v.Currency
+	case "Rate":
+		return v.Rate
+	}
+	return nil
+}
+
+func codecRemainingPayment(v *records.Payment, from int, fn func(string, *codecTag, codecFields) bool) bool {
+	for i := from; i < 4; i++ {
+		switch i {
+		case 0: // Amount
+			if ok, err := codecPresent(&codecPayment_Amount, v, codecSiblingPayment); (ok || err != nil) && !fn("Amount", &codecPayment_Amount, nil) {
+				return false
+			}
+		case 1: // Foreign
+			if ok, err := codecPresent(&codecPayment_Foreign, v, codecSiblingPayment); (ok || err != nil) && !fn("Foreign", &codecPayment_Foreign, nil) {
+				return false
+			}
+		case 2: // Currency
+			if ok, err := codecPresent(&codecPayment_Currency, v, codecSiblingPayment); (ok || err != nil) && !fn("Currency", &codecPayment_Currency, nil) {
+				return false
+			}
+		case 3: // Rate
+			if ok, err := codecPresent(&codecPayment_Rate, v, codecSiblingPayment); (ok || err != nil) && !fn("Rate", &codecPayment_Rate, nil) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (enc *codecEncoder) encodePayment(v *records.Payment, wrap bool) error {
+	sep := false
+	if wrap {
+		enc.Write(codecStructOpener)
+	}
+	// Amount
+	d216 := v.Amount
+	if codecPayment_Amount.encodeDef && d216 == 0 {
+		d216 = *codecPayment_Amount.def.(*int)
+	}
+	if ok, err := codecPresent(&codecPayment_Amount, v, codecSiblingPayment); err != nil {
+		return &oxygen.TagError{Name: cfg.Name, Tag: "4,0,r", Field: "Amount", Err: err}
+	} else if ok && !(codecPayment_Amount.omit && d216 == 0) {
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if codecPayment_Amount.err != nil {
+			return &oxygen.TagError{Name: cfg.Name, Tag: "4,0,r", Field: "Amount", Err: codecPayment_Amount.err}
+		}
+		if fn217, _ := codecRegistered[int](); fn217 != nil {
+			p, err := fn217("Amount", codecPayment_Amount.tag, d216)
+			if err != nil {
+				return codecMarshalError(err, "Amount", codecTypeOf[int])
+			}
+			if err := enc.value("Amount", codecPayment_Amount.tag, p, false); err != nil {
+				return codecMarshalError(err, "Amount", codecTypeOf[int])
+			}
+		} else {
+			if o := codecPayment_Amount.order; o != nil {
+				enc.Write(oxygen.AppendBinary(enc.scratch[:0], o, uint64(d216), strconv.IntSize/8))
+			} else {
+				if err := codecPayment_Amount.format.CheckInt(int64(d216)); err != nil {
+					return codecMarshalError(err, "Amount", codecTypeOf[int])
+				}
+				if err := enc.value("Amount", codecPayment_Amount.tag, codecPayment_Amount.format.AppendInt(enc.scratch[:0], int64(d216)), codecPayment_Amount.format.Encoding == oxygen.PackedDecimal); err != nil {
+					return codecMarshalError(err, "Amount", codecTypeOf[int])
+				}
+			}
+		}
+	}
+	// Foreign
+	d222 := v.Foreign
+	if codecPayment_Foreign.encodeDef && len(d222) == 0 {
+		d222 = *codecPayment_Foreign.def.(*string)
+	}
+	if ok, err := codecPresent(&codecPayment_Foreign, v, codecSiblingPayment); err != nil {
+		return &oxygen.TagError{Name: cfg.Name, Tag: "1", Field: "Foreign", Err: err}
+	} else if ok && !(codecPayment_Foreign.omit && len(d222) == 0) {
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if codecPayment_Foreign.err != nil {
+			return &oxygen.TagError{Name: cfg.Name, Tag: "1", Field: "Foreign", Err: codecPayment_Foreign.err}
+		}
+		if fn223, _ := codecRegistered[string](); fn223 != nil {
+			p, err := fn223("Foreign", codecPayment_Foreign.tag, d222)
+			if err != nil {
+				return codecMarshalError(err, "Foreign", codecTypeOf[string])
+			}
+			if err := enc.value("Foreign", codecPayment_Foreign.tag, p, false); err != nil {
+				return codecMarshalError(err, "Foreign", codecTypeOf[string])
+			}
+		} else {
+			if err := enc.value("Foreign", codecPayment_Foreign.tag, append(enc.scratch[:0], string(d222)...), false); err != nil {
+				return codecMarshalError(err, "Foreign", codecTypeOf[string])
+			}
+		}
+	}
+	// Currency
+	d227 := v.Currency
+	if codecPayment_Currency.encodeDef && len(d227) == 0 {
+		d227 = *codecPayment_Currency.def.(*string)
+	}
+	if ok, err := codecPresent(&codecPayment_Currency, v, codecSiblingPayment); err != nil {
+		return &oxygen.TagError{Name: cfg.Name, Tag: "3,_,l,,,Foreign=Y", Field: "Currency", Err: err}
+	} else if ok && !(codecPayment_Currency.omit && len(d227) == 0) {
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if codecPayment_Currency.err != nil {
+			return &oxygen.TagError{Name: cfg.Name, Tag: "3,_,l,,,Foreign=Y", Field: "Currency", Err: codecPayment_Currency.err}
+		}
+		if fn228, _ := codecRegistered[string](); fn228 != nil {
+			p, err := fn228("Currency", codecPayment_Currency.tag, d227)
+			if err != nil {
+				return codecMarshalError(err, "Currency", codecTypeOf[string])
+			}
+			if err := enc.value("Currency", codecPayment_Currency.tag, p, false); err != nil {
+				return codecMarshalError(err, "Currency", codecTypeOf[string])
+			}
+		} else {
+			if err := enc.value("Currency", codecPayment_Currency.tag, append(enc.scratch[:0], string(d227)...), false); err != nil {
+				return codecMarshalError(err, "Currency", codecTypeOf[string])
+			}
+		}
+	}
+	// Rate
+	d232 := v.Rate
+	if codecPayment_Rate.encodeDef && d232 == 0 {
+		d232 = *codecPayment_Rate.def.(*uint16)
+	}
+	if ok, err := codecPresent(&codecPayment_Rate, v, codecSiblingPayment); err != nil {
+		return &oxygen.TagError{Name: cfg.Name, Tag: "4,0,r,,,@rated", Field: "Rate", Err: err}
+	} else if ok && !(codecPayment_Rate.omit && d232 == 0) {
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if codecPayment_Rate.err != nil {
+			return &oxygen.TagError{Name: cfg.Name, Tag: "4,0,r,,,@rated", Field: "Rate", Err: codecPayment_Rate.err}
+		}
+		if fn233, _ := codecRegistered[uint16](); fn233 != nil {
+			p, err := fn233("Rate", codecPayment_Rate.tag, d232)
+			if err != nil {
+				return codecMarshalError(err, "Rate", codecTypeOf[uint16])
+			}
+			if err := enc.value("Rate", codecPayment_Rate.tag, p, false); err != nil {
+				return codecMarshalError(err, "Rate", codecTypeOf[uint16])
+			}
+		} else {
+			if o := codecPayment_Rate.order; o != nil {
+				enc.Write(oxygen.AppendBinary(enc.scratch[:0], o, uint64(d232), 2))
+			} else {
+				if err := codecPayment_Rate.format.CheckUint(uint64(d232)); err != nil {
+					return codecMarshalError(err, "Rate", codecTypeOf[uint16])
+				}
+				if err := enc.value("Rate", codecPayment_Rate.tag, codecPayment_Rate.format.AppendUint(enc.scratch[:0], uint64(d232)), codecPayment_Rate.format.Encoding == oxygen.PackedDecimal); err != nil {
+					return codecMarshalError(err, "Rate", codecTypeOf[uint16])
+				}
+			}
+		}
+	}
+	if wrap {
+		enc.Write(codecStructCloser)
+	}
+	return nil
+}
+
+func (dec *codecDecoder) decodePayment(v *records.Payment, unwrap bool) error {
+	if unwrap {
+		if err := dec.removePrefix(codecStructOpener); err != nil {
+			return err
+		}
+	}
+	sep := false
+	for i := 0; i < 4; i++ {
+		if len(dec.data) == 0 || unwrap && bytes.HasPrefix(dec.data, codecStructCloser) {
+			remaining := func(fn func(string, *codecTag, codecFields) bool) bool {
+				return codecRemainingPayment(v, i, fn)
+			}
+			if codecStrict {
+				if name := codecTruncated(remaining); name != "" {
+					return &oxygen.SyntaxError{Name: cfg.Name, Field: name, Offset: dec.offset(), Err: oxygen.ErrTruncated}
+				}
+			}
+			remaining(dec.required)
+			break
+		}
+		switch i {
+		case 0: // Amount
+			if ok, err := codecPresent(&codecPayment_Amount, v, codecSiblingPayment); err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "4,0,r", Field: "Amount", Err: err}
+			} else if !ok {
+				continue
+			}
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "Amount", codecTypeOf[int])
+				}
+			}
+			sep = codecRemoveSeparator
+			if codecPayment_Amount.err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "4,0,r", Field: "Amount", Err: codecPayment_Amount.err}
+			}
+			if _, fn218 := codecRegistered[int](); fn218 != nil {
+				{
+					off219 := dec.offset()
+					p, err := dec.value("Amount", codecPayment_Amount.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off219, err), "Amount", codecTypeOf[int])
+					}
+					if len(p) != 0 {
+						r, err := fn218("Amount", codecPayment_Amount.tag, p)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off219, err), "Amount", codecTypeOf[int])
+						}
+						v.Amount = r
+					} else if codecPayment_Amount.def != nil {
+						v.Amount = *codecPayment_Amount.def.(*int)
+					}
+				}
+			} else {
+				if o := codecPayment_Amount.order; o != nil {
+					off220 := dec.offset()
+					u, err := dec.binary(o, strconv.IntSize/8)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off220, err), "Amount", codecTypeOf[int])
+					}
+					v.Amount = int(codecSigned(u, strconv.IntSize/8))
+				} else {
+					{
+						off221 := dec.offset()
+						p, err := dec.value("Amount", codecPayment_Amount.tag, codecPayment_Amount.format.Encoding == oxygen.PackedDecimal)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off221, err), "Amount", codecTypeOf[int])
+						}
+						if len(p) != 0 {
+							r, err := codecPayment_Amount.format.ParseInt(string(p), strconv.IntSize)
+							v.Amount = int(r)
+							if err != nil {
+								return codecUnmarshalError(dec.typeError(off221, err), "Amount", codecTypeOf[int])
+							}
+						} else if codecPayment_Amount.def != nil {
+							v.Amount = *codecPayment_Amount.def.(*int)
+						}
+					}
+				}
+			}
+		case 1: // Foreign
+			if ok, err := codecPresent(&codecPayment_Foreign, v, codecSiblingPayment); err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "1", Field: "Foreign", Err: err}
+			} else if !ok {
+				continue
+			}
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "Foreign", codecTypeOf[string])
+				}
+			}
+			sep = codecRemoveSeparator
+			if codecPayment_Foreign.err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "1", Field: "Foreign", Err: codecPayment_Foreign.err}
+			}
+			if _, fn224 := codecRegistered[string](); fn224 != nil {
+				{
+					off225 := dec.offset()
+					p, err := dec.value("Foreign", codecPayment_Foreign.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off225, err), "Foreign", codecTypeOf[string])
+					}
+					if len(p) != 0 {
+						r, err := fn224("Foreign", codecPayment_Foreign.tag, p)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off225, err), "Foreign", codecTypeOf[string])
+						}
+						v.Foreign = r
+					} else if codecPayment_Foreign.def != nil {
+						v.Foreign = *codecPayment_Foreign.def.(*string)
+					}
+				}
+			} else {
+				{
+					off226 := dec.offset()
+					p, err := dec.value("Foreign", codecPayment_Foreign.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off226, err), "Foreign", codecTypeOf[string])
+					}
+					if len(p) != 0 {
+						v.Foreign = string(p)
+					} else if codecPayment_Foreign.def != nil {
+						v.Foreign = *codecPayment_Foreign.def.(*string)
+					}
+				}
+			}
+		case 2: // Currency
+			if ok, err := codecPresent(&codecPayment_Currency, v, codecSiblingPayment); err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "3,_,l,,,Foreign=Y", Field: "Currency", Err: err}
+			} else if !ok {
+				continue
+			}
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "Currency", codecTypeOf[string])
+				}
+			}
+			sep = codecRemoveSeparator
+			if codecPayment_Currency.err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "3,_,l,,,Foreign=Y", Field: "Currency", Err: codecPayment_Currency.err}
+			}
+			if _, fn229 := codecRegistered[string](); fn229 != nil {
+				{
+					off230 := dec.offset()
+					p, err := dec.value("Currency", codecPayment_Currency.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off230, err), "Currency", codecTypeOf[string])
+					}
+					if len(p) != 0 {
+						r, err := fn229("Currency", codecPayment_Currency.tag, p)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off230, err), "Currency", codecTypeOf[string])
+						}
+						v.Currency = r
+					} else if codecPayment_Currency.def != nil {
+						v.Currency = *codecPayment_Currency.def.(*string)
+					}
+				}
+			} else {
+				{
+					off231 := dec.offset()
+					p, err := dec.value("Currency", codecPayment_Currency.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off231, err), "Currency", codecTypeOf[string])
+					}
+					if len(p) != 0 {
+						v.Currency = string(p)
+					} else if codecPayment_Currency.def != nil {
+						v.Currency = *codecPayment_Currency.def.(*string)
+					}
+				}
+			}
+		case 3: // Rate
+			if ok, err := codecPresent(&codecPayment_Rate, v, codecSiblingPayment); err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "4,0,r,,,@rated", Field: "Rate", Err: err}
+			} else if !ok {
+				continue
+			}
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "Rate", codecTypeOf[uint16])
+				}
+			}
+			sep = codecRemoveSeparator
+			if codecPayment_Rate.err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "4,0,r,,,@rated", Field: "Rate", Err: codecPayment_Rate.err}
+			}
+			if _, fn234 := codecRegistered[uint16](); fn234 != nil {
+				{
+					off235 := dec.offset()
+					p, err := dec.value("Rate", codecPayment_Rate.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off235, err), "Rate", codecTypeOf[uint16])
+					}
+					if len(p) != 0 {
+						r, err := fn234("Rate", codecPayment_Rate.tag, p)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off235, err), "Rate", codecTypeOf[uint16])
+						}
+						v.Rate = r
+					} else if codecPayment_Rate.def != nil {
+						v.Rate = *codecPayment_Rate.def.(*uint16)
+					}
+				}
+			} else {
+				if o := codecPayment_Rate.order; o != nil {
+					off236 := dec.offset()
+					u, err := dec.binary(o, 2)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off236, err), "Rate", codecTypeOf[uint16])
+					}
+					v.Rate = uint16(u)
+				} else {
+					{
+						off237 := dec.offset()
+						p, err := dec.value("Rate", codecPayment_Rate.tag, codecPayment_Rate.format.Encoding == oxygen.PackedDecimal)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off237, err), "Rate", codecTypeOf[uint16])
+						}
+						if len(p) != 0 {
+							r, err := codecPayment_Rate.format.ParseUint(string(p), 16)
+							v.Rate = uint16(r)
+							if err != nil {
+								return codecUnmarshalError(dec.typeError(off237, err), "Rate", codecTypeOf[uint16])
+							}
+						} else if codecPayment_Rate.def != nil {
+							v.Rate = *codecPayment_Rate.def.(*uint16)
+						}
+					}
+				}
+			}
+		}
+	}
+	if unwrap {
+		if codecStrict && len(dec.data) != 0 && !bytes.HasPrefix(dec.data, codecStructCloser) {
+			return &oxygen.SyntaxError{Name: cfg.Name, Offset: dec.offset(), Err: oxygen.ErrTrailingData}
+		}
+		if i := bytes.Index(dec.data, codecStructCloser); i > 0 {
+			dec.data = dec.data[i:]
+		}
+		if err := dec.removePrefix(codecStructCloser); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (enc *codecEncoder) encodeNestedPayment(v *records.Payment, wrap bool) error {
+	if err := enc.enter(); err != nil {
+		return err
+	}
+	err := enc.encodePayment(v, wrap)
+	enc.leave()
+	return err
+}
+
+func (dec *codecDecoder) decodeNestedPayment(v *records.Payment, unwrap bool) error {
+	if err := dec.enter(); err != nil {
+		return dec.typeError(dec.offset(), err)
+	}
+	err := dec.decodePayment(v, unwrap)
+	dec.leave()
+	return err
+}
+
+func codecSiblingBatch(v *records.Batch, name string) any {
+	switch name {
+	case "ID":
+		return v.ID
+	case "Limits":
+		return v.Limits
+	case "Items":
+		return v.Items
+	}
+	return nil
+}
+
+func codecRemainingBatch(v *records.Batch, from int, fn func(string, *codecTag, codecFields) bool) bool {
+	for i := from; i < 3; i++ {
+		switch i {
+		case 0: // ID
+			if ok, err := codecPresent(&codecBatch_ID, v, codecSiblingBatch); (ok || err != nil) && !fn("ID", &codecBatch_ID, nil) {
+				return false
+			}
+		case 1: // Limits
+			if !fn("Limits", nil, codecNested[records.Limits](func(fn func(string, *codecTag, codecFields) bool) bool {
+				return codecRemainingLimits(&v.Limits, 0, fn)
+			})) {
+				return false
+			}
+		case 2: // Items
+			if !fn("Items", nil, nil) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (enc *codecEncoder) encodeBatch(v *records.Batch, wrap bool) error {
+	sep := false
+	if wrap {
+		enc.Write(codecStructOpener)
+	}
+	// ID
+	d238 := v.ID
+	if codecBatch_ID.encodeDef && d238 == 0 {
+		d238 = *codecBatch_ID.def.(*int)
+	}
+	if ok, err := codecPresent(&codecBatch_ID, v, codecSiblingBatch); err != nil {
+		return &oxygen.TagError{Name: cfg.Name, Tag: "!4,0,r", Field: "ID", Err: err}
+	} else if ok && !(codecBatch_ID.omit && d238 == 0) {
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if codecBatch_ID.err != nil {
+			return &oxygen.TagError{Name: cfg.Name, Tag: "!4,0,r", Field: "ID", Err: codecBatch_ID.err}
+		}
+		if fn239, _ := codecRegistered[int](); fn239 != nil {
+			p, err := fn239("ID", codecBatch_ID.tag, d238)
+			if err != nil {
+				return codecMarshalError(err, "ID", codecTypeOf[int])
+			}
+			if err := enc.value("ID", codecBatch_ID.tag, p, false); err != nil {
+				return codecMarshalError(err, "ID", codecTypeOf[int])
+			}
+		} else {
+			if o := codecBatch_ID.order; o != nil {
+				enc.Write(oxygen.AppendBinary(enc.scratch[:0], o, uint64(d238), strconv.IntSize/8))
+			} else {
+				if err := codecBatch_ID.format.CheckInt(int64(d238)); err != nil {
+					return codecMarshalError(err, "ID", codecTypeOf[int])
+				}
+				if err := enc.value("ID", codecBatch_ID.tag, codecBatch_ID.format.AppendInt(enc.scratch[:0], int64(d238)), codecBatch_ID.format.Encoding == oxygen.PackedDecimal); err != nil {
+					return codecMarshalError(err, "ID", codecTypeOf[int])
+				}
+			}
+		}
+	}
+	// Limits
+	{
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if fn244, _ := codecRegistered[records.Limits](); fn244 != nil {
+			p, err := fn244("Limits", nil, v.Limits)
+			if err != nil {
+				return codecMarshalError(err, "Limits", codecTypeOf[records.Limits])
+			}
+			if err := enc.value("Limits", nil, p, false); err != nil {
+				return codecMarshalError(err, "Limits", codecTypeOf[records.Limits])
+			}
+		} else {
+			if err := enc.encodeNestedLimits(&v.Limits, codecWrap); err != nil {
+				return codecMarshalError(err, "Limits", codecTypeOf[records.Limits])
+			}
+		}
+	}
+	// Items
+	{
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if fn248, _ := codecRegistered[[]records.Item](); fn248 != nil {
+			p, err := fn248("Items", nil, v.Items)
+			if err != nil {
+				return codecMarshalError(err, "Items", codecTypeOf[[]records.Item])
+			}
+			if err := enc.value("Items", nil, p, false); err != nil {
+				return codecMarshalError(err, "Items", codecTypeOf[[]records.Item])
+			}
+		} else {
+			if err := enc.enter(); err != nil {
+				return codecMarshalError(err, "Items", codecTypeOf[[]records.Item])
+			}
+			if codecWrapList {
+				enc.Write(codecListOpener)
+			}
+			for i249 := range v.Items {
+				if i249 > 0 && codecSeparateElems {
+					enc.Write(codecElementSeparator)
+				}
+				if fn250, _ := codecRegistered[records.Item](); fn250 != nil {
+					p, err := fn250("Items", nil, v.Items[i249])
+					if err != nil {
+						return codecMarshalError(err, "Items", codecTypeOf[[]records.Item])
+					}
+					if err := enc.value("Items", nil, p, false); err != nil {
+						return codecMarshalError(err, "Items", codecTypeOf[[]records.Item])
+					}
+				} else {
+					if err := enc.encodeNestedItem(&v.Items[i249], codecWrap); err != nil {
+						return codecMarshalError(err, "Items", codecTypeOf[[]records.Item])
+					}
+				}
+			}
+			if codecWrapList {
+				enc.Write(codecListCloser)
+			}
+			enc.leave()
+		}
+	}
+	if wrap {
+		enc.Write(codecStructCloser)
+	}
+	return nil
+}
+
+func (dec *codecDecoder) decodeBatch(v *records.Batch, unwrap bool) error {
+	if unwrap {
+		if err := dec.removePrefix(codecStructOpener); err != nil {
+			return err
+		}
+	}
+	sep := false
+	for i := 0; i < 3; i++ {
+		if len(dec.data) == 0 || unwrap && bytes.HasPrefix(dec.data, codecStructCloser) {
+			remaining := func(fn func(string, *codecTag, codecFields) bool) bool {
+				return codecRemainingBatch(v, i, fn)
+			}
+			if codecStrict {
+				if name := codecTruncated(remaining); name != "" {
+					return &oxygen.SyntaxError{Name: cfg.Name, Field: name, Offset: dec.offset(), Err: oxygen.ErrTruncated}
+				}
+			}
+			remaining(dec.required)
+			break
+		}
+		switch i {
+		case 0: // ID
+			if ok, err := codecPresent(&codecBatch_ID, v, codecSiblingBatch); err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "!4,0,r", Field: "ID", Err: err}
+			} else if !ok {
+				continue
+			}
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "ID", codecTypeOf[int])
+				}
+			}
+			sep = codecRemoveSeparator
+			if codecBatch_ID.err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "!4,0,r", Field: "ID", Err: codecBatch_ID.err}
+			}
+			if _, fn240 := codecRegistered[int](); fn240 != nil {
+				{
+					off241 := dec.offset()
+					p, err := dec.value("ID", codecBatch_ID.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off241, err), "ID", codecTypeOf[int])
+					}
+					if len(p) != 0 {
+						r, err := fn240("ID", codecBatch_ID.tag, p)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off241, err), "ID", codecTypeOf[int])
+						}
+						v.ID = r
+					} else if codecBatch_ID.def != nil {
+						v.ID = *codecBatch_ID.def.(*int)
+					}
+				}
+			} else {
+				if o := codecBatch_ID.order; o != nil {
+					off242 := dec.offset()
+					u, err := dec.binary(o, strconv.IntSize/8)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off242, err), "ID", codecTypeOf[int])
+					}
+					v.ID = int(codecSigned(u, strconv.IntSize/8))
+				} else {
+					{
+						off243 := dec.offset()
+						p, err := dec.value("ID", codecBatch_ID.tag, codecBatch_ID.format.Encoding == oxygen.PackedDecimal)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off243, err), "ID", codecTypeOf[int])
+						}
+						if len(p) != 0 {
+							r, err := codecBatch_ID.format.ParseInt(string(p), strconv.IntSize)
+							v.ID = int(r)
+							if err != nil {
+								return codecUnmarshalError(dec.typeError(off243, err), "ID", codecTypeOf[int])
+							}
+						} else if codecBatch_ID.def != nil {
+							v.ID = *codecBatch_ID.def.(*int)
+						}
+					}
+				}
+			}
+		case 1: // Limits
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "Limits", codecTypeOf[records.Limits])
+				}
+			}
+			sep = codecRemoveSeparator
+			if _, fn245 := codecRegistered[records.Limits](); fn245 != nil {
+				{
+					off246 := dec.offset()
+					p, err := dec.value("Limits", nil, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off246, err), "Limits", codecTypeOf[records.Limits])
+					}
+					if len(p) != 0 {
+						r, err := fn245("Limits", nil, p)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off246, err), "Limits", codecTypeOf[records.Limits])
+						}
+						v.Limits = r
+					}
+				}
+			} else {
+				n247 := len(dec.missing)
+				if err := dec.decodeNestedLimits(&v.Limits, codecRemoveWrapper); err != nil {
+					return codecUnmarshalError(err, "Limits", codecTypeOf[records.Limits])
+				}
+				dec.prefix(n247, "Limits")
+			}
+		case 2: // Items
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "Items", codecTypeOf[[]records.Item])
+				}
+			}
+			sep = codecRemoveSeparator
+			if _, fn251 := codecRegistered[[]records.Item](); fn251 != nil {
+				{
+					off252 := dec.offset()
+					p, err := dec.value("Items", nil, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off252, err), "Items", codecTypeOf[[]records.Item])
+					}
+					if len(p) != 0 {
+						r, err := fn251("Items", nil, p)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off252, err), "Items", codecTypeOf[[]records.Item])
+						}
+						v.Items = r
+					}
+				}
+			} else {
+				{
+					if err := dec.enter(); err != nil {
+						return codecUnmarshalError(dec.typeError(dec.offset(), err), "Items", codecTypeOf[[]records.Item])
+					}
+					if codecUnwrapList {
+						if err := dec.removePrefix(codecListOpener); err != nil {
+							return codecUnmarshalError(err, "Items", codecTypeOf[[]records.Item])
+						}
+					}
+					var z254 records.Item
+					n253 := 0
+					m255 := len(dec.missing)
+					for ; ; n253++ {
+						if dec.endOf(codecUnwrapList, codecListCloser) {
+							break
+						}
+						left256 := len(dec.data)
+						if n253 > 0 && codecRemoveElemSep {
+							if err := dec.removePrefix(codecElementSeparator); err != nil {
+								return codecUnmarshalError(err, "Items", codecTypeOf[[]records.Item])
+							}
+						}
+						if n253 < len(v.Items) {
+							v.Items[n253] = z254
+						} else {
+							v.Items = append(v.Items, z254)
+						}
+						if _, fn257 := codecRegistered[records.Item](); fn257 != nil {
+							{
+								off258 := dec.offset()
+								p, err := dec.value("Items", nil, false)
+								if err != nil {
+									return codecUnmarshalError(dec.typeError(off258, err), "Items", codecTypeOf[[]records.Item])
+								}
+								if len(p) != 0 {
+									r, err := fn257("Items", nil, p)
+									if err != nil {
+										return codecUnmarshalError(dec.typeError(off258, err), "Items", codecTypeOf[[]records.Item])
+									}
+									v.Items[n253] = r
+								}
+							}
+						} else {
+							n259 := len(dec.missing)
+							if err := dec.decodeNestedItem(&v.Items[n253], codecRemoveWrapper); err != nil {
+								return codecUnmarshalError(err, "Items", codecTypeOf[[]records.Item])
+							}
+							dec.prefix(n259, "["+strconv.Itoa(n253)+"]")
+						}
+						if len(dec.data) == left256 {
+							break
+						}
+					}
+					if codecUnwrapList {
+						if err := dec.removePrefix(codecListCloser); err != nil {
+							return codecUnmarshalError(err, "Items", codecTypeOf[[]records.Item])
+						}
+					}
+					dec.leave()
+					dec.prefix(m255, "Items")
+					if v.Items != nil {
+						v.Items = v.Items[:n253]
+					}
+				}
+			}
+		}
+	}
+	if unwrap {
+		if codecStrict && len(dec.data) != 0 && !bytes.HasPrefix(dec.data, codecStructCloser) {
+			return &oxygen.SyntaxError{Name: cfg.Name, Offset: dec.offset(), Err: oxygen.ErrTrailingData}
+		}
+		if i := bytes.Index(dec.data, codecStructCloser); i > 0 {
+			dec.data = dec.data[i:]
+		}
+		if err := dec.removePrefix(codecStructCloser); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (enc *codecEncoder) encodeNestedBatch(v *records.Batch, wrap bool) error {
+	if err := enc.enter(); err != nil {
+		return err
+	}
+	err := enc.encodeBatch(v, wrap)
+	enc.leave()
+	return err
+}
+
+func (dec *codecDecoder) decodeNestedBatch(v *records.Batch, unwrap bool) error {
+	if err := dec.enter(); err != nil {
+		return dec.typeError(dec.offset(), err)
+	}
+	err := dec.decodeBatch(v, unwrap)
+	dec.leave()
+	return err
+}
+
+func codecSiblingAddress(v *records.Address, name string) any {
+	switch name {
+	case "Street":
+		return v.Street
+	case "Country":
+		return v.Country
+	case "Floor":
+		return v.Floor
+	}
+	return nil
+}
+
+func codecRemainingAddress(v *records.Address, from int, fn func(string, *codecTag, codecFields) bool) bool {
+	for i := from; i < 3; i++ {
+		switch i {
+		case 0: // Street
+			if ok, err := codecPresent(&codecAddress_Street, v, codecSiblingAddress); (ok || err != nil) && !fn("Street", &codecAddress_Street, nil) {
+				return false
+			}
+		case 1: // Country
+			if ok, err := codecPresent(&codecAddress_Country, v, codecSiblingAddress); (ok || err != nil) && !fn("Country", &codecAddress_Country, nil) {
+				return false
+			}
+		case 2: // Floor
+			if ok, err := codecPresent(&codecAddress_Floor, v, codecSiblingAddress); (ok || err != nil) && !fn("Floor", &codecAddress_Floor, nil) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (enc *codecEncoder) encodeAddress(v *records.Address, wrap bool) error {
+	sep := false
+	if wrap {
+		enc.Write(codecStructOpener)
+	}
+	// Street
+	d260 := v.Street
+	if codecAddress_Street.encodeDef && len(d260) == 0 {
+		d260 = *codecAddress_Street.def.(*string)
+	}
+	if ok, err := codecPresent(&codecAddress_Street, v, codecSiblingAddress); err != nil {
+		return &oxygen.TagError{Name: cfg.Name, Tag: "6, ,l", Field: "Street", Err: err}
+	} else if ok && !(codecAddress_Street.omit && len(d260) == 0) {
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if codecAddress_Street.err != nil {
+			return &oxygen.TagError{Name: cfg.Name, Tag: "6, ,l", Field: "Street", Err: codecAddress_Street.err}
+		}
+		if fn261, _ := codecRegistered[string](); fn261 != nil {
+			p, err := fn261("Street", codecAddress_Street.tag, d260)
+			if err != nil {
+				return codecMarshalError(err, "Street", codecTypeOf[string])
+			}
+			if err := enc.value("Street", codecAddress_Street.tag, p, false); err != nil {
+				return codecMarshalError(err, "Street", codecTypeOf[string])
+			}
+		} else {
+			if err := enc.value("Street", codecAddress_Street.tag, append(enc.scratch[:0], string(d260)...), false); err != nil {
+				return codecMarshalError(err, "Street", codecTypeOf[string])
+			}
+		}
+	}
+	// Country
+	d265 := v.Country
+	if codecAddress_Country.encodeDef && len(d265) == 0 {
+		d265 = *codecAddress_Country.def.(*string)
+	}
+	if ok, err := codecPresent(&codecAddress_Country, v, codecSiblingAddress); err != nil {
+		return &oxygen.TagError{Name: cfg.Name, Tag: "2, ,l,,,,*US", Field: "Country", Err: err}
+	} else if ok && !(codecAddress_Country.omit && len(d265) == 0) {
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if codecAddress_Country.err != nil {
+			return &oxygen.TagError{Name: cfg.Name, Tag: "2, ,l,,,,*US", Field: "Country", Err: codecAddress_Country.err}
+		}
+		if fn266, _ := codecRegistered[string](); fn266 != nil {
+			p, err := fn266("Country", codecAddress_Country.tag, d265)
+			if err != nil {
+				return codecMarshalError(err, "Country", codecTypeOf[string])
+			}
+			if err := enc.value("Country", codecAddress_Country.tag, p, false); err != nil {
+				return codecMarshalError(err, "Country", codecTypeOf[string])
+			}
+		} else {
+			if err := enc.value("Country", codecAddress_Country.tag, append(enc.scratch[:0], string(d265)...), false); err != nil {
+				return codecMarshalError(err, "Country", codecTypeOf[string])
+			}
+		}
+	}
+	// Floor
+	d270 := v.Floor
+	if codecAddress_Floor.encodeDef && d270 == nil {
+		d270 = codecAddress_Floor.def.(*uint8)
+	}
+	if ok, err := codecPresent(&codecAddress_Floor, v, codecSiblingAddress); err != nil {
+		return &oxygen.TagError{Name: cfg.Name, Tag: "2,0,r,,,,1", Field: "Floor", Err: err}
+	} else if ok && !(codecAddress_Floor.omit && d270 == nil) {
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if codecAddress_Floor.err != nil {
+			return &oxygen.TagError{Name: cfg.Name, Tag: "2,0,r,,,,1", Field: "Floor", Err: codecAddress_Floor.err}
+		}
+		if fn271, _ := codecRegistered[*uint8](); fn271 != nil {
+			p, err := fn271("Floor", codecAddress_Floor.tag, d270)
+			if err != nil {
+				return codecMarshalError(err, "Floor", codecTypeOf[*uint8])
+			}
+			if err := enc.value("Floor", codecAddress_Floor.tag, p, false); err != nil {
+				return codecMarshalError(err, "Floor", codecTypeOf[*uint8])
+			}
+		} else {
+			p272 := d270
+			if p272 == nil {
+				p272 = new(uint8)
+			}
+			if fn273, _ := codecRegistered[uint8](); fn273 != nil {
+				p, err := fn273("Floor", codecAddress_Floor.tag, (*p272))
+				if err != nil {
+					return codecMarshalError(err, "Floor", codecTypeOf[*uint8])
+				}
+				if err := enc.value("Floor", codecAddress_Floor.tag, p, false); err != nil {
+					return codecMarshalError(err, "Floor", codecTypeOf[*uint8])
+				}
+			} else {
+				if o := codecAddress_Floor.order; o != nil {
+					enc.Write(oxygen.AppendBinary(enc.scratch[:0], o, uint64((*p272)), 1))
+				} else {
+					if err := codecAddress_Floor.format.CheckUint(uint64((*p272))); err != nil {
+						return codecMarshalError(err, "Floor", codecTypeOf[*uint8])
+					}
+					if err := enc.value("Floor", codecAddress_Floor.tag, codecAddress_Floor.format.AppendUint(enc.scratch[:0], uint64((*p272))), codecAddress_Floor.format.Encoding == oxygen.PackedDecimal); err != nil {
+						return codecMarshalError(err, "Floor", codecTypeOf[*uint8])
+					}
+				}
+			}
+		}
+	}
+	if wrap {
+		enc.Write(codecStructCloser)
+	}
+	return nil
+}
+
+func (dec *codecDecoder) decodeAddress(v *records.Address, unwrap bool) error {
+	if unwrap {
+		if err := dec.removePrefix(codecStructOpener); err != nil {
+			return err
+		}
+	}
+	sep := false
+	for i := 0; i < 3; i++ {
+		if len(dec.data) == 0 || unwrap && bytes.HasPrefix(dec.data, codecStructCloser) {
+			remaining := func(fn func(string, *codecTag, codecFields) bool) bool {
+				return codecRemainingAddress(v, i, fn)
+			}
+			if codecStrict {
+				if name := codecTruncated(remaining); name != "" {
+					return &oxygen.SyntaxError{Name: cfg.Name, Field: name, Offset: dec.offset(), Err: oxygen.ErrTruncated}
+				}
+			}
+			remaining(dec.required)
+			break
+		}
+		switch i {
+		case 0: // Street
+			if ok, err := codecPresent(&codecAddress_Street, v, codecSiblingAddress); err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "6, ,l", Field: "Street", Err: err}
+			} else if !ok {
+				continue
+			}
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "Street", codecTypeOf[string])
+				}
+			}
+			sep = codecRemoveSeparator
+			if codecAddress_Street.err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "6, ,l", Field: "Street", Err: codecAddress_Street.err}
+			}
+			if _, fn262 := codecRegistered[string](); fn262 != nil {
+				{
+					off263 := dec.offset()
+					p, err := dec.value("Street", codecAddress_Street.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off263, err), "Street", codecTypeOf[string])
+					}
+					if len(p) != 0 {
+						r, err := fn262("Street", codecAddress_Street.tag, p)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off263, err), "Street", codecTypeOf[string])
+						}
+						v.Street = r
+					} else if codecAddress_Street.def != nil {
+						v.Street = *codecAddress_Street.def.(*string)
+					}
+				}
+			} else {
+				{
+					off264 := dec.offset()
+					p, err := dec.value("Street", codecAddress_Street.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off264, err), "Street", codecTypeOf[string])
+					}
+					if len(p) != 0 {
+						v.Street = string(p)
+					} else if codecAddress_Street.def != nil {
+						v.Street = *codecAddress_Street.def.(*string)
+					}
+				}
+			}
+		case 1: // Country
+			if ok, err := codecPresent(&codecAddress_Country, v, codecSiblingAddress); err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "2, ,l,,,,*US", Field: "Country", Err: err}
+			} else if !ok {
+				continue
+			}
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "Country", codecTypeOf[string])
+				}
+			}
+			sep = codecRemoveSeparator
+			if codecAddress_Country.err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "2, ,l,,,,*US", Field: "Country", Err: codecAddress_Country.err}
+			}
+			if _, fn267 := codecRegistered[string](); fn267 != nil {
+				{
+					off268 := dec.offset()
+					p, err := dec.value("Country", codecAddress_Country.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off268, err), "Country", codecTypeOf[string])
+					}
+					if len(p) != 0 {
+						r, err := fn267("Country", codecAddress_Country.tag, p)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off268, err), "Country", codecTypeOf[string])
+						}
+						v.Country = r
+					} else if codecAddress_Country.def != nil {
+						v.Country = *codecAddress_Country.def.(*string)
+					}
+				}
+			} else {
+				{
+					off269 := dec.offset()
+					p, err := dec.value("Country", codecAddress_Country.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off269, err), "Country", codecTypeOf[string])
+					}
+					if len(p) != 0 {
+						v.Country = string(p)
+					} else if codecAddress_Country.def != nil {
+						v.Country = *codecAddress_Country.def.(*string)
+					}
+				}
+			}
+		case 2: // Floor
+			if ok, err := codecPresent(&codecAddress_Floor, v, codecSiblingAddress); err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "2,0,r,,,,1", Field: "Floor", Err: err}
+			} else if !ok {
+				continue
+			}
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "Floor", codecTypeOf[*uint8])
+				}
+			}
+			sep = codecRemoveSeparator
+			if codecAddress_Floor.err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "2,0,r,,,,1", Field: "Floor", Err: codecAddress_Floor.err}
+			}
+			if _, fn274 := codecRegistered[*uint8](); fn274 != nil {
+				{
+					off275 := dec.offset()
+					p, err := dec.value("Floor", codecAddress_Floor.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off275, err), "Floor", codecTypeOf[*uint8])
+					}
+					if len(p) != 0 {
+						r, err := fn274("Floor", codecAddress_Floor.tag, p)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off275, err), "Floor", codecTypeOf[*uint8])
+						}
+						v.Floor = r
+					} else if codecAddress_Floor.def != nil {
+						d276 := *codecAddress_Floor.def.(*uint8)
+						v.Floor = &d276
+					}
+				}
+			} else {
+				{
+					p277 := v.Floor
+					if p277 == nil {
+						p277 = new(uint8)
+					}
+					if _, fn278 := codecRegistered[uint8](); fn278 != nil {
+						{
+							off279 := dec.offset()
+							p, err := dec.value("Floor", codecAddress_Floor.tag, false)
+							if err != nil {
+								return codecUnmarshalError(dec.typeError(off279, err), "Floor", codecTypeOf[*uint8])
+							}
+							if len(p) != 0 {
+								r, err := fn278("Floor", codecAddress_Floor.tag, p)
+								if err != nil {
+									return codecUnmarshalError(dec.typeError(off279, err), "Floor", codecTypeOf[*uint8])
+								}
+								(*p277) = r
+							} else if codecAddress_Floor.def != nil {
+								d280 := *codecAddress_Floor.def.(*uint8)
+								v.Floor = &d280
+							}
+						}
+					} else {
+						if o := codecAddress_Floor.order; o != nil {
+							off281 := dec.offset()
+							u, err := dec.binary(o, 1)
+							if err != nil {
+								return codecUnmarshalError(dec.typeError(off281, err), "Floor", codecTypeOf[*uint8])
+							}
+							(*p277) = uint8(u)
+						} else {
+							{
+								off282 := dec.offset()
+								p, err := dec.value("Floor", codecAddress_Floor.tag, codecAddress_Floor.format.Encoding == oxygen.PackedDecimal)
+								if err != nil {
+									return codecUnmarshalError(dec.typeError(off282, err), "Floor", codecTypeOf[*uint8])
+								}
+								if len(p) != 0 {
+									r, err := codecAddress_Floor.format.ParseUint(string(p), 8)
+									(*p277) = uint8(r)
+									if err != nil {
+										return codecUnmarshalError(dec.typeError(off282, err), "Floor", codecTypeOf[*uint8])
+									}
+								} else if codecAddress_Floor.def != nil {
+									d283 := *codecAddress_Floor.def.(*uint8)
+									v.Floor = &d283
+								}
+							}
+						}
+					}
+					if v.Floor == nil && !((*p277) == 0) {
+						v.Floor = p277
+					}
+				}
+			}
+		}
+	}
+	if unwrap {
+		if codecStrict && len(dec.data) != 0 && !bytes.HasPrefix(dec.data, codecStructCloser) {
+			return &oxygen.SyntaxError{Name: cfg.Name, Offset: dec.offset(), Err: oxygen.ErrTrailingData}
+		}
+		if i := bytes.Index(dec.data, codecStructCloser); i > 0 {
+			dec.data = dec.data[i:]
+		}
+		if err := dec.removePrefix(codecStructCloser); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (enc *codecEncoder) encodeNestedAddress(v *records.Address, wrap bool) error {
+	if err := enc.enter(); err != nil {
+		return err
+	}
+	err := enc.encodeAddress(v, wrap)
+	enc.leave()
+	return err
+}
+
+func (dec *codecDecoder) decodeNestedAddress(v *records.Address, unwrap bool) error {
+	if err := dec.enter(); err != nil {
+		return dec.typeError(dec.offset(), err)
+	}
+	err := dec.decodeAddress(v, unwrap)
+	dec.leave()
+	return err
+}
+
+func codecSiblingTicket(v *records.Ticket, name string) any {
+	switch name {
+	case "Code":
+		return v.Code
+	case "Legs":
+		return v.Legs
+	}
+	return nil
+}
+
+func codecRemainingTicket(v *records.Ticket, from int, fn func(string, *codecTag, codecFields) bool) bool {
+	for i := from; i < 2; i++ {
+		switch i {
+		case 0: // Code
+			if ok, err := codecPresent(&codecTicket_Code, v, codecSiblingTicket); (ok || err != nil) && !fn("Code", &codecTicket_Code, nil) {
+				return false
+			}
+		case 1: // Legs
+			if !fn("Legs", nil, nil) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (enc *codecEncoder) encodeTicket(v *records.Ticket, wrap bool) error {
+	sep := false
+	if wrap {
+		enc.Write(codecStructOpener)
+	}
+	// Code
+	d284 := v.Code
+	if codecTicket_Code.encodeDef && len(d284) == 0 {
+		d284 = *codecTicket_Code.def.(*records.Code)
+	}
+	if ok, err := codecPresent(&codecTicket_Code, v, codecSiblingTicket); err != nil {
+		return &oxygen.TagError{Name: cfg.Name, Tag: "3,_,l", Field: "Code", Err: err}
+	} else if ok && !(codecTicket_Code.omit && len(d284) == 0) {
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if codecTicket_Code.err != nil {
+			return &oxygen.TagError{Name: cfg.Name, Tag: "3,_,l", Field: "Code", Err: codecTicket_Code.err}
+		}
+		if fn285, _ := codecRegistered[records.Code](); fn285 != nil {
+			p, err := fn285("Code", codecTicket_Code.tag, d284)
+			if err != nil {
+				return codecMarshalError(err, "Code", codecTypeOf[records.Code])
+			}
+			if err := enc.value("Code", codecTicket_Code.tag, p, false); err != nil {
+				return codecMarshalError(err, "Code", codecTypeOf[records.Code])
+			}
+		} else {
+			if err := enc.value("Code", codecTicket_Code.tag, append(enc.scratch[:0], string(d284)...), false); err != nil {
+				return codecMarshalError(err, "Code", codecTypeOf[records.Code])
+			}
+		}
+	}
+	// Legs
+	{
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if fn289, _ := codecRegistered[[]records.Leg](); fn289 != nil {
+			p, err := fn289("Legs", nil, v.Legs)
+			if err != nil {
+				return codecMarshalError(err, "Legs", codecTypeOf[[]records.Leg])
+			}
+			if err := enc.value("Legs", nil, p, false); err != nil {
+				return codecMarshalError(err, "Legs", codecTypeOf[[]records.Leg])
+			}
+		} else {
+			if err := enc.enter(); err != nil {
+				return codecMarshalError(err, "Legs", codecTypeOf[[]records.Leg])
+			}
+			if codecWrapList {
+				enc.Write(codecListOpener)
+			}
+			for i290 := range v.Legs {
+				if i290 > 0 && codecSeparateElems {
+					enc.Write(codecElementSeparator)
+				}
+				if fn291, _ := codecRegistered[records.Leg](); fn291 != nil {
+					p, err := fn291("Legs", nil, v.Legs[i290])
+					if err != nil {
+						return codecMarshalError(err, "Legs", codecTypeOf[[]records.Leg])
+					}
+					if err := enc.value("Legs", nil, p, false); err != nil {
+						return codecMarshalError(err, "Legs", codecTypeOf[[]records.Leg])
+					}
+				} else {
+					if err := enc.encodeNestedLeg(&v.Legs[i290], codecWrap); err != nil {
+						return codecMarshalError(err, "Legs", codecTypeOf[[]records.Leg])
+					}
+				}
+			}
+			if codecWrapList {
+				enc.Write(codecListCloser)
+			}
+			enc.leave()
+		}
+	}
+	if wrap {
+		enc.Write(codecStructCloser)
+	}
+	return nil
+}
+
+func (dec *codecDecoder) decodeTicket(v *records.Ticket, unwrap bool) error {
+	if unwrap {
+		if err := dec.removePrefix(codecStructOpener); err != nil {
+			return err
+		}
+	}
+	sep := false
+	for i := 0; i < 2; i++ {
+		if len(dec.data) == 0 || unwrap && bytes.HasPrefix(dec.data, codecStructCloser) {
+			remaining := func(fn func(string, *codecTag, codecFields) bool) bool {
+				return codecRemainingTicket(v, i, fn)
+			}
+			if codecStrict {
+				if name := codecTruncated(remaining); name != "" {
+					return &oxygen.SyntaxError{Name: cfg.Name, Field: name, Offset: dec.offset(), Err: oxygen.ErrTruncated}
+				}
+			}
+			remaining(dec.required)
+			break
+		}
+		switch i {
+		case 0: // Code
+			if ok, err := codecPresent(&codecTicket_Code, v, codecSiblingTicket); err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "3,_,l", Field: "Code", Err: err}
+			} else if !ok {
+				continue
+			}
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "Code", codecTypeOf[records.Code])
+				}
+			}
+			sep = codecRemoveSeparator
+			if codecTicket_Code.err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "3,_,l", Field: "Code", Err: codecTicket_Code.err}
+			}
+			if _, fn286 := codecRegistered[records.Code](); fn286 != nil {
+				{
+					off287 := dec.offset()
+					p, err := dec.value("Code", codecTicket_Code.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off287, err), "Code", codecTypeOf[records.Code])
+					}
+					if len(p) != 0 {
+						r, err := fn286("Code", codecTicket_Code.tag, p)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off287, err), "Code", codecTypeOf[records.Code])
+						}
+						v.Code = r
+					} else if codecTicket_Code.def != nil {
+						v.Code = *codecTicket_Code.def.(*records.Code)
+					}
+				}
+			} else {
+				{
+					off288 := dec.offset()
+					p, err := dec.value("Code", codecTicket_Code.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off288, err), "Code", codecTypeOf[records.Code])
+					}
+					if len(p) != 0 {
+						v.Code = records.Code(p)
+					} else if codecTicket_Code.def != nil {
+						v.Code = *codecTicket_Code.def.(*records.Code)
+					}
+				}
+			}
+		case 1: // Legs
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "Legs", codecTypeOf[[]records.Leg])
+				}
+			}
+			sep = codecRemoveSeparator
+			if _, fn292 := codecRegistered[[]records.Leg](); fn292 != nil {
+				{
+					off293 := dec.offset()
+					p, err := dec.value("Legs", nil, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off293, err), "Legs", codecTypeOf[[]records.Leg])
+					}
+					if len(p) != 0 {
+						r, err := fn292("Legs", nil, p)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off293, err), "Legs", codecTypeOf[[]records.Leg])
+						}
+						v.Legs = r
+					}
+				}
+			} else {
+				{
+					if err := dec.enter(); err != nil {
+						return codecUnmarshalError(dec.typeError(dec.offset(), err), "Legs", codecTypeOf[[]records.Leg])
+					}
+					if codecUnwrapList {
+						if err := dec.removePrefix(codecListOpener); err != nil {
+							return codecUnmarshalError(err, "Legs", codecTypeOf[[]records.Leg])
+						}
+					}
+					var z295 records.Leg
+					n294 := 0
+					m296 := len(dec.missing)
+					for ; ; n294++ {
+						if dec.endOf(codecUnwrapList, codecListCloser) {
+							break
+						}
+						left297 := len(dec.data)
+						if n294 > 0 && codecRemoveElemSep {
+							if err := dec.removePrefix(codecElementSeparator); err != nil {
+								return codecUnmarshalError(err, "Legs", codecTypeOf[[]records.Leg])
+							}
+						}
+						if n294 < len(v.Legs) {
+							v.Legs[n294] = z295
+						} else {
+							v.Legs = append(v.Legs, z295)
+						}
+						if _, fn298 := codecRegistered[records.Leg](); fn298 != nil {
+							{
+								off299 := dec.offset()
+								p, err := dec.value("Legs", nil, false)
+								if err != nil {
+									return codecUnmarshalError(dec.typeError(off299, err), "Legs", codecTypeOf[[]records.Leg])
+								}
+								if len(p) != 0 {
+									r, err := fn298("Legs", nil, p)
+									if err != nil {
+										return codecUnmarshalError(dec.typeError(off299, err), "Legs", codecTypeOf[[]records.Leg])
+									}
+									v.Legs[n294] = r
+								}
+							}
+						} else {
+							n300 := len(dec.missing)
+							if err := dec.decodeNestedLeg(&v.Legs[n294], codecRemoveWrapper); err != nil {
+								return codecUnmarshalError(err, "Legs", codecTypeOf[[]records.Leg])
+							}
+							dec.prefix(n300, "["+strconv.Itoa(n294)+"]")
+						}
+						if len(dec.data) == left297 {
+							break
+						}
+					}
+					if codecUnwrapList {
+						if err := dec.removePrefix(codecListCloser); err != nil {
+							return codecUnmarshalError(err, "Legs", codecTypeOf[[]records.Leg])
+						}
+					}
+					dec.leave()
+					dec.prefix(m296, "Legs")
+					if v.Legs != nil {
+						v.Legs = v.Legs[:n294]
+					}
+				}
+			}
+		}
+	}
+	if unwrap {
+		if codecStrict && len(dec.data) != 0 && !bytes.HasPrefix(dec.data, codecStructCloser) {
+			return &oxygen.SyntaxError{Name: cfg.Name, Offset: dec.offset(), Err: oxygen.ErrTrailingData}
+		}
+		if i := bytes.Index(dec.data, codecStructCloser); i > 0 {
+			dec.data = dec.data[i:]
+		}
+		if err := dec.removePrefix(codecStructCloser); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (enc *codecEncoder) encodeNestedTicket(v *records.Ticket, wrap bool) error {
+	c := *v
+	v = &c
+	if err := c.BeforeMarshal(); err != nil {
+		return &oxygen.MarshalTypeError{Name: cfg.Name, Type: codecTypeOf[records.Ticket](), Err: fmt.Errorf("BeforeMarshal: %w", err)}
+	}
+	if err := enc.enter(); err != nil {
+		return err
+	}
+	err := enc.encodeTicket(v, wrap)
+	enc.leave()
+	return err
+}
+
+func (dec *codecDecoder) decodeNestedTicket(v *records.Ticket, unwrap bool) error {
+	off := dec.offset()
+	if err := dec.enter(); err != nil {
+		return dec.typeError(dec.offset(), err)
+	}
+	err := dec.decodeTicket(v, unwrap)
+	dec.leave()
+	if err != nil {
+		return err
+	}
+	if err := v.AfterUnmarshal(); err != nil {
+		return &oxygen.UnmarshalTypeError{Name: cfg.Name, Type: codecTypeOf[records.Ticket](), Offset: off, Err: fmt.Errorf("AfterUnmarshal: %w", err)}
+	}
+	return nil
+}
+
+func codecSiblingTimer(v *records.Timer, name string) any {
+	switch name {
+	case "Took":
+		return v.Took
+	case "Best":
+		return v.Best
+	case "Laps":
+		return v.Laps
+	case "Next":
+		return v.Next
+	}
+	return nil
+}
+
+func codecRemainingTimer(v *records.Timer, from int, fn func(string, *codecTag, codecFields) bool) bool {
+	for i := from; i < 4; i++ {
+		switch i {
+		case 0: // Took
+			if ok, err := codecPresent(&codecTimer_Took, v, codecSiblingTimer); (ok || err != nil) && !fn("Took", &codecTimer_Took, nil) {
+				return false
+			}
+		case 1: // Best
+			if ok, err := codecPresent(&codecTimer_Best, v, codecSiblingTimer); (ok || err != nil) && !fn("Best", &codecTimer_Best, nil) {
+				return false
+			}
+		case 2: // Laps
+			if ok, err := codecPresent(&codecTimer_Laps, v, codecSiblingTimer); (ok || err != nil) && !fn("Laps", &codecTimer_Laps, nil) {
+				return false
+			}
+		case 3: // Next
+			if ok, err := codecPresent(&codecTimer_Next, v, codecSiblingTimer); (ok || err != nil) && !fn("Next", &codecTimer_Next, nil) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (enc *codecEncoder) encodeTimer(v *records.Timer, wrap bool) error {
+	sep := false
+	if wrap {
+		enc.Write(codecStructOpener)
+	}
+	// Took
+	c301 := v.Took
+	switch "Took" {
+	case codecTimer_Laps.count:
+		n, err := codecLength[time.Duration](len(v.Laps))
+		if err != nil {
+			return codecMarshalError(err, "Took", codecTypeOf[time.Duration])
+		}
+		c301 = n
+	}
+	if codecTimer_Took.encodeDef && c301 == 0 {
+		c301 = *codecTimer_Took.def.(*time.Duration)
+	}
+	if ok, err := codecPresent(&codecTimer_Took, v, codecSiblingTimer); err != nil {
+		return &oxygen.TagError{Name: cfg.Name, Tag: "8, ,l", Field: "Took", Err: err}
+	} else if ok && !(codecTimer_Took.omit && c301 == 0) {
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if codecTimer_Took.err != nil {
+			return &oxygen.TagError{Name: cfg.Name, Tag: "8, ,l", Field: "Took", Err: codecTimer_Took.err}
+		}
+		if fn302, _ := codecRegistered[time.Duration](); fn302 != nil {
+			p, err := fn302("Took", codecTimer_Took.tag, c301)
+			if err != nil {
+				return codecMarshalError(err, "Took", codecTypeOf[time.Duration])
+			}
+			if err := enc.value("Took", codecTimer_Took.tag, p, false); err != nil {
+				return codecMarshalError(err, "Took", codecTypeOf[time.Duration])
+			}
+		} else {
+			if o := codecTimer_Took.order; o != nil {
+				enc.Write(oxygen.AppendBinary(enc.scratch[:0], o, uint64(c301), 8))
+			} else {
+				if err := codecTimer_Took.format.CheckInt(int64(c301)); err != nil {
+					return codecMarshalError(err, "Took", codecTypeOf[time.Duration])
+				}
+				if err := enc.value("Took", codecTimer_Took.tag, codecTimer_Took.format.AppendInt(enc.scratch[:0], int64(c301)), codecTimer_Took.format.Encoding == oxygen.PackedDecimal); err != nil {
+					return codecMarshalError(err, "Took", codecTypeOf[time.Duration])
+				}
+			}
+		}
+	}
+	// Best
+	d307 := v.Best
+	if codecTimer_Best.encodeDef && d307 == nil {
+		d307 = codecTimer_Best.def.(*time.Duration)
+	}
+	if ok, err := codecPresent(&codecTimer_Best, v, codecSiblingTimer); err != nil {
+		return &oxygen.TagError{Name: cfg.Name, Tag: "5, ,l", Field: "Best", Err: err}
+	} else if ok && !(codecTimer_Best.omit && d307 == nil) {
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if codecTimer_Best.err != nil {
+			return &oxygen.TagError{Name: cfg.Name, Tag: "5, ,l", Field: "Best", Err: codecTimer_Best.err}
+		}
+		if fn308, _ := codecRegistered[*time.Duration](); fn308 != nil {
+			p, err := fn308("Best", codecTimer_Best.tag, d307)
+			if err != nil {
+				return codecMarshalError(err, "Best", codecTypeOf[*time.Duration])
+			}
+			if err := enc.value("Best", codecTimer_Best.tag, p, false); err != nil {
+				return codecMarshalError(err, "Best", codecTypeOf[*time.Duration])
+			}
+		} else {
+			p309 := d307
+			if p309 == nil {
+				p309 = new(time.Duration)
+			}
+			if fn310, _ := codecRegistered[time.Duration](); fn310 != nil {
+				p, err := fn310("Best", codecTimer_Best.tag, (*p309))
+				if err != nil {
+					return codecMarshalError(err, "Best", codecTypeOf[*time.Duration])
+				}
+				if err := enc.value("Best", codecTimer_Best.tag, p, false); err != nil {
+					return codecMarshalError(err, "Best", codecTypeOf[*time.Duration])
+				}
+			} else {
+				if o := codecTimer_Best.order; o != nil {
+					enc.Write(oxygen.AppendBinary(enc.scratch[:0], o, uint64((*p309)), 8))
+				} else {
+					if err := codecTimer_Best.format.CheckInt(int64((*p309))); err != nil {
+						return codecMarshalError(err, "Best", codecTypeOf[*time.Duration])
+					}
+					if err := enc.value("Best", codecTimer_Best.tag, codecTimer_Best.format.AppendInt(enc.scratch[:0], int64((*p309))), codecTimer_Best.format.Encoding == oxygen.PackedDecimal); err != nil {
+						return codecMarshalError(err, "Best", codecTypeOf[*time.Duration])
+					}
+				}
+			}
+		}
+	}
+	// Laps
+	switch codecTimer_Laps.count {
+	case "Took":
+		if len(v.Laps) != int(c301) {
+			return codecMarshalError(fmt.Errorf("length %d of the slice differs from the count %d", len(v.Laps), int(c301)), "Laps", codecTypeOf[[]time.Duration])
+		}
+	}
+	if ok, err := codecPresent(&codecTimer_Laps, v, codecSiblingTimer); err != nil {
+		return &oxygen.TagError{Name: cfg.Name, Tag: "4, ,l", Field: "Laps", Err: err}
+	} else if ok && !(codecTimer_Laps.omit && len(v.Laps) == 0) {
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if codecTimer_Laps.err != nil {
+			return &oxygen.TagError{Name: cfg.Name, Tag: "4, ,l", Field: "Laps", Err: codecTimer_Laps.err}
+		}
+		if fn321, _ := codecRegistered[[]time.Duration](); fn321 != nil {
+			p, err := fn321("Laps", codecTimer_Laps.tag, v.Laps)
+			if err != nil {
+				return codecMarshalError(err, "Laps", codecTypeOf[[]time.Duration])
+			}
+			if err := enc.value("Laps", codecTimer_Laps.tag, p, false); err != nil {
+				return codecMarshalError(err, "Laps", codecTypeOf[[]time.Duration])
+			}
+		} else {
+			if err := enc.enter(); err != nil {
+				return codecMarshalError(err, "Laps", codecTypeOf[[]time.Duration])
+			}
+			if codecWrapList {
+				enc.Write(codecListOpener)
+			}
+			for i322 := range v.Laps {
+				if i322 > 0 && codecSeparateElems {
+					enc.Write(codecElementSeparator)
+				}
+				if fn323, _ := codecRegistered[time.Duration](); fn323 != nil {
+					p, err := fn323("Laps", codecTimer_Laps.tag, v.Laps[i322])
+					if err != nil {
+						return codecMarshalError(err, "Laps", codecTypeOf[[]time.Duration])
+					}
+					if err := enc.value("Laps", codecTimer_Laps.tag, p, false); err != nil {
+						return codecMarshalError(err, "Laps", codecTypeOf[[]time.Duration])
+					}
+				} else {
+					if o := codecTimer_Laps.order; o != nil {
+						enc.Write(oxygen.AppendBinary(enc.scratch[:0], o, uint64(v.Laps[i322]), 8))
+					} else {
+						if err := codecTimer_Laps.format.CheckInt(int64(v.Laps[i322])); err != nil {
+							return codecMarshalError(err, "Laps", codecTypeOf[[]time.Duration])
+						}
+						if err := enc.value("Laps", codecTimer_Laps.tag, codecTimer_Laps.format.AppendInt(enc.scratch[:0], int64(v.Laps[i322])), codecTimer_Laps.format.Encoding == oxygen.PackedDecimal); err != nil {
+							return codecMarshalError(err, "Laps", codecTypeOf[[]time.Duration])
+						}
+					}
+				}
+			}
+			if codecWrapList {
+				enc.Write(codecListCloser)
+			}
+			enc.leave()
+		}
+	}
+	// Next
+	if ok, err := codecPresent(&codecTimer_Next, v, codecSiblingTimer); err != nil {
+		return &oxygen.TagError{Name: cfg.Name, Tag: "3, ,l", Field: "Next", Err: err}
+	} else if ok && !(codecTimer_Next.omit && v.Next == nil) {
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if codecTimer_Next.err != nil {
+			return &oxygen.TagError{Name: cfg.Name, Tag: "3, ,l", Field: "Next", Err: codecTimer_Next.err}
+		}
+		if fn341, _ := codecRegistered[*[]time.Duration](); fn341 != nil {
+			p, err := fn341("Next", codecTimer_Next.tag, v.Next)
+			if err != nil {
+				return codecMarshalError(err, "Next", codecTypeOf[*[]time.Duration])
+			}
+			if err := enc.value("Next", codecTimer_Next.tag, p, false); err != nil {
+				return codecMarshalError(err, "Next", codecTypeOf[*[]time.Duration])
+			}
+		} else {
+			p342 := v.Next
+			if p342 == nil {
+				p342 = new([]time.Duration)
+			}
+			if fn343, _ := codecRegistered[[]time.Duration](); fn343 != nil {
+				p, err := fn343("Next", codecTimer_Next.tag, (*p342))
+				if err != nil {
+					return codecMarshalError(err, "Next", codecTypeOf[*[]time.Duration])
+				}
+				if err := enc.value("Next", codecTimer_Next.tag, p, false); err != nil {
+					return codecMarshalError(err, "Next", codecTypeOf[*[]time.Duration])
+				}
+			} else {
+				if err := enc.enter(); err != nil {
+					return codecMarshalError(err, "Next", codecTypeOf[*[]time.Duration])
+				}
+				if codecWrapList {
+					enc.Write(codecListOpener)
+				}
+				for i344 := range *p342 {
+					if i344 > 0 && codecSeparateElems {
+						enc.Write(codecElementSeparator)
+					}
+					if fn345, _ := codecRegistered[time.Duration](); fn345 != nil {
+						p, err := fn345("Next", codecTimer_Next.tag, (*p342)[i344])
+						if err != nil {
+							return codecMarshalError(err, "Next", codecTypeOf[*[]time.Duration])
+						}
+						if err := enc.value("Next", codecTimer_Next.tag, p, false); err != nil {
+							return codecMarshalError(err, "Next", codecTypeOf[*[]time.Duration])
+						}
+					} else {
+						if o := codecTimer_Next.order; o != nil {
+							enc.Write(oxygen.AppendBinary(enc.scratch[:0], o, uint64((*p342)[i344]), 8))
+						} else {
+							if err := codecTimer_Next.format.CheckInt(int64((*p342)[i344])); err != nil {
+								return codecMarshalError(err, "Next", codecTypeOf[*[]time.Duration])
+							}
+							if err := enc.value("Next", codecTimer_Next.tag, codecTimer_Next.format.AppendInt(enc.scratch[:0], int64((*p342)[i344])), codecTimer_Next.format.Encoding == oxygen.PackedDecimal); err != nil {
+								return codecMarshalError(err, "Next", codecTypeOf[*[]time.Duration])
+							}
+						}
+					}
+				}
+				if codecWrapList {
+					enc.Write(codecListCloser)
+				}
+				enc.leave()
+			}
+		}
+	}
+	if wrap {
+		enc.Write(codecStructCloser)
+	}
+	return nil
+}
+
+func (dec *codecDecoder) decodeTimer(v *records.Timer, unwrap bool) error {
+	if unwrap {
+		if err := dec.removePrefix(codecStructOpener); err != nil {
+			return err
+		}
+	}
+	sep := false
+	for i := 0; i < 4; i++ {
+		if len(dec.data) == 0 || unwrap && bytes.HasPrefix(dec.data, codecStructCloser) {
+			remaining := func(fn func(string, *codecTag, codecFields) bool) bool {
+				return codecRemainingTimer(v, i, fn)
+			}
+			if codecStrict {
+				if name := codecTruncated(remaining); name != "" {
+					return &oxygen.SyntaxError{Name: cfg.Name, Field: name, Offset: dec.offset(), Err: oxygen.ErrTruncated}
+				}
+			}
+			remaining(dec.required)
+			break
+		}
+		switch i {
+		case 0: // Took
+			if ok, err := codecPresent(&codecTimer_Took, v, codecSiblingTimer); err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "8, ,l", Field: "Took", Err: err}
+			} else if !ok {
+				continue
+			}
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "Took", codecTypeOf[time.Duration])
+				}
+			}
+			sep = codecRemoveSeparator
+			if codecTimer_Took.err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "8, ,l", Field: "Took", Err: codecTimer_Took.err}
+			}
+			if _, fn303 := codecRegistered[time.Duration](); fn303 != nil {
+				{
+					off304 := dec.offset()
+					p, err := dec.value("Took", codecTimer_Took.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off304, err), "Took", codecTypeOf[time.Duration])
+					}
+					if len(p) != 0 {
+						r, err := fn303("Took", codecTimer_Took.tag, p)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off304, err), "Took", codecTypeOf[time.Duration])
+						}
+						v.Took = r
+					} else if codecTimer_Took.def != nil {
+						v.Took = *codecTimer_Took.def.(*time.Duration)
+					}
+				}
+			} else {
+				if o := codecTimer_Took.order; o != nil {
+					off305 := dec.offset()
+					u, err := dec.binary(o, 8)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off305, err), "Took", codecTypeOf[time.Duration])
+					}
+					v.Took = time.Duration(codecSigned(u, 8))
+				} else {
+					{
+						off306 := dec.offset()
+						p, err := dec.value("Took", codecTimer_Took.tag, codecTimer_Took.format.Encoding == oxygen.PackedDecimal)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off306, err), "Took", codecTypeOf[time.Duration])
+						}
+						if len(p) != 0 {
+							r, err := codecTimer_Took.format.ParseInt(string(p), 64)
+							v.Took = time.Duration(r)
+							if err != nil {
+								return codecUnmarshalError(dec.typeError(off306, err), "Took", codecTypeOf[time.Duration])
+							}
+						} else if codecTimer_Took.def != nil {
+							v.Took = *codecTimer_Took.def.(*time.Duration)
+						}
+					}
+				}
+			}
+		case 1: // Best
+			if ok, err := codecPresent(&codecTimer_Best, v, codecSiblingTimer); err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "5, ,l", Field: "Best", Err: err}
+			} else if !ok {
+				continue
+			}
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "Best", codecTypeOf[*time.Duration])
+				}
+			}
+			sep = codecRemoveSeparator
+			if codecTimer_Best.err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "5, ,l", Field: "Best", Err: codecTimer_Best.err}
+			}
+			if _, fn311 := codecRegistered[*time.Duration](); fn311 != nil {
+				{
+					off312 := dec.offset()
+					p, err := dec.value("Best", codecTimer_Best.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off312, err), "Best", codecTypeOf[*time.Duration])
+					}
+					if len(p) != 0 {
+						r, err := fn311("Best", codecTimer_Best.tag, p)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off312, err), "Best", codecTypeOf[*time.Duration])
+						}
+						v.Best = r
+					} else if codecTimer_Best.def != nil {
+						d313 := *codecTimer_Best.def.(*time.Duration)
+						v.Best = &d313
+					}
+				}
+			} else {
+				{
+					p314 := v.Best
+					if p314 == nil {
+						p314 = new(time.Duration)
+					}
+					if _, fn315 := codecRegistered[time.Duration](); fn315 != nil {
+						{
+							off316 := dec.offset()
+							p, err := dec.value("Best", codecTimer_Best.tag, false)
+							if err != nil {
+								return codecUnmarshalError(dec.typeError(off316, err), "Best", codecTypeOf[*time.Duration])
+							}
+							if len(p) != 0 {
+								r, err := fn315("Best", codecTimer_Best.tag, p)
+								if err != nil {
+									return codecUnmarshalError(dec.typeError(off316, err), "Best", codecTypeOf[*time.Duration])
+								}
+								(*p314) = r
+							} else if codecTimer_Best.def != nil {
+								d317 := *codecTimer_Best.def.(*time.Duration)
+								v.Best = &d317
+							}
+						}
+					} else {
+						if o := codecTimer_Best.order; o != nil {
+							off318 := dec.offset()
+							u, err := dec.binary(o, 8)
+							if err != nil {
+								return codecUnmarshalError(dec.typeError(off318, err), "Best", codecTypeOf[*time.Duration])
+							}
+							(*p314) = time.Duration(codecSigned(u, 8))
+						} else {
+							{
+								off319 := dec.offset()
+								p, err := dec.value("Best", codecTimer_Best.tag, codecTimer_Best.format.Encoding == oxygen.PackedDecimal)
+								if err != nil {
+									return codecUnmarshalError(dec.typeError(off319, err), "Best", codecTypeOf[*time.Duration])
+								}
+								if len(p) != 0 {
+									r, err := codecTimer_Best.format.ParseInt(string(p), 64)
+									(*p314) = time.Duration(r)
+									if err != nil {
+										return codecUnmarshalError(dec.typeError(off319, err), "Best", codecTypeOf[*time.Duration])
+									}
+								} else if codecTimer_Best.def != nil {
+									d320 := *codecTimer_Best.def.(*time.Duration)
+									v.Best = &d320
+								}
+							}
+						}
+					}
+					if v.Best == nil && !((*p314) == 0) {
+						v.Best = p314
+					}
+				}
+			}
+		case 2: // Laps
+			if ok, err := codecPresent(&codecTimer_Laps, v, codecSiblingTimer); err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "4, ,l", Field: "Laps", Err: err}
+			} else if !ok {
+				continue
+			}
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "Laps", codecTypeOf[[]time.Duration])
+				}
+			}
+			sep = codecRemoveSeparator
+			if codecTimer_Laps.err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "4, ,l", Field: "Laps", Err: codecTimer_Laps.err}
+			}
+			if codecTimer_Laps.count == "" {
+				if _, fn324 := codecRegistered[[]time.Duration](); fn324 != nil {
+					{
+						off325 := dec.offset()
+						p, err := dec.value("Laps", codecTimer_Laps.tag, false)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off325, err), "Laps", codecTypeOf[[]time.Duration])
+						}
+						if len(p) != 0 {
+							r, err := fn324("Laps", codecTimer_Laps.tag, p)
+							if err != nil {
+								return codecUnmarshalError(dec.typeError(off325, err), "Laps", codecTypeOf[[]time.Duration])
+							}
+							v.Laps = r
+						}
+					}
+				} else {
+					{
+						if err := dec.enter(); err != nil {
+							return codecUnmarshalError(dec.typeError(dec.offset(), err), "Laps", codecTypeOf[[]time.Duration])
+						}
+						if codecUnwrapList {
+							if err := dec.removePrefix(codecListOpener); err != nil {
+								return codecUnmarshalError(err, "Laps", codecTypeOf[[]time.Duration])
+							}
+						}
+						var z327 time.Duration
+						n326 := 0
+						for ; ; n326++ {
+							if dec.endOf(codecUnwrapList, codecListCloser) {
+								break
+							}
+							left328 := len(dec.data)
+							if n326 > 0 && codecRemoveElemSep {
+								if err := dec.removePrefix(codecElementSeparator); err != nil {
+									return codecUnmarshalError(err, "Laps", codecTypeOf[[]time.Duration])
+								}
+							}
+							if n326 < len(v.Laps) {
+								v.Laps[n326] = z327
+							} else {
+								v.Laps = append(v.Laps, z327)
+							}
+							if _, fn329 := codecRegistered[time.Duration](); fn329 != nil {
+								{
+									off330 := dec.offset()
+									p, err := dec.value("Laps", codecTimer_Laps.tag, false)
+									if err != nil {
+										return codecUnmarshalError(dec.typeError(off330, err), "Laps", codecTypeOf[[]time.Duration])
+									}
+									if len(p) != 0 {
+										r, err := fn329("Laps", codecTimer_Laps.tag, p)
+										if err != nil {
+											return codecUnmarshalError(dec.typeError(off330, err), "Laps", codecTypeOf[[]time.Duration])
+										}
+										v.Laps[n326] = r
+									}
+								}
+							} else {
+								if o := codecTimer_Laps.order; o != nil {
+									off331 := dec.offset()
+									u, err := dec.binary(o, 8)
+									if err != nil {
+										return codecUnmarshalError(dec.typeError(off331, err), "Laps", codecTypeOf[[]time.Duration])
+									}
+									v.Laps[n326] = time.Duration(codecSigned(u, 8))
+								} else {
+									{
+										off332 := dec.offset()
+										p, err := dec.value("Laps", codecTimer_Laps.tag, codecTimer_Laps.format.Encoding == oxygen.PackedDecimal)
+										if err != nil {
+											return codecUnmarshalError(dec.typeError(off332, err), "Laps", codecTypeOf[[]time.Duration])
+										}
+										if len(p) != 0 {
+											r, err := codecTimer_Laps.format.ParseInt(string(p), 64)
+											v.Laps[n326] = time.Duration(r)
+											if err != nil {
+												return codecUnmarshalError(dec.typeError(off332, err), "Laps", codecTypeOf[[]time.Duration])
+											}
+										}
+									}
+								}
+							}
+							if len(dec.data) == left328 {
+								break
+							}
+						}
+						if codecUnwrapList {
+							if err := dec.removePrefix(codecListCloser); err != nil {
+								return codecUnmarshalError(err, "Laps", codecTypeOf[[]time.Duration])
+							}
+						}
+						dec.leave()
+						if v.Laps != nil {
+							v.Laps = v.Laps[:n326]
+						}
+					}
+				}
+			} else {
+				off := dec.offset()
+				var want uint64
+				var err error
+				switch codecTimer_Laps.count {
+				case "Took":
+					want, err = codecCount(v.Took)
+				}
+				if err != nil {
+					return codecUnmarshalError(dec.typeError(off, err), "Laps", codecTypeOf[[]time.Duration])
+				}
+				{
+					if err := dec.enter(); err != nil {
+						return codecUnmarshalError(dec.typeError(dec.offset(), err), "Laps", codecTypeOf[[]time.Duration])
+					}
+					if codecUnwrapList {
+						if err := dec.removePrefix(codecListOpener); err != nil {
+							return codecUnmarshalError(err, "Laps", codecTypeOf[[]time.Duration])
+						}
+					}
+					var z334 time.Duration
+					n333 := 0
+					limit335 := math.MaxInt32
+					if want < uint64(limit335) {
+						limit335 = int(want)
+					}
+					v.Laps = nil
+					for ; n333 < limit335; n333++ {
+						if dec.endOf(codecUnwrapList, codecListCloser) {
+							break
+						}
+						left336 := len(dec.data)
+						if n333 > 0 && codecRemoveElemSep {
+							if err := dec.removePrefix(codecElementSeparator); err != nil {
+								return codecUnmarshalError(err, "Laps", codecTypeOf[[]time.Duration])
+							}
+						}
+						if n333 < len(v.Laps) {
+							v.Laps[n333] = z334
+						} else {
+							v.Laps = append(v.Laps, z334)
+						}
+						if _, fn337 := codecRegistered[time.Duration](); fn337 != nil {
+							{
+								off338 := dec.offset()
+								p, err := dec.value("Laps", codecTimer_Laps.tag, false)
+								if err != nil {
+									return codecUnmarshalError(dec.typeError(off338, err), "Laps", codecTypeOf[[]time.Duration])
+								}
+								if len(p) != 0 {
+									r, err := fn337("Laps", codecTimer_Laps.tag, p)
+									if err != nil {
+										return codecUnmarshalError(dec.typeError(off338, err), "Laps", codecTypeOf[[]time.Duration])
+									}
+									v.Laps[n333] = r
+								}
+							}
+						} else {
+							if o := codecTimer_Laps.order; o != nil {
+								off339 := dec.offset()
+								u, err := dec.binary(o, 8)
+								if err != nil {
+									return codecUnmarshalError(dec.typeError(off339, err), "Laps", codecTypeOf[[]time.Duration])
+								}
+								v.Laps[n333] = time.Duration(codecSigned(u, 8))
+							} else {
+								{
+									off340 := dec.offset()
+									p, err := dec.value("Laps", codecTimer_Laps.tag, codecTimer_Laps.format.Encoding == oxygen.PackedDecimal)
+									if err != nil {
+										return codecUnmarshalError(dec.typeError(off340, err), "Laps", codecTypeOf[[]time.Duration])
+									}
+									if len(p) != 0 {
+										r, err := codecTimer_Laps.format.ParseInt(string(p), 64)
+										v.Laps[n333] = time.Duration(r)
+										if err != nil {
+											return codecUnmarshalError(dec.typeError(off340, err), "Laps", codecTypeOf[[]time.Duration])
+										}
+									}
+								}
+							}
+						}
+						if len(dec.data) == left336 {
+							break
+						}
+					}
+					if codecUnwrapList {
+						if err := dec.removePrefix(codecListCloser); err != nil {
+							return codecUnmarshalError(err, "Laps", codecTypeOf[[]time.Duration])
+						}
+					}
+					dec.leave()
+					if v.Laps != nil {
+						v.Laps = v.Laps[:n333]
+					}
+					if uint64(n333) != want {
+						return codecUnmarshalError(dec.typeError(dec.offset(), fmt.Errorf("found %d elements out of %d", n333, want)), "Laps", codecTypeOf[[]time.Duration])
+					}
+				}
+			}
+		case 3: // Next
+			if ok, err := codecPresent(&codecTimer_Next, v, codecSiblingTimer); err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "3, ,l", Field: "Next", Err: err}
+			} else if !ok {
+				continue
+			}
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "Next", codecTypeOf[*[]time.Duration])
+				}
+			}
+			sep = codecRemoveSeparator
+			if codecTimer_Next.err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "3, ,l", Field: "Next", Err: codecTimer_Next.err}
+			}
+			if _, fn346 := codecRegistered[*[]time.Duration](); fn346 != nil {
+				{
+					off347 := dec.offset()
+					p, err := dec.value("Next", codecTimer_Next.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off347, err), "Next", codecTypeOf[*[]time.Duration])
+					}
+					if len(p) != 0 {
+						r, err := fn346("Next", codecTimer_Next.tag, p)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off347, err), "Next", codecTypeOf[*[]time.Duration])
+						}
+						v.Next = r
+					}
+				}
+			} else {
+				{
+					p348 := v.Next
+					if p348 == nil {
+						p348 = new([]time.Duration)
+					}
+					if _, fn349 := codecRegistered[[]time.Duration](); fn349 != nil {
+						{
+							off350 := dec.offset()
+							p, err := dec.value("Next", codecTimer_Next.tag, false)
+							if err != nil {
+								return codecUnmarshalError(dec.typeError(off350, err), "Next", codecTypeOf[*[]time.Duration])
+							}
+							if len(p) != 0 {
+								r, err := fn349("Next", codecTimer_Next.tag, p)
+								if err != nil {
+									return codecUnmarshalError(dec.typeError(off350, err), "Next", codecTypeOf[*[]time.Duration])
+								}
+								(*p348) = r
+							}
+						}
+					} else {
+						{
+							if err := dec.enter(); err != nil {
+								return codecUnmarshalError(dec.typeError(dec.offset(), err), "Next", codecTypeOf[*[]time.Duration])
+							}
+							if codecUnwrapList {
+								if err := dec.removePrefix(codecListOpener); err != nil {
+									return codecUnmarshalError(err, "Next", codecTypeOf[*[]time.Duration])
+								}
+							}
+							var z352 time.Duration
+							n351 := 0
+							for ; ; n351++ {
+								if dec.endOf(codecUnwrapList, codecListCloser) {
+									break
+								}
+								left353 := len(dec.data)
+								if n351 > 0 && codecRemoveElemSep {
+									if err := dec.removePrefix(codecElementSeparator); err != nil {
+										return codecUnmarshalError(err, "Next", codecTypeOf[*[]time.Duration])
+									}
+								}
+								if n351 < len((*p348)) {
+									(*p348)[n351] = z352
+								} else {
+									(*p348) = append((*p348), z352)
+								}
+								if _, fn354 := codecRegistered[time.Duration](); fn354 != nil {
+									{
+										off355 := dec.offset()
+										p, err := dec.value("Next", codecTimer_Next.tag, false)
+										if err != nil {
+											return codecUnmarshalError(dec.typeError(off355, err), "Next", codecTypeOf[*[]time.Duration])
+										}
+										if len(p) != 0 {
+											r, err := fn354("Next", codecTimer_Next.tag, p)
+											if err != nil {
+												return codecUnmarshalError(dec.typeError(off355, err), "Next", codecTypeOf[*[]time.Duration])
+											}
+											(*p348)[n351] = r
+										}
+									}
+								} else {
+									if o := codecTimer_Next.order; o != nil {
+										off356 := dec.offset()
+										u, err := dec.binary(o, 8)
+										if err != nil {
+											return codecUnmarshalError(dec.typeError(off356, err), "Next", codecTypeOf[*[]time.Duration])
+										}
+										(*p348)[n351] = time.Duration(codecSigned(u, 8))
+									} else {
+										{
+											off357 := dec.offset()
+											p, err := dec.value("Next", codecTimer_Next.tag, codecTimer_Next.format.Encoding == oxygen.PackedDecimal)
+											if err != nil {
+												return codecUnmarshalError(dec.typeError(off357, err), "Next", codecTypeOf[*[]time.Duration])
+											}
+											if len(p) != 0 {
+												r, err := codecTimer_Next.format.ParseInt(string(p), 64)
+												(*p348)[n351] = time.Duration(r)
+												if err != nil {
+													return codecUnmarshalError(dec.typeError(off357, err), "Next", codecTypeOf[*[]time.Duration])
+												}
+											}
+										}
+									}
+								}
+								if len(dec.data) == left353 {
+									break
+								}
+							}
+							if codecUnwrapList {
+								if err := dec.removePrefix(codecListCloser); err != nil {
+									return codecUnmarshalError(err, "Next", codecTypeOf[*[]time.Duration])
+								}
+							}
+							dec.leave()
+							if (*p348) != nil {
+								(*p348) = (*p348)[:n351]
+							}
+						}
+					}
+					if v.Next == nil && !(len((*p348)) == 0) {
+						v.Next = p348
+					}
+				}
+			}
+		}
+	}
+	if unwrap {
+		if codecStrict && len(dec.data) != 0 && !bytes.HasPrefix(dec.data, codecStructCloser) {
+			return &oxygen.SyntaxError{Name: cfg.Name, Offset: dec.offset(), Err: oxygen.ErrTrailingData}
+		}
+		if i := bytes.Index(dec.data, codecStructCloser); i > 0 {
+			dec.data = dec.data[i:]
+		}
+		if err := dec.removePrefix(codecStructCloser); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (enc *codecEncoder) encodeNestedTimer(v *records.Timer, wrap bool) error {
+	if err := enc.enter(); err != nil {
+		return err
+	}
+	err := enc.encodeTimer(v, wrap)
+	enc.leave()
+	return err
+}
+
+func (dec *codecDecoder) decodeNestedTimer(v *records.Timer, unwrap bool) error {
+	if err := dec.enter(); err != nil {
+		return dec.typeError(dec.offset(), err)
+	}
+	err := dec.decodeTimer(v, unwrap)
+	dec.leave()
+	return err
+}
+
+func codecSiblingNode(v *records.Node, name string) any {
+	switch name {
+	case "ID":
+		return v.ID
+	case "Kids":
+		return v.Kids
+	}
+	return nil
+}
+
+func codecRemainingNode(v *records.Node, from int, fn func(string, *codecTag, codecFields) bool) bool {
+	for i := from; i < 2; i++ {
+		switch i {
+		case 0: // ID
+			if ok, err := codecPresent(&codecNode_ID, v, codecSiblingNode); (ok || err != nil) && !fn("ID", &codecNode_ID, nil) {
+				return false
+			}
+		case 1: // Kids
+			if !fn("Kids", nil, nil) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (enc *codecEncoder) encodeNode(v *records.Node, wrap bool) error {
+	sep := false
+	if wrap {
+		enc.Write(codecStructOpener)
+	}
+	// ID
+	d358 := v.ID
+	if codecNode_ID.encodeDef && d358 == 0 {
+		d358 = *codecNode_ID.def.(*int)
+	}
+	if ok, err := codecPresent(&codecNode_ID, v, codecSiblingNode); err != nil {
+		return &oxygen.TagError{Name: cfg.Name, Tag: "2,0,r", Field: "ID", Err: err}
+	} else if ok && !(codecNode_ID.omit && d358 == 0) {
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if codecNode_ID.err != nil {
+			return &oxygen.TagError{Name: cfg.Name, Tag: "2,0,r", Field: "ID", Err: codecNode_ID.err}
+		}
+		if fn359, _ := codecRegistered[int](); fn359 != nil {
+			p, err := fn359("ID", codecNode_ID.tag, d358)
+			if err != nil {
+				return codecMarshalError(err, "ID", codecTypeOf[int])
+			}
+			if err := enc.value("ID", codecNode_ID.tag, p, false); err != nil {
+				return codecMarshalError(err, "ID", codecTypeOf[int])
+			}
+		} else {
+			if o := codecNode_ID.order; o != nil {
+				enc.Write(oxygen.AppendBinary(enc.scratch[:0], o, uint64(d358), strconv.IntSize/8))
+			} else {
+				if err := codecNode_ID.format.CheckInt(int64(d358)); err != nil {
+					return codecMarshalError(err, "ID", codecTypeOf[int])
+				}
+				if err := enc.value("ID", codecNode_ID.tag, codecNode_ID.format.AppendInt(enc.scratch[:0], int64(d358)), codecNode_ID.format.Encoding == oxygen.PackedDecimal); err != nil {
+					return codecMarshalError(err, "ID", codecTypeOf[int])
+				}
+			}
+		}
+	}
+	// Kids
+	{
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if fn364, _ := codecRegistered[[]records.Node](); fn364 != nil {
+			p, err := fn364("Kids", nil, v.Kids)
+			if err != nil {
+				return codecMarshalError(err, "Kids", codecTypeOf[[]records.Node])
+			}
+			if err := enc.value("Kids", nil, p, false); err != nil {
+				return codecMarshalError(err, "Kids", codecTypeOf[[]records.Node])
+			}
+		} else {
+			if err := enc.enter(); err != nil {
+				return codecMarshalError(err, "Kids", codecTypeOf[[]records.Node])
+			}
+			if codecWrapList {
+				enc.Write(codecListOpener)
+			}
+			for i365 := range v.Kids {
+				if i365 > 0 && codecSeparateElems {
+					enc.Write(codecElementSeparator)
+				}
+				if fn366, _ := codecRegistered[records.Node](); fn366 != nil {
+					p, err := fn366("Kids", nil, v.Kids[i365])
+					if err != nil {
+						return codecMarshalError(err, "Kids", codecTypeOf[[]records.Node])
+					}
+					if err := enc.value("Kids", nil, p, false); err != nil {
+						return codecMarshalError(err, "Kids", codecTypeOf[[]records.Node])
+					}
+				} else {
+					if err := enc.encodeNestedNode(&v.Kids[i365], codecWrap); err != nil {
+						return codecMarshalError(err, "Kids", codecTypeOf[[]records.Node])
+					}
+				}
+			}
+			if codecWrapList {
+				enc.Write(codecListCloser)
+			}
+			enc.leave()
+		}
+	}
+	if wrap {
+		enc.Write(codecStructCloser)
+	}
+	return nil
+}
+
+func (dec *codecDecoder) decodeNode(v *records.Node, unwrap bool) error {
+	if unwrap {
+		if err := dec.removePrefix(codecStructOpener); err != nil {
+			return err
+		}
+	}
+	sep := false
+	for i := 0; i < 2; i++ {
+		if len(dec.data) == 0 || unwrap && bytes.HasPrefix(dec.data, codecStructCloser) {
+			remaining := func(fn func(string, *codecTag, codecFields) bool) bool {
+				return codecRemainingNode(v, i, fn)
+			}
+			if codecStrict {
+				if name := codecTruncated(remaining); name != "" {
+					return &oxygen.SyntaxError{Name: cfg.Name, Field: name, Offset: dec.offset(), Err: oxygen.ErrTruncated}
+				}
+			}
+			remaining(dec.required)
+			break
+		}
+		switch i {
+		case 0: // ID
+			if ok, err := codecPresent(&codecNode_ID, v, codecSiblingNode); err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "2,0,r", Field: "ID", Err: err}
+			} else if !ok {
+				continue
+			}
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "ID", codecTypeOf[int])
+				}
+			}
+			sep = codecRemoveSeparator
+			if codecNode_ID.err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "2,0,r", Field: "ID", Err: codecNode_ID.err}
+			}
+			if _, fn360 := codecRegistered[int](); fn360 != nil {
+				{
+					off361 := dec.offset()
+					p, err := dec.value("ID", codecNode_ID.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off361, err), "ID", codecTypeOf[int])
+					}
+					if len(p) != 0 {
+						r, err := fn360("ID", codecNode_ID.tag, p)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off361, err), "ID", codecTypeOf[int])
+						}
+						v.ID = r
+					} else if codecNode_ID.def != nil {
+						v.ID = *codecNode_ID.def.(*int)
+					}
+				}
+			} else {
+				if o := codecNode_ID.order; o != nil {
+					off362 := dec.offset()
+					u, err := dec.binary(o, strconv.IntSize/8)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off362, err), "ID", codecTypeOf[int])
+					}
+					v.ID = int(codecSigned(u, strconv.IntSize/8))
+				} else {
+					{
+						off363 := dec.offset()
+						p, err := dec.value("ID", codecNode_ID.tag, codecNode_ID.format.Encoding == oxygen.PackedDecimal)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off363, err), "ID", codecTypeOf[int])
+						}
+						if len(p) != 0 {
+							r, err := codecNode_ID.format.ParseInt(string(p), strconv.IntSize)
+							v.ID = int(r)
+							if err != nil {
+								return codecUnmarshalError(dec.typeError(off363, err), "ID", codecTypeOf[int])
+							}
+						} else if codecNode_ID.def != nil {
+							v.ID = *codecNode_ID.def.(*int)
+						}
+					}
+				}
+			}
+		case 1: // Kids
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "Kids", codecTypeOf[[]records.Node])
+				}
+			}
+			sep = codecRemoveSeparator
+			if _, fn367 := codecRegistered[[]records.Node](); fn367 != nil {
+				{
+					off368 := dec.offset()
+					p, err := dec.value("Kids", nil, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off368, err), "Kids", codecTypeOf[[]records.Node])
+					}
+					if len(p) != 0 {
+						r, err := fn367("Kids", nil, p)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off368, err), "Kids", codecTypeOf[[]records.Node])
+						}
+						v.Kids = r
+					}
+				}
+			} else {
+				{
+					if err := dec.enter(); err != nil {
+						return codecUnmarshalError(dec.typeError(dec.offset(), err), "Kids", codecTypeOf[[]records.Node])
+					}
+					if codecUnwrapList {
+						if err := dec.removePrefix(codecListOpener); err != nil {
+							return codecUnmarshalError(err, "Kids", codecTypeOf[[]records.Node])
+						}
+					}
+					var z370 records.Node
+					n369 := 0
+					m371 := len(dec.missing)
+					for ; ; n369++ {
+						if dec.endOf(codecUnwrapList, codecListCloser) {
+							break
+						}
+						left372 := len(dec.data)
+						if n369 > 0 && codecRemoveElemSep {
+							if err := dec.removePrefix(codecElementSeparator); err != nil {
+								return codecUnmarshalError(err, "Kids", codecTypeOf[[]records.Node])
+							}
+						}
+						if n369 < len(v.Kids) {
+							v.Kids[n369] = z370
+						} else {
+							v.Kids = append(v.Kids, z370)
+						}
+						if _, fn373 := codecRegistered[records.Node](); fn373 != nil {
+							{
+								off374 := dec.offset()
+								p, err := dec.value("Kids", nil, false)
+								if err != nil {
+									return codecUnmarshalError(dec.typeError(off374, err), "Kids", codecTypeOf[[]records.Node])
+								}
+								if len(p) != 0 {
+									r, err := fn373("Kids", nil, p)
+									if err != nil {
+										return codecUnmarshalError(dec.typeError(off374, err), "Kids", codecTypeOf[[]records.Node])
+									}
+									v.Kids[n369] = r
+								}
+							}
+						} else {
+							n375 := len(dec.missing)
+							if err := dec.decodeNestedNode(&v.Kids[n369], codecRemoveWrapper); err != nil {
+								return codecUnmarshalError(err, "Kids", codecTypeOf[[]records.Node])
+							}
+							dec.prefix(n375, "["+strconv.Itoa(n369)+"]")
+						}
+						if len(dec.data) == left372 {
+							break
+						}
+					}
+					if codecUnwrapList {
+						if err := dec.removePrefix(codecListCloser); err != nil {
+							return codecUnmarshalError(err, "Kids", codecTypeOf[[]records.Node])
+						}
+					}
+					dec.leave()
+					dec.prefix(m371, "Kids")
+					if v.Kids != nil {
+						v.Kids = v.Kids[:n369]
+					}
+				}
+			}
+		}
+	}
+	if unwrap {
+		if codecStrict && len(dec.data) != 0 && !bytes.HasPrefix(dec.data, codecStructCloser) {
+			return &oxygen.SyntaxError{Name: cfg.Name, Offset: dec.offset(), Err: oxygen.ErrTrailingData}
+		}
+		if i := bytes.Index(dec.data, codecStructCloser); i > 0 {
+			dec.data = dec.data[i:]
+		}
+		if err := dec.removePrefix(codecStructCloser); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (enc *codecEncoder) encodeNestedNode(v *records.Node, wrap bool) error {
+	if err := enc.enter(); err != nil {
+		return err
+	}
+	err := enc.encodeNode(v, wrap)
+	enc.leave()
+	return err
+}
+
+func (dec *codecDecoder) decodeNestedNode(v *records.Node, unwrap bool) error {
+	if err := dec.enter(); err != nil {
+		return dec.typeError(dec.offset(), err)
+	}
+	err := dec.decodeNode(v, unwrap)
+	dec.leave()
+	return err
+}
+
+func codecSiblingHeader(v *records.Header, name string) any {
+	switch name {
+	case "Kind":
+		return v.Kind
+	case "Rev":
+		return v.Rev
+	}
+	return nil
+}
+
+func codecRemainingHeader(v *records.Header, from int, fn func(string, *codecTag, codecFields) bool) bool {
+	for i := from; i < 2; i++ {
+		switch i {
+		case 0: // Kind
+			if ok, err := codecPresent(&codecHeader_Kind, v, codecSiblingHeader); (ok || err != nil) && !fn("Kind", &codecHeader_Kind, nil) {
+				return false
+			}
+		case 1: // Rev
+			if ok, err := codecPresent(&codecHeader_Rev, v, codecSiblingHeader); (ok || err != nil) && !fn("Rev", &codecHeader_Rev, nil) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (enc *codecEncoder) encodeHeader(v *records.Header, wrap bool) error {
+	sep := false
+	if wrap {
+		enc.Write(codecStructOpener)
+	}
+	// Kind
+	d376 := v.Kind
+	if codecHeader_Kind.encodeDef && len(d376) == 0 {
+		d376 = *codecHeader_Kind.def.(*string)
+	}
+	if ok, err := codecPresent(&codecHeader_Kind, v, codecSiblingHeader); err != nil {
+		return &oxygen.TagError{Name: cfg.Name, Tag: "1", Field: "Kind", Err: err}
+	} else if ok && !(codecHeader_Kind.omit && len(d376) == 0) {
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if codecHeader_Kind.err != nil {
+			return &oxygen.TagError{Name: cfg.Name, Tag: "1", Field: "Kind", Err: codecHeader_Kind.err}
+		}
+		if fn377, _ := codecRegistered[string](); fn377 != nil {
+			p, err := fn377("Kind", codecHeader_Kind.tag, d376)
+			if err != nil {
+				return codecMarshalError(err, "Kind", codecTypeOf[string])
+			}
+			if err := enc.value("Kind", codecHeader_Kind.tag, p, false); err != nil {
+				return codecMarshalError(err, "Kind", codecTypeOf[string])
+			}
+		} else {
+			if err := enc.value("Kind", codecHeader_Kind.tag, append(enc.scratch[:0], string(d376)...), false); err != nil {
+				return codecMarshalError(err, "Kind", codecTypeOf[string])
+			}
+		}
+	}
+	// Rev
+	d381 := v.Rev
+	if codecHeader_Rev.encodeDef && d381 == 0 {
+		d381 = *codecHeader_Rev.def.(*uint8)
+	}
+	if ok, err := codecPresent(&codecHeader_Rev, v, codecSiblingHeader); err != nil {
+		return &oxygen.TagError{Name: cfg.Name, Tag: "2,0,r", Field: "Rev", Err: err}
+	} else if ok && !(codecHeader_Rev.omit && d381 == 0) {
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if codecHeader_Rev.err != nil {
+			return &oxygen.TagError{Name: cfg.Name, Tag: "2,0,r", Field: "Rev", Err: codecHeader_Rev.err}
+		}
+		if fn382, _ := codecRegistered[uint8](); fn382 != nil {
+			p, err := fn382("Rev", codecHeader_Rev.tag, d381)
+			if err != nil {
+				return codecMarshalError(err, "Rev", codecTypeOf[uint8])
+			}
+			if err := enc.value("Rev", codecHeader_Rev.tag, p, false); err != nil {
+				return codecMarshalError(err, "Rev", codecTypeOf[uint8])
+			}
+		} else {
+			if o := codecHeader_Rev.order; o != nil {
+				enc.Write(oxygen.AppendBinary(enc.scratch[:0], o, uint64(d381), 1))
+			} else {
+				if err := codecHeader_Rev.format.CheckUint(uint64(d381)); err != nil {
+					return codecMarshalError(err, "Rev", codecTypeOf[uint8])
+				}
+				if err := enc.value("Rev", codecHeader_Rev.tag, codecHeader_Rev.format.AppendUint(enc.scratch[:0], uint64(d381)), codecHeader_Rev.format.Encoding == oxygen.PackedDecimal); err != nil {
+					return codecMarshalError(err, "Rev", codecTypeOf[uint8])
+				}
+			}
+		}
+	}
+	if wrap {
+		enc.Write(codecStructCloser)
+	}
+	return nil
+}
+
+func (dec *codecDecoder) decodeHeader(v *records.Header, unwrap bool) error {
+	if unwrap {
+		if err := dec.removePrefix(codecStructOpener); err != nil {
+			return err
+		}
+	}
+	sep := false
+	for i := 0; i < 2; i++ {
+		if len(dec.data) == 0 || unwrap && bytes.HasPrefix(dec.data, codecStructCloser) {
+			remaining := func(fn func(string, *codecTag, codecFields) bool) bool {
+				return codecRemainingHeader(v, i, fn)
+			}
+			if codecStrict {
+				if name := codecTruncated(remaining); name != "" {
+					return &oxygen.SyntaxError{Name: cfg.Name, Field: name, Offset: dec.offset(), Err: oxygen.ErrTruncated}
+				}
+			}
+			remaining(dec.required)
+			break
+		}
+		switch i {
+		case 0: // Kind
+			if ok, err := codecPresent(&codecHeader_Kind, v, codecSiblingHeader); err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "1", Field: "Kind", Err: err}
+			} else if !ok {
+				continue
+			}
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "Kind", codecTypeOf[string])
+				}
+			}
+			sep = codecRemoveSeparator
+			if codecHeader_Kind.err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "1", Field: "Kind", Err: codecHeader_Kind.err}
+			}
+			if _, fn378 := codecRegistered[string](); fn378 != nil {
+				{
+					off379 := dec.offset()
+					p, err := dec.value("Kind", codecHeader_Kind.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off379, err), "Kind", codecTypeOf[string])
+					}
+					if len(p) != 0 {
+						r, err := fn378("Kind", codecHeader_Kind.tag, p)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off379, err), "Kind", codecTypeOf[string])
+						}
+						v.Kind = r
+					} else if codecHeader_Kind.def != nil {
+						v.Kind = *codecHeader_Kind.def.(*string)
+					}
+				}
+			} else {
+				{
+					off380 := dec.offset()
+					p, err := dec.value("Kind", codecHeader_Kind.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off380, err), "Kind", codecTypeOf[string])
+					}
+					if len(p) != 0 {
+						v.Kind = string(p)
+					} else if codecHeader_Kind.def != nil {
+						v.Kind = *codecHeader_Kind.def.(*string)
+					}
+				}
+			}
+		case 1: // Rev
+			if ok, err := codecPresent(&codecHeader_Rev, v, codecSiblingHeader); err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "2,0,r", Field: "Rev", Err: err}
+			} else if !ok {
+				continue
+			}
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "Rev", codecTypeOf[uint8])
+				}
+			}
+			sep = codecRemoveSeparator
+			if codecHeader_Rev.err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "2,0,r", Field: "Rev", Err: codecHeader_Rev.err}
+			}
+			if _, fn383 := codecRegistered[uint8](); fn383 != nil {
+				{
+					off384 := dec.offset()
+					p, err := dec.value("Rev", codecHeader_Rev.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off384, err), "Rev", codecTypeOf[uint8])
+					}
+					if len(p) != 0 {
+						r, err := fn383("Rev", codecHeader_Rev.tag, p)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off384, err), "Rev", codecTypeOf[uint8])
+						}
+						v.Rev = r
+					} else if codecHeader_Rev.def != nil {
+						v.Rev = *codecHeader_Rev.def.(*uint8)
+					}
+				}
+			} else {
+				if o := codecHeader_Rev.order; o != nil {
+					off385 := dec.offset()
+					u, err := dec.binary(o, 1)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off385, err), "Rev", codecTypeOf[uint8])
+					}
+					v.Rev = uint8(u)
+				} else {
+					{
+						off386 := dec.offset()
+						p, err := dec.value("Rev", codecHeader_Rev.tag, codecHeader_Rev.format.Encoding == oxygen.PackedDecimal)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off386, err), "Rev", codecTypeOf[uint8])
+						}
+						if len(p) != 0 {
+							r, err := codecHeader_Rev.format.ParseUint(string(p), 8)
+							v.Rev = uint8(r)
+							if err != nil {
+								return codecUnmarshalError(dec.typeError(off386, err), "Rev", codecTypeOf[uint8])
+							}
+						} else if codecHeader_Rev.def != nil {
+							v.Rev = *codecHeader_Rev.def.(*uint8)
+						}
+					}
+				}
+			}
+		}
+	}
+	if unwrap {
+		if codecStrict && len(dec.data) != 0 && !bytes.HasPrefix(dec.data, codecStructCloser) {
+			return &oxygen.SyntaxError{Name: cfg.Name, Offset: dec.offset(), Err: oxygen.ErrTrailingData}
+		}
+		if i := bytes.Index(dec.data, codecStructCloser); i > 0 {
+			dec.data = dec.data[i:]
+		}
+		if err := dec.removePrefix(codecStructCloser); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (enc *codecEncoder) encodeNestedHeader(v *records.Header, wrap bool) error {
+	if err := enc.enter(); err != nil {
+		return err
+	}
+	err := enc.encodeHeader(v, wrap)
+	enc.leave()
+	return err
+}
+
+func (dec *codecDecoder) decodeNestedHeader(v *records.Header, unwrap bool) error {
+	if err := dec.enter(); err != nil {
+		return dec.typeError(dec.offset(), err)
+	}
+	err := dec.decodeHeader(v, unwrap)
+	dec.leave()
+	return err
+}
+
+func codecRemainingTimeTime(v *time.Time, from int, fn func(string, *codecTag, codecFields) bool) bool {
+	return true
+}
+
+func (enc *codecEncoder) encodeTimeTime(v *time.Time, wrap bool) error {
+	if wrap {
+		enc.Write(codecStructOpener)
+	}
+	if wrap {
+		enc.Write(codecStructCloser)
+	}
+	return nil
+}
+
+func (dec *codecDecoder) decodeTimeTime(v *time.Time, unwrap bool) error {
+	if unwrap {
+		if err := dec.removePrefix(codecStructOpener); err != nil {
+			return err
+		}
+	}
+	if unwrap {
+		if codecStrict && len(dec.data) != 0 && !bytes.HasPrefix(dec.data, codecStructCloser) {
+			return &oxygen.SyntaxError{Name: cfg.Name, Offset: dec.offset(), Err: oxygen.ErrTrailingData}
+		}
+		if i := bytes.Index(dec.data, codecStructCloser); i > 0 {
+			dec.data = dec.data[i:]
+		}
+		if err := dec.removePrefix(codecStructCloser); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (enc *codecEncoder) encodeNestedTimeTime(v *time.Time, wrap bool) error {
+	if err := enc.enter(); err != nil {
+		return err
+	}
+	err := enc.encodeTimeTime(v, wrap)
+	enc.leave()
+	return err
+}
+
+func (dec *codecDecoder) decodeNestedTimeTime(v *time.Time, unwrap bool) error {
+	if err := dec.enter(); err != nil {
+		return dec.typeError(dec.offset(), err)
+	}
+	err := dec.decodeTimeTime(v, unwrap)
+	dec.leave()
+	return err
+}
+
+func codecSiblingLimits(v *records.Limits, name string) any {
+	switch name {
+	case "Daily":
+		return v.Daily
+	case "Monthly":
+		return v.Monthly
+	}
+	return nil
+}
+
+func codecRemainingLimits(v *records.Limits, from int, fn func(string, *codecTag, codecFields) bool) bool {
+	for i := from; i < 2; i++ {
+		switch i {
+		case 0: // Daily
+			if ok, err := codecPresent(&codecLimits_Daily, v, codecSiblingLimits); (ok || err != nil) && !fn("Daily", &codecLimits_Daily, nil) {
+				return false
+			}
+		case 1: // Monthly
+			if ok, err := codecPresent(&codecLimits_Monthly, v, codecSiblingLimits); (ok || err != nil) && !fn("Monthly", &codecLimits_Monthly, nil) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (enc *codecEncoder) encodeLimits(v *records.Limits, wrap bool) error {
+	sep := false
+	if wrap {
+		enc.Write(codecStructOpener)
+	}
+	// Daily
+	d387 := v.Daily
+	if codecLimits_Daily.encodeDef && d387 == 0 {
+		d387 = *codecLimits_Daily.def.(*int)
+	}
+	if ok, err := codecPresent(&codecLimits_Daily, v, codecSiblingLimits); err != nil {
+		return &oxygen.TagError{Name: cfg.Name, Tag: "!3,0,r", Field: "Daily", Err: err}
+	} else if ok && !(codecLimits_Daily.omit && d387 == 0) {
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if codecLimits_Daily.err != nil {
+			return &oxygen.TagError{Name: cfg.Name, Tag: "!3,0,r", Field: "Daily", Err: codecLimits_Daily.err}
+		}
+		if fn388, _ := codecRegistered[int](); fn388 != nil {
+			p, err := fn388("Daily", codecLimits_Daily.tag, d387)
+			if err != nil {
+				return codecMarshalError(err, "Daily", codecTypeOf[int])
+			}
+			if err := enc.value("Daily", codecLimits_Daily.tag, p, false); err != nil {
+				return codecMarshalError(err, "Daily", codecTypeOf[int])
+			}
+		} else {
+			if o := codecLimits_Daily.order; o != nil {
+				enc.Write(oxygen.AppendBinary(enc.scratch[:0], o, uint64(d387), strconv.IntSize/8))
+			} else {
+				if err := codecLimits_Daily.format.CheckInt(int64(d387)); err != nil {
+					return codecMarshalError(err, "Daily", codecTypeOf[int])
+				}
+				if err := enc.value("Daily", codecLimits_Daily.tag, codecLimits_Daily.format.AppendInt(enc.scratch[:0], int64(d387)), codecLimits_Daily.format.Encoding == oxygen.PackedDecimal); err != nil {
+					return codecMarshalError(err, "Daily", codecTypeOf[int])
+				}
+			}
+		}
+	}
+	// Monthly
+	d393 := v.Monthly
+	if codecLimits_Monthly.encodeDef && d393 == 0 {
+		d393 = *codecLimits_Monthly.def.(*int)
+	}
+	if ok, err := codecPresent(&codecLimits_Monthly, v, codecSiblingLimits); err != nil {
+		return &oxygen.TagError{Name: cfg.Name, Tag: "!4,0,r", Field: "Monthly", Err: err}
+	} else if ok && !(codecLimits_Monthly.omit && d393 == 0) {
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if codecLimits_Monthly.err != nil {
+			return &oxygen.TagError{Name: cfg.Name, Tag: "!4,0,r", Field: "Monthly", Err: codecLimits_Monthly.err}
+		}
+		if fn394, _ := codecRegistered[int](); fn394 != nil {
+			p, err := fn394("Monthly", codecLimits_Monthly.tag, d393)
+			if err != nil {
+				return codecMarshalError(err, "Monthly", codecTypeOf[int])
+			}
+			if err := enc.value("Monthly", codecLimits_Monthly.tag, p, false); err != nil {
+				return codecMarshalError(err, "Monthly", codecTypeOf[int])
+			}
+		} else {
+			if o := codecLimits_Monthly.order; o != nil {
+				enc.Write(oxygen.AppendBinary(enc.scratch[:0], o, uint64(d393), strconv.IntSize/8))
+			} else {
+				if err := codecLimits_Monthly.format.CheckInt(int64(d393)); err != nil {
+					return codecMarshalError(err, "Monthly", codecTypeOf[int])
+				}
+				if err := enc.value("Monthly", codecLimits_Monthly.tag, codecLimits_Monthly.format.AppendInt(enc.scratch[:0], int64(d393)), codecLimits_Monthly.format.Encoding == oxygen.PackedDecimal); err != nil {
+					return codecMarshalError(err, "Monthly", codecTypeOf[int])
+				}
+			}
+		}
+	}
+	if wrap {
+		enc.Write(codecStructCloser)
+	}
+	return nil
+}
+
+func (dec *codecDecoder) decodeLimits(v *records.Limits, unwrap bool) error {
+	if unwrap {
+		if err := dec.removePrefix(codecStructOpener); err != nil {
+			return err
+		}
+	}
+	sep := false
+	for i := 0; i < 2; i++ {
+		if len(dec.data) == 0 || unwrap && bytes.HasPrefix(dec.data, codecStructCloser) {
+			remaining := func(fn func(string, *codecTag, codecFields) bool) bool {
+				return codecRemainingLimits(v, i, fn)
+			}
+			if codecStrict {
+				if name := codecTruncated(remaining); name != "" {
+					return &oxygen.SyntaxError{Name: cfg.Name, Field: name, Offset: dec.offset(), Err: oxygen.ErrTruncated}
+				}
+			}
+			remaining(dec.required)
+			break
+		}
+		switch i {
+		case 0: // Daily
+			if ok, err := codecPresent(&codecLimits_Daily, v, codecSiblingLimits); err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "!3,0,r", Field: "Daily", Err: err}
+			} else if !ok {
+				continue
+			}
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "Daily", codecTypeOf[int])
+				}
+			}
+			sep = codecRemoveSeparator
+			if codecLimits_Daily.err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "!3,0,r", Field: "Daily", Err: codecLimits_Daily.err}
+			}
+			if _, fn389 := codecRegistered[int](); fn389 != nil {
+				{
+					off390 := dec.offset()
+					p, err := dec.value("Daily", codecLimits_Daily.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off390, err), "Daily", codecTypeOf[int])
+					}
+					if len(p) != 0 {
+						r, err := fn389("Daily", codecLimits_Daily.tag, p)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off390, err), "Daily", codecTypeOf[int])
+						}
+						v.Daily = r
+					} else if codecLimits_Daily.def != nil {
+						v.Daily = *codecLimits_Daily.def.(*int)
+					}
+				}
+			} else {
+				if o := codecLimits_Daily.order; o != nil {
+					off391 := dec.offset()
+					u, err := dec.binary(o, strconv.IntSize/8)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off391, err), "Daily", codecTypeOf[int])
+					}
+					v.Daily = int(codecSigned(u, strconv.IntSize/8))
+				} else {
+					{
+						off392 := dec.offset()
+						p, err := dec.value("Daily", codecLimits_Daily.tag, codecLimits_Daily.format.Encoding == oxygen.PackedDecimal)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off392, err), "Daily", codecTypeOf[int])
+						}
+						if len(p) != 0 {
+							r, err := codecLimits_Daily.format.ParseInt(string(p), strconv.IntSize)
+							v.Daily = int(r)
+							if err != nil {
+								return codecUnmarshalError(dec.typeError(off392, err), "Daily", codecTypeOf[int])
+							}
+						} else if codecLimits_Daily.def != nil {
+							v.Daily = *codecLimits_Daily.def.(*int)
+						}
+					}
+				}
+			}
+		case 1: // Monthly
+			if ok, err := codecPresent(&codecLimits_Monthly, v, codecSiblingLimits); err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "!4,0,r", Field: "Monthly", Err: err}
+			} else if !ok {
+				continue
+			}
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "Monthly", codecTypeOf[int])
+				}
+			}
+			sep = codecRemoveSeparator
+			if codecLimits_Monthly.err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "!4,0,r", Field: "Monthly", Err: codecLimits_Monthly.err}
+			}
+			if _, fn395 := codecRegistered[int](); fn395 != nil {
+				{
+					off396 := dec.offset()
+					p, err := dec.value("Monthly", codecLimits_Monthly.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off396, err), "Monthly", codecTypeOf[int])
+					}
+					if len(p) != 0 {
+						r, err := fn395("Monthly", codecLimits_Monthly.tag, p)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off396, err), "Monthly", codecTypeOf[int])
+						}
+						v.Monthly = r
+					} else if codecLimits_Monthly.def != nil {
+						v.Monthly = *codecLimits_Monthly.def.(*int)
+					}
+				}
+			} else {
+				if o := codecLimits_Monthly.order; o != nil {
+					off397 := dec.offset()
+					u, err := dec.binary(o, strconv.IntSize/8)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off397, err), "Monthly", codecTypeOf[int])
+					}
+					v.Monthly = int(codecSigned(u, strconv.IntSize/8))
+				} else {
+					{
+						off398 := dec.offset()
+						p, err := dec.value("Monthly", codecLimits_Monthly.tag, codecLimits_Monthly.format.Encoding == oxygen.PackedDecimal)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off398, err), "Monthly", codecTypeOf[int])
+						}
+						if len(p) != 0 {
+							r, err := codecLimits_Monthly.format.ParseInt(string(p), strconv.IntSize)
+							v.Monthly = int(r)
+							if err != nil {
+								return codecUnmarshalError(dec.typeError(off398, err), "Monthly", codecTypeOf[int])
+							}
+						} else if codecLimits_Monthly.def != nil {
+							v.Monthly = *codecLimits_Monthly.def.(*int)
+						}
+					}
+				}
+			}
+		}
+	}
+	if unwrap {
+		if codecStrict && len(dec.data) != 0 && !bytes.HasPrefix(dec.data, codecStructCloser) {
+			return &oxygen.SyntaxError{Name: cfg.Name, Offset: dec.offset(), Err: oxygen.ErrTrailingData}
+		}
+		if i := bytes.Index(dec.data, codecStructCloser); i > 0 {
+			dec.data = dec.data[i:]
+		}
+		if err := dec.removePrefix(codecStructCloser); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (enc *codecEncoder) encodeNestedLimits(v *records.Limits, wrap bool) error {
+	if err := enc.enter(); err != nil {
+		return err
+	}
+	err := enc.encodeLimits(v, wrap)
+	enc.leave()
+	return err
+}
+
+func (dec *codecDecoder) decodeNestedLimits(v *records.Limits, unwrap bool) error {
+	if err := dec.enter(); err != nil {
+		return dec.typeError(dec.offset(), err)
+	}
+	err := dec.decodeLimits(v, unwrap)
+	dec.leave()
+	return err
+}
+
+func codecSiblingItem(v *records.Item, name string) any {
+	switch name {
+	case "Qty":
+		return v.Qty
+	case "Note":
+		return v.Note
+	}
+	return nil
+}
+
+func codecRemainingItem(v *records.Item, from int, fn func(string, *codecTag, codecFields) bool) bool {
+	for i := from; i < 2; i++ {
+		switch i {
+		case 0: // Qty
+			if ok, err := codecPresent(&codecItem_Qty, v, codecSiblingItem); (ok || err != nil) && !fn("Qty", &codecItem_Qty, nil) {
+				return false
+			}
+		case 1: // Note
+			if ok, err := codecPresent(&codecItem_Note, v, codecSiblingItem); (ok || err != nil) && !fn("Note", &codecItem_Note, nil) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (enc *codecEncoder) encodeItem(v *records.Item, wrap bool) error {
+	sep := false
+	if wrap {
+		enc.Write(codecStructOpener)
+	}
+	// Qty
+	d399 := v.Qty
+	if codecItem_Qty.encodeDef && d399 == 0 {
+		d399 = *codecItem_Qty.def.(*int)
+	}
+	if ok, err := codecPresent(&codecItem_Qty, v, codecSiblingItem); err != nil {
+		return &oxygen.TagError{Name: cfg.Name, Tag: "!2,0,r", Field: "Qty", Err: err}
+	} else if ok && !(codecItem_Qty.omit && d399 == 0) {
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if codecItem_Qty.err != nil {
+			return &oxygen.TagError{Name: cfg.Name, Tag: "!2,0,r", Field: "Qty", Err: codecItem_Qty.err}
+		}
+		if fn400, _ := codecRegistered[int](); fn400 != nil {
+			p, err := fn400("Qty", codecItem_Qty.tag, d399)
+			if err != nil {
+				return codecMarshalError(err, "Qty", codecTypeOf[int])
+			}
+			if err := enc.value("Qty", codecItem_Qty.tag, p, false); err != nil {
+				return codecMarshalError(err, "Qty", codecTypeOf[int])
+			}
+		} else {
+			if o := codecItem_Qty.order; o != nil {
+				enc.Write(oxygen.AppendBinary(enc.scratch[:0], o, uint64(d399), strconv.IntSize/8))
+			} else {
+				if err := codecItem_Qty.format.CheckInt(int64(d399)); err != nil {
+					return codecMarshalError(err, "Qty", codecTypeOf[int])
+				}
+				if err := enc.value("Qty", codecItem_Qty.tag, codecItem_Qty.format.AppendInt(enc.scratch[:0], int64(d399)), codecItem_Qty.format.Encoding == oxygen.PackedDecimal); err != nil {
+					return codecMarshalError(err, "Qty", codecTypeOf[int])
+				}
+			}
+		}
+	}
+	// Note
+	d405 := v.Note
+	if codecItem_Note.encodeDef && len(d405) == 0 {
+		d405 = *codecItem_Note.def.(*string)
+	}
+	if ok, err := codecPresent(&codecItem_Note, v, codecSiblingItem); err != nil {
+		return &oxygen.TagError{Name: cfg.Name, Tag: "2, ,l", Field: "Note", Err: err}
+	} else if ok && !(codecItem_Note.omit && len(d405) == 0) {
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if codecItem_Note.err != nil {
+			return &oxygen.TagError{Name: cfg.Name, Tag: "2, ,l", Field: "Note", Err: codecItem_Note.err}
+		}
+		if fn406, _ := codecRegistered[string](); fn406 != nil {
+			p, err := fn406("Note", codecItem_Note.tag, d405)
+			if err != nil {
+				return codecMarshalError(err, "Note", codecTypeOf[string])
+			}
+			if err := enc.value("Note", codecItem_Note.tag, p, false); err != nil {
+				return codecMarshalError(err, "Note", codecTypeOf[string])
+			}
+		} else {
+			if err := enc.value("Note", codecItem_Note.tag, append(enc.scratch[:0], string(d405)...), false); err != nil {
+				return codecMarshalError(err, "Note", codecTypeOf[string])
+			}
+		}
+	}
+	if wrap {
+		enc.Write(codecStructCloser)
+	}
+	return nil
+}
+
+func (dec *codecDecoder) decodeItem(v *records.Item, unwrap bool) error {
+	if unwrap {
+		if err := dec.removePrefix(codecStructOpener); err != nil {
+			return err
+		}
+	}
+	sep := false
+	for i := 0; i < 2; i++ {
+		if len(dec.data) == 0 || unwrap && bytes.HasPrefix(dec.data, codecStructCloser) {
+			remaining := func(fn func(string, *codecTag, codecFields) bool) bool {
+				return codecRemainingItem(v, i, fn)
+			}
+			if codecStrict {
+				if name := codecTruncated(remaining); name != "" {
+					return &oxygen.SyntaxError{Name: cfg.Name, Field: name, Offset: dec.offset(), Err: oxygen.ErrTruncated}
+				}
+			}
+			remaining(dec.required)
+			break
+		}
+		switch i {
+		case 0: // Qty
+			if ok, err := codecPresent(&codecItem_Qty, v, codecSiblingItem); err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "!2,0,r", Field: "Qty", Err: err}
+			} else if !ok {
+				continue
+			}
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "Qty", codecTypeOf[int])
+				}
+			}
+			sep = codecRemoveSeparator
+			if codecItem_Qty.err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "!2,0,r", Field: "Qty", Err: codecItem_Qty.err}
+			}
+			if _, fn401 := codecRegistered[int](); fn401 != nil {
+				{
+					off402 := dec.offset()
+					p, err := dec.value("Qty", codecItem_Qty.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off402, err), "Qty", codecTypeOf[int])
+					}
+					if len(p) != 0 {
+						r, err := fn401("Qty", codecItem_Qty.tag, p)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off402, err), "Qty", codecTypeOf[int])
+						}
+						v.Qty = r
+					} else if codecItem_Qty.def != nil {
+						v.Qty = *codecItem_Qty.def.(*int)
+					}
+				}
+			} else {
+				if o := codecItem_Qty.order; o != nil {
+					off403 := dec.offset()
+					u, err := dec.binary(o, strconv.IntSize/8)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off403, err), "Qty", codecTypeOf[int])
+					}
+					v.Qty = int(codecSigned(u, strconv.IntSize/8))
+				} else {
+					{
+						off404 := dec.offset()
+						p, err := dec.value("Qty", codecItem_Qty.tag, codecItem_Qty.format.Encoding == oxygen.PackedDecimal)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off404, err), "Qty", codecTypeOf[int])
+						}
+						if len(p) != 0 {
+							r, err := codecItem_Qty.format.ParseInt(string(p), strconv.IntSize)
+							v.Qty = int(r)
+							if err != nil {
+								return codecUnmarshalError(dec.typeError(off404, err), "Qty", codecTypeOf[int])
+							}
+						} else if codecItem_Qty.def != nil {
+							v.Qty = *codecItem_Qty.def.(*int)
+						}
+					}
+				}
+			}
+		case 1: // Note
+			if ok, err := codecPresent(&codecItem_Note, v, codecSiblingItem); err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "2, ,l", Field: "Note", Err: err}
+			} else if !ok {
+				continue
+			}
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "Note", codecTypeOf[string])
+				}
+			}
+			sep = codecRemoveSeparator
+			if codecItem_Note.err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "2, ,l", Field: "Note", Err: codecItem_Note.err}
+			}
+			if _, fn407 := codecRegistered[string](); fn407 != nil {
+				{
+					off408 := dec.offset()
+					p, err := dec.value("Note", codecItem_Note.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off408, err), "Note", codecTypeOf[string])
+					}
+					if len(p) != 0 {
+						r, err := fn407("Note", codecItem_Note.tag, p)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off408, err), "Note", codecTypeOf[string])
+						}
+						v.Note = r
+					} else if codecItem_Note.def != nil {
+						v.Note = *codecItem_Note.def.(*string)
+					}
+				}
+			} else {
+				{
+					off409 := dec.offset()
+					p, err := dec.value("Note", codecItem_Note.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off409, err), "Note", codecTypeOf[string])
+					}
+					if len(p) != 0 {
+						v.Note = string(p)
+					} else if codecItem_Note.def != nil {
+						v.Note = *codecItem_Note.def.(*string)
+					}
+				}
+			}
+		}
+	}
+	if unwrap {
+		if codecStrict && len(dec.data) != 0 && !bytes.HasPrefix(dec.data, codecStructCloser) {
+			return &oxygen.SyntaxError{Name: cfg.Name, Offset: dec.offset(), Err: oxygen.ErrTrailingData}
+		}
+		if i := bytes.Index(dec.data, codecStructCloser); i > 0 {
+			dec.data = dec.data[i:]
+		}
+		if err := dec.removePrefix(codecStructCloser); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (enc *codecEncoder) encodeNestedItem(v *records.Item, wrap bool) error {
+	if err := enc.enter(); err != nil {
+		return err
+	}
+	err := enc.encodeItem(v, wrap)
+	enc.leave()
+	return err
+}
+
+func (dec *codecDecoder) decodeNestedItem(v *records.Item, unwrap bool) error {
+	if err := dec.enter(); err != nil {
+		return dec.typeError(dec.offset(), err)
+	}
+	err := dec.decodeItem(v, unwrap)
+	dec.leave()
+	return err
+}
+
+func codecSiblingLeg(v *records.Leg, name string) any {
+	switch name {
+	case "From":
+		return v.From
+	case "To":
+		return v.To
+	}
+	return nil
+}
+
+func codecRemainingLeg(v *records.Leg, from int, fn func(string, *codecTag, codecFields) bool) bool {
+	for i := from; i < 2; i++ {
+		switch i {
+		case 0: // From
+			if ok, err := codecPresent(&codecLeg_From, v, codecSiblingLeg); (ok || err != nil) && !fn("From", &codecLeg_From, nil) {
+				return false
+			}
+		case 1: // To
+			if ok, err := codecPresent(&codecLeg_To, v, codecSiblingLeg); (ok || err != nil) && !fn("To", &codecLeg_To, nil) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (enc *codecEncoder) encodeLeg(v *records.Leg, wrap bool) error {
+	sep := false
+	if wrap {
+		enc.Write(codecStructOpener)
+	}
+	// From
+	d410 := v.From
+	if codecLeg_From.encodeDef && d410 == 0 {
+		d410 = *codecLeg_From.def.(*int)
+	}
+	if ok, err := codecPresent(&codecLeg_From, v, codecSiblingLeg); err != nil {
+		return &oxygen.TagError{Name: cfg.Name, Tag: "2,0,r", Field: "From", Err: err}
+	} else if ok && !(codecLeg_From.omit && d410 == 0) {
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if codecLeg_From.err != nil {
+			return &oxygen.TagError{Name: cfg.Name, Tag: "2,0,r", Field: "From", Err: codecLeg_From.err}
+		}
+		if fn411, _ := codecRegistered[int](); fn411 != nil {
+			p, err := fn411("From", codecLeg_From.tag, d410)
+			if err != nil {
+				return codecMarshalError(err, "From", codecTypeOf[int])
+			}
+			if err := enc.value("From", codecLeg_From.tag, p, false); err != nil {
+				return codecMarshalError(err, "From", codecTypeOf[int])
+			}
+		} else {
+			if o := codecLeg_From.order; o != nil {
+				enc.Write(oxygen.AppendBinary(enc.scratch[:0], o, uint64(d410), strconv.IntSize/8))
+			} else {
+				if err := codecLeg_From.format.CheckInt(int64(d410)); err != nil {
+					return codecMarshalError(err, "From", codecTypeOf[int])
+				}
+				if err := enc.value("From", codecLeg_From.tag, codecLeg_From.format.AppendInt(enc.scratch[:0], int64(d410)), codecLeg_From.format.Encoding == oxygen.PackedDecimal); err != nil {
+					return codecMarshalError(err, "From", codecTypeOf[int])
+				}
+			}
+		}
+	}
+	// To
+	d416 := v.To
+	if codecLeg_To.encodeDef && d416 == 0 {
+		d416 = *codecLeg_To.def.(*int)
+	}
+	if ok, err := codecPresent(&codecLeg_To, v, codecSiblingLeg); err != nil {
+		return &oxygen.TagError{Name: cfg.Name, Tag: "2,0,r", Field: "To", Err: err}
+	} else if ok && !(codecLeg_To.omit && d416 == 0) {
+		if sep {
+			enc.Write(codecValueSeparator)
+		}
+		sep = codecSeparate
+		if codecLeg_To.err != nil {
+			return &oxygen.TagError{Name: cfg.Name, Tag: "2,0,r", Field: "To", Err: codecLeg_To.err}
+		}
+		if fn417, _ := codecRegistered[int](); fn417 != nil {
+			p, err := fn417("To", codecLeg_To.tag, d416)
+			if err != nil {
+				return codecMarshalError(err, "To", codecTypeOf[int])
+			}
+			if err := enc.value("To", codecLeg_To.tag, p, false); err != nil {
+				return codecMarshalError(err, "To", codecTypeOf[int])
+			}
+		} else {
+			if o := codecLeg_To.order; o != nil {
+				enc.Write(oxygen.AppendBinary(enc.scratch[:0], o, uint64(d416), strconv.IntSize/8))
+			} else {
+				if err := codecLeg_To.format.CheckInt(int64(d416)); err != nil {
+					return codecMarshalError(err, "To", codecTypeOf[int])
+				}
+				if err := enc.value("To", codecLeg_To.tag, codecLeg_To.format.AppendInt(enc.scratch[:0], int64(d416)), codecLeg_To.format.Encoding == oxygen.PackedDecimal); err != nil {
+					return codecMarshalError(err, "To", codecTypeOf[int])
+				}
+			}
+		}
+	}
+	if wrap {
+		enc.Write(codecStructCloser)
+	}
+	return nil
+}
+
+func (dec *codecDecoder) decodeLeg(v *records.Leg, unwrap bool) error {
+	if unwrap {
+		if err := dec.removePrefix(codecStructOpener); err != nil {
+			return err
+		}
+	}
+	sep := false
+	for i := 0; i < 2; i++ {
+		if len(dec.data) == 0 || unwrap && bytes.HasPrefix(dec.data, codecStructCloser) {
+			remaining := func(fn func(string, *codecTag, codecFields) bool) bool {
+				return codecRemainingLeg(v, i, fn)
+			}
+			if codecStrict {
+				if name := codecTruncated(remaining); name != "" {
+					return &oxygen.SyntaxError{Name: cfg.Name, Field: name, Offset: dec.offset(), Err: oxygen.ErrTruncated}
+				}
+			}
+			remaining(dec.required)
+			break
+		}
+		switch i {
+		case 0: // From
+			if ok, err := codecPresent(&codecLeg_From, v, codecSiblingLeg); err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "2,0,r", Field: "From", Err: err}
+			} else if !ok {
+				continue
+			}
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "From", codecTypeOf[int])
+				}
+			}
+			sep = codecRemoveSeparator
+			if codecLeg_From.err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "2,0,r", Field: "From", Err: codecLeg_From.err}
+			}
+			if _, fn412 := codecRegistered[int](); fn412 != nil {
+				{
+					off413 := dec.offset()
+					p, err := dec.value("From", codecLeg_From.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off413, err), "From", codecTypeOf[int])
+					}
+					if len(p) != 0 {
+						r, err := fn412("From", codecLeg_From.tag, p)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off413, err), "From", codecTypeOf[int])
+						}
+						v.From = r
+					} else if codecLeg_From.def != nil {
+						v.From = *codecLeg_From.def.(*int)
+					}
+				}
+			} else {
+				if o := codecLeg_From.order; o != nil {
+					off414 := dec.offset()
+					u, err := dec.binary(o, strconv.IntSize/8)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off414, err), "From", codecTypeOf[int])
+					}
+					v.From = int(codecSigned(u, strconv.IntSize/8))
+				} else {
+					{
+						off415 := dec.offset()
+						p, err := dec.value("From", codecLeg_From.tag, codecLeg_From.format.Encoding == oxygen.PackedDecimal)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off415, err), "From", codecTypeOf[int])
+						}
+						if len(p) != 0 {
+							r, err := codecLeg_From.format.ParseInt(string(p), strconv.IntSize)
+							v.From = int(r)
+							if err != nil {
+								return codecUnmarshalError(dec.typeError(off415, err), "From", codecTypeOf[int])
+							}
+						} else if codecLeg_From.def != nil {
+							v.From = *codecLeg_From.def.(*int)
+						}
+					}
+				}
+			}
+		case 1: // To
+			if ok, err := codecPresent(&codecLeg_To, v, codecSiblingLeg); err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "2,0,r", Field: "To", Err: err}
+			} else if !ok {
+				continue
+			}
+			if sep {
+				if err := dec.removePrefix(codecValueSeparator); err != nil {
+					return codecUnmarshalError(err, "To", codecTypeOf[int])
+				}
+			}
+			sep = codecRemoveSeparator
+			if codecLeg_To.err != nil {
+				return &oxygen.TagError{Name: cfg.Name, Tag: "2,0,r", Field: "To", Err: codecLeg_To.err}
+			}
+			if _, fn418 := codecRegistered[int](); fn418 != nil {
+				{
+					off419 := dec.offset()
+					p, err := dec.value("To", codecLeg_To.tag, false)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off419, err), "To", codecTypeOf[int])
+					}
+					if len(p) != 0 {
+						r, err := fn418("To", codecLeg_To.tag, p)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off419, err), "To", codecTypeOf[int])
+						}
+						v.To = r
+					} else if codecLeg_To.def != nil {
+						v.To = *codecLeg_To.def.(*int)
+					}
+				}
+			} else {
+				if o := codecLeg_To.order; o != nil {
+					off420 := dec.offset()
+					u, err := dec.binary(o, strconv.IntSize/8)
+					if err != nil {
+						return codecUnmarshalError(dec.typeError(off420, err), "To", codecTypeOf[int])
+					}
+					v.To = int(codecSigned(u, strconv.IntSize/8))
+				} else {
+					{
+						off421 := dec.offset()
+						p, err := dec.value("To", codecLeg_To.tag, codecLeg_To.format.Encoding == oxygen.PackedDecimal)
+						if err != nil {
+							return codecUnmarshalError(dec.typeError(off421, err), "To", codecTypeOf[int])
+						}
+						if len(p) != 0 {
+							r, err := codecLeg_To.format.ParseInt(string(p), strconv.IntSize)
+							v.To = int(r)
+							if err != nil {
+								return codecUnmarshalError(dec.typeError(off421, err), "To", codecTypeOf[int])
+							}
+						} else if codecLeg_To.def != nil {
+							v.To = *codecLeg_To.def.(*int)
+						}
+					}
+				}
+			}
+		}
+	}
+	if unwrap {
+		if codecStrict && len(dec.data) != 0 && !bytes.HasPrefix(dec.data, codecStructCloser) {
+			return &oxygen.SyntaxError{Name: cfg.Name, Offset: dec.offset(), Err: oxygen.ErrTrailingData}
+		}
+		if i := bytes.Index(dec.data, codecStructCloser); i > 0 {
+			dec.data = dec.data[i:]
+		}
+		if err := dec.removePrefix(codecStructCloser); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (enc *codecEncoder) encodeNestedLeg(v *records.Leg, wrap bool) error {
+	if err := enc.enter(); err != nil {
+		return err
+	}
+	err := enc.encodeLeg(v, wrap)
+	enc.leave()
+	return err
+}
+
+func (dec *codecDecoder) decodeNestedLeg(v *records.Leg, unwrap bool) error {
+	off := dec.offset()
+	if err := dec.enter(); err != nil {
+		return dec.typeError(dec.offset(), err)
+	}
+	err := dec.decodeLeg(v, unwrap)
+	dec.leave()
+	if err != nil {
+		return err
+	}
+	if err := v.Validate(); err != nil {
+		return &oxygen.UnmarshalTypeError{Name: cfg.Name, Type: codecTypeOf[records.Leg](), Offset: off, Err: fmt.Errorf("Validate: %w", err)}
+	}
+	return nil
+}
+
+// codecDepth is the nesting of structs and lists, it's limited by MaxDepth as in the engine.
+type codecDepth struct {
+	nesting int
+}
+
+// enter increases the nesting, it returns ErrMaxDepth if the nesting exceeds a non-zero MaxDepth.
+func (d *codecDepth) enter() error {
+	if d.nesting++; cfg.MaxDepth > 0 && d.nesting > cfg.MaxDepth {
+		return oxygen.ErrMaxDepth
+	}
+	return nil
+}
+
+func (d *codecDepth) leave() { d.nesting-- }
+
+// codecRegistered returns the functions registered by oxygen.RegisterType for values of type V, nil if they aren't.
+func codecRegistered[V any]() (func(string, *tag, V) ([]byte, error), func(string, *tag, []byte) (V, error)) {
+	return oxygen.RegisteredType[tag, V](test)
+}
+
+// codecNested returns the fields of the nested struct of type V unless it's decoded by a registered function.
+func codecNested[V any](fields codecFields) codecFields {
+	if _, decode := codecRegistered[V](); decode != nil {
+		return nil
+	}
+	return fields
+}
+
+type codecEncoder struct {
+	*bytes.Buffer
+	codecDepth
+	scratch [64]byte
+}
+
+var codecEncoderPool = sync.Pool{
+	New: func() any {
+		return &codecEncoder{Buffer: new(bytes.Buffer)}
+	},
+}
+
+// value writes the value p of the field with the Encode function and transcodes it to the charset unless raw is set.
+func (enc *codecEncoder) value(fieldName string, tag *tag, p []byte, raw bool) error {
+	start := enc.Len()
+	if err := codecEngine.Encode(fieldName, tag, p, enc); err != nil || raw || cfg.Charset == nil {
+		return err
+	}
+	b := enc.Bytes()[start:]
+	b, err := cfg.Charset.Encode(b[:0], b)
+	enc.Truncate(start + len(b))
+	return err
+}
+
+type codecDecoder struct {
+	data []byte // remaining input
+	size int    // length of input
+
+	src             []byte // input, if the charset is set
+	text            []byte // input decoded from the charset
+	srcPos, textPos int    // positions of the same character in src and text
+
+	missing []string // paths of the required fields the data ended before
+	codecDepth
+}
+
+// codecFields calls fn for fields with their name, their tag and the fields of the nested struct they hold,
+// nested is nil if the field holds no struct decoded field by field. It stops and returns false when fn returns false.
+type codecFields func(fn func(name string, t *codecTag, nested codecFields) bool) bool
+
+// required records the field if it's required, or the required fields of the nested struct it holds.
+func (dec *codecDecoder) required(name string, t *codecTag, nested codecFields) bool {
+	switch {
+	case t != nil && t.required:
+		dec.missing = append(dec.missing, name)
+	case nested != nil:
+		n := len(dec.missing)
+		nested(dec.required)
+		dec.prefix(n, name)
+	}
+	return true
+}
+
+// prefix joins the name of the field or the index of the element to the paths of the missing fields recorded since the n-th.
+func (dec *codecDecoder) prefix(n int, field string) {
+	for i := n; i < len(dec.missing); i++ {
+		if dec.missing[i][0] == '[' {
+			dec.missing[i] = field + dec.missing[i]
+		} else {
+			dec.missing[i] = field + "." + dec.missing[i]
+		}
+	}
+}
+
+// codecTruncated returns the first of the remaining fields that cannot be omitted when empty.
+func codecTruncated(remaining codecFields) (name string) {
+	remaining(func(n string, t *codecTag, _ codecFields) bool {
+		if t != nil && t.omit {
+			return true
+		}
+		name = n
+		return false
+	})
+	return
+}
+
+func (dec *codecDecoder) offset() int64 {
+	return int64(dec.size - len(dec.data))
+}
+
+// value reads the value of the field with the Decode function, transcoded from the charset unless raw is set.
+func (dec *codecDecoder) value(fieldName string, tag *tag, raw bool) ([]byte, error) {
+	in := dec.data
+	if cfg.Charset != nil && !raw {
+		var char [utf8.UTFMax]byte
+		for pos := dec.size - len(dec.data); dec.srcPos < pos; dec.srcPos++ {
+			dec.textPos += len(cfg.Charset.Decode(char[:0], dec.src[dec.srcPos:dec.srcPos+1]))
+		}
+		in = dec.text[dec.textPos:]
+	}
+
+	p, n, err := codecEngine.Decode(fieldName, tag, in)
+	if err != nil {
+		return nil, err
+	}
+	if n < 0 || n > len(in) {
+		return nil, fmt.Errorf("consumed %d bytes out of %d remaining", n, len(in))
+	}
+
+	if cfg.Charset != nil && !raw {
+		// Every character of the charset takes one byte of the remaining data.
+		var i, chars int
+		for ; i < n; chars++ {
+			_, size := utf8.DecodeRune(in[i:])
+			i += size
+		}
+		dec.srcPos += chars
+		dec.textPos += i
+		n = chars
+	}
+	dec.data = dec.data[n:]
+	return p, nil
+}
+
+func (dec *codecDecoder) binary(order binary.ByteOrder, size int) (uint64, error) {
+	if len(dec.data) < size {
+		return 0, io.ErrUnexpectedEOF
+	}
+	u := oxygen.ParseBinary(order, dec.data[:size])
+	dec.data = dec.data[size:]
+	return u, nil
+}
+
+func (dec *codecDecoder) removePrefix(b []byte) error {
+	if !bytes.HasPrefix(dec.data, b) {
+		return &oxygen.SyntaxError{Name: cfg.Name, Offset: dec.offset(), Err: oxygen.ErrInvalidFormat}
+	}
+	dec.data = dec.data[len(b):]
+	return nil
+}
+
+func (dec *codecDecoder) endOf(unwrap bool, closer []byte) bool {
+	if len(dec.data) == 0 {
+		return true
+	}
+	if unwrap && len(closer) != 0 {
+		return bytes.HasPrefix(dec.data, closer)
+	}
+	return codecRemoveWrapper && bytes.HasPrefix(dec.data, codecStructCloser)
+}
+
+func (dec *codecDecoder) typeError(offset int64, err error) error {
+	return &oxygen.UnmarshalTypeError{Name: cfg.Name, Offset: offset, Err: codecUnwrap(err)}
+}
+
+// codecTag is the parsed tag of a field.
+type codecTag struct {
+	tag       *tag
+	omit      bool
+	format    oxygen.NumberFormat
+	order     binary.ByteOrder // nil if primitives are written as text
+	count     string           // name of the field holding the number of elements of the slice
+	required  bool
+	presence  *oxygen.Presence // nil if the field is always present
+	def       any              // pointer to the default value, nil if the field has none
+	encodeDef bool             // the default value is written instead of the zero value
+	err       error
+}
+
+// codecParse parses the tag of the field, counts are the names of the integer fields
+// declared before the field that can hold its length, nil if the field isn't a slice,
+// before are the names of all the fields declared before the field, def is a pointer to a value of its type.
+func codecParse(fieldName, tagValue string, counts, before []string, def any) (t codecTag) {
+	t.tag, t.format, t.order = new(tag), cfg.NumberFormat, codecOrder
+	if t.omit, t.err = codecEngine.Parse(tagValue, t.tag); t.err != nil {
+		return
+	}
+	if nf, ok := any(codecEngine).(oxygen.NumberFormatter[tag]); ok {
+		if f, ok := nf.NumberFormat(fieldName, t.tag); ok {
+			if t.format, t.err = f, f.Validate(); t.err != nil {
+				return
+			}
+		}
+	}
+	if ps, ok := any(codecEngine).(oxygen.PrimitivesSelector[tag]); ok {
+		if p, o, ok := ps.Primitives(fieldName, t.tag); ok {
+			if p != oxygen.Text && p != oxygen.Binary {
+				t.err = fmt.Errorf("invalid primitives %d", p)
+				return
+			}
+			t.order = codecByteOrder(p, o)
+		}
+	}
+	if cb, ok := any(codecEngine).(oxygen.CountBinder[tag]); ok {
+		if name, ok := cb.CountField(fieldName, t.tag); ok {
+			if counts == nil {
+				t.err = errors.New("only the length of a slice can be bound")
+				return
+			}
+			if !codecContains(counts, name) {
+				t.err = fmt.Errorf("no integer field %s precedes the field", name)
+				return
+			}
+			t.count = name
+		}
+	}
+	if ps, ok := any(codecEngine).(oxygen.PresenceSelector[tag]); ok {
+		if p, ok := ps.Presence(fieldName, t.tag); ok {
+			if p.Func == "" && !codecContains(before, p.Field) {
+				t.err = fmt.Errorf("no field %s precedes the field", p.Field)
+				return
+			}
+			t.presence = &p
+		}
+	}
+	if rs, ok := any(codecEngine).(oxygen.RequiredSelector[tag]); ok {
+		t.required = rs.Required(fieldName, t.tag)
+	}
+	if d, ok := any(codecEngine).(oxygen.Defaulter[tag]); ok {
+		if value, encode, ok := d.DefaultValue(fieldName, t.tag); ok {
+			if t.err = oxygen.ParseDefault(value, def); t.err != nil {
+				return
+			}
+			t.def, t.encodeDef = def, encode
+		}
+	}
+	return
+}
+
+func codecContains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// codecPresent reports whether the field with the tag t is present in the struct v,
+// sibling returns the value of the field of v with the name.
+func codecPresent[V any](t *codecTag, v *V, sibling func(*V, string) any) (bool, error) {
+	p := t.presence
+	if p == nil {
+		return true, nil
+	}
+	if p.Func != "" {
+		fn, err := oxygen.Predicate[V](test, p.Func)
+		if err != nil {
+			return false, err
+		}
+		return fn(v), nil
+	}
+	s := fmt.Sprint(sibling(v, p.Field))
+	for _, value := range p.Values {
+		if s == value {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+type codecInteger interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// codecLength returns the length n of a slice as the value of the count field.
+func codecLength[T codecInteger](n int) (T, error) {
+	if v := T(n); int(v) == n {
+		return v, nil
+	}
+	return 0, fmt.Errorf("length %d of the slice overflows the count", n)
+}
+
+// codecCount returns the number of elements held by the count field.
+func codecCount[T codecInteger](c T) (uint64, error) {
+	if c < 0 {
+		return 0, fmt.Errorf("negative count %d of elements", int64(c))
+	}
+	return uint64(c), nil
+}
+
+func codecByteOrder(p oxygen.Primitives, order binary.ByteOrder) binary.ByteOrder {
+	if p != oxygen.Binary {
+		return nil
+	}
+	if order == nil {
+		return binary.BigEndian
+	}
+	return order
+}
+
+func codecDelimiter(b []byte) []byte {
+	if cfg.Charset == nil || len(b) == 0 {
+		return b
+	}
+	p, err := cfg.Charset.Encode(nil, b)
+	if err != nil {
+		return b
+	}
+	return p
+}
+
+func codecBinaryBool(b bool) uint64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func codecParseBinaryBool(u uint64) (bool, error) {
+	switch u {
+	case 0:
+		return false, nil
+	case 1:
+		return true, nil
+	default:
+		return false, fmt.Errorf("invalid binary bool %#x", u)
+	}
+}
+
+func codecSigned(u uint64, size int) int64 {
+	switch size {
+	case 1:
+		return int64(int8(u))
+	case 2:
+		return int64(int16(u))
+	case 4:
+		return int64(int32(u))
+	default:
+		return int64(u)
+	}
+}
+
+func codecFloatBits(f float64, size int) uint64 {
+	if size == 4 {
+		return uint64(math.Float32bits(float32(f)))
+	}
+	return math.Float64bits(f)
+}
+
+func codecFloatFrom(u uint64, size int) float64 {
+	if size == 4 {
+		return float64(math.Float32frombits(uint32(u)))
+	}
+	return math.Float64frombits(u)
+}
+
+func codecTypeOf[V any]() reflect.Type {
+	return reflect.TypeOf((*V)(nil)).Elem()
+}
+
+func codecUnwrap(err error) error {
+	var ne *strconv.NumError
+	if errors.As(err, &ne) {
+		return ne.Err
+	}
+	return err
+}
+
+func codecJoin(field, path string) string {
+	if path == "" {
+		return field
+	}
+	if field == "" {
+		return path
+	}
+	return field + "." + path
+}
+
+func codecMarshalError(err error, field string, typ func() reflect.Type) error {
+	switch e := err.(type) {
+	case *oxygen.MarshalTypeError:
+		e.Field = codecJoin(field, e.Field)
+	case *oxygen.TagError:
+		e.Field = codecJoin(field, e.Field)
+	default:
+		return &oxygen.MarshalTypeError{Name: cfg.Name, Field: field, Type: typ(), Err: codecUnwrap(err)}
+	}
+	return err
+}
+
+func codecUnmarshalError(err error, field string, typ func() reflect.Type) error {
+	switch e := err.(type) {
+	case *oxygen.UnmarshalTypeError:
+		if e.Type == nil {
+			e.Type = typ()
+		}
+		e.Field = codecJoin(field, e.Field)
+	case *oxygen.SyntaxError:
+		e.Field = codecJoin(field, e.Field)
+	case *oxygen.TagError:
+		e.Field = codecJoin(field, e.Field)
+	default:
+		if errors.Is(err, oxygen.ErrPointerToUnexported) {
+			return err
+		}
+		return &oxygen.UnmarshalTypeError{Name: cfg.Name, Field: field, Type: typ(), Err: codecUnwrap(err)}
+	}
+	return err
+}
+
+func codecRoot(err error, structName string) error {
+	switch e := err.(type) {
+	case *oxygen.MarshalTypeError:
+		e.Struct = structName
+	case *oxygen.UnmarshalTypeError:
+		e.Struct = structName
+	case *oxygen.SyntaxError:
+		e.Struct = structName
+	case *oxygen.TagError:
+		e.Struct = structName
+	}
+	return err
+}
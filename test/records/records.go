@@ -0,0 +1,165 @@
+// Package records declares the types the reflection-free codec of the test formatter is generated for.
+package records
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gromey/oxygen"
+)
+
+type Header struct {
+	Kind string `test:"1"`
+	Rev  uint8  `test:"2,0,r"`
+}
+
+type Order struct {
+	Header
+	ID      int     `test:"4,0,r"`
+	Paid    bool    `test:"5, ,l"`
+	Amount  float64 `test:"6,0,r"`
+	Code    Code    `test:"3,_,l"`
+	Note    *string `test:"6,_,l"`
+	Raw     []byte  `test:"3, ,l"`
+	Lines   []Line  `test:"-"`
+	Items   []Line
+	Pins    [2]uint16 `test:"3,0,r"`
+	Next    *Line
+	State   State          `test:"3, ,l"`
+	Created time.Time      `test:"20, ,l"`
+	Tags    []string       `test:"2"`
+	Extra   string         `test:"2, ,l"`
+	Cents   float64        `test:"7,0,r,i2"`
+	Hex     uint32         `test:"4,0,r,x16"`
+	Parts   uint8          `test:"1,0,r"`
+	Sizes   []int16        `test:"2,0,r,,Parts"`
+	Port    int16          `test:"0, ,l,le"`
+	Ratio   float32        `test:"0, ,l,be"`
+	Total   oxygen.Decimal `test:"3,_,r,p5.2"`
+	Count   int64          `test:"4,0,r,z4"`
+	hidden  int
+}
+
+type Line struct {
+	Qty   int   `test:"2,0,r"`
+	Price *uint `test:"3,0,r"`
+}
+
+// Batch has required fields, in a nested struct and in the elements of a list.
+type Batch struct {
+	ID     int `test:"!4,0,r"`
+	Limits Limits
+	Items  []Item
+}
+
+type Limits struct {
+	Daily   int `test:"!3,0,r"`
+	Monthly int `test:"!4,0,r"`
+}
+
+type Item struct {
+	Qty  int    `test:"!2,0,r"`
+	Note string `test:"2, ,l"`
+}
+
+// Address has fields decoded as their default value when blank.
+type Address struct {
+	Street  string `test:"6, ,l"`
+	Country string `test:"2, ,l,,,,*US"`
+	Floor   *uint8 `test:"2,0,r,,,,1"`
+}
+
+// Payment has fields present only when a condition holds.
+type Payment struct {
+	Amount   int    `test:"4,0,r"`
+	Foreign  string `test:"1"`
+	Currency string `test:"3,_,l,,,Foreign=Y"`
+	Rate     uint16 `test:"4,0,r,,,@rated"`
+}
+
+// Reading holds numbers written as packed decimals of 5 digits.
+type Reading struct {
+	Value float64        `test:"3, ,l,p5.2"`
+	Count int32          `test:"3, ,l,p5"`
+	Units uint32         `test:"3, ,l,p5"`
+	Total oxygen.Decimal `test:"3, ,l,p5.2"`
+}
+
+// Ticket has hooks run around its encoding and the encoding of its legs.
+type Ticket struct {
+	Code Code `test:"3,_,l"`
+	Legs []Leg
+}
+
+type Leg struct {
+	From int `test:"2,0,r"`
+	To   int `test:"2,0,r"`
+}
+
+// BeforeMarshal writes the code in upper case.
+func (t *Ticket) BeforeMarshal() error {
+	t.Code = Code(strings.ToUpper(string(t.Code)))
+	return nil
+}
+
+// AfterUnmarshal reads the code in upper case.
+func (t *Ticket) AfterUnmarshal() error {
+	t.Code = Code(strings.ToUpper(string(t.Code)))
+	return nil
+}
+
+// Validate rejects legs going backwards.
+func (l *Leg) Validate() error {
+	if l.To < l.From {
+		return errors.New("leg going backwards")
+	}
+	return nil
+}
+
+// Timer holds durations encoded by the functions the test formatter registers for time.Duration.
+type Timer struct {
+	Took time.Duration    `test:"8, ,l"`
+	Best *time.Duration   `test:"5, ,l"`
+	Laps []time.Duration  `test:"4, ,l"`
+	Next *[]time.Duration `test:"3, ,l"`
+}
+
+// Node nests as deep as its children go, the test formatter limits the depth.
+type Node struct {
+	ID   int `test:"2,0,r"`
+	Kids []Node
+}
+
+type Code string
+
+// State is encoded with the methods of the test formatter instead of its kind.
+type State int
+
+const (
+	New State = iota
+	Done
+)
+
+func (s *State) MarshalTEST() ([]byte, error) {
+	switch *s {
+	case New:
+		return []byte("NEW"), nil
+	case Done:
+		return []byte("END"), nil
+	default:
+		return nil, errors.New("unknown state")
+	}
+}
+
+func (s *State) UnmarshalTEST(data []byte) error {
+	switch string(data) {
+	case "NEW":
+		*s = New
+	case "END":
+		*s = Done
+	default:
+		return errors.New("unknown state")
+	}
+	return nil
+}
@@ -2,21 +2,39 @@ package test
 
 import (
 	"bytes"
+	"encoding/binary"
 	"fmt"
+	"io"
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gromey/oxygen"
 )
 
 var (
 	cfg = oxygen.Config{
-		StructOpener:                []byte("{"),
-		StructCloser:                []byte("}"),
-		UnwrapWhenDecoding:          true,
-		ValueSeparator:              []byte(","),
-		RemoveSeparatorWhenDecoding: true,
+		StructOpener:                        []byte("{"),
+		StructCloser:                        []byte("}"),
+		UnwrapWhenDecoding:                  true,
+		ValueSeparator:                      []byte(","),
+		RemoveSeparatorWhenDecoding:         true,
+		ListOpener:                          []byte("["),
+		ListCloser:                          []byte("]"),
+		UnwrapListWhenDecoding:              true,
+		ElementSeparator:                    []byte(";"),
+		RemoveElementSeparatorWhenDecoding:  true,
+		MapOpener:                           []byte("("),
+		MapCloser:                           []byte(")"),
+		UnwrapMapWhenDecoding:               true,
+		KeyValueSeparator:                   []byte(":"),
+		RemoveKeyValueSeparatorWhenDecoding: true,
+		PairSeparator:                       []byte(";"),
+		RemovePairSeparatorWhenDecoding:     true,
+		RecordTerminator:                    []byte("\n"),
+		DisableTextMarshaler:                false,
+		MaxDepth:                            8,
 		// WARNING: DO NOT DELETE CONFIGURATIONS BELOW!
 		Name:        "test",
 		Marshaller:  reflect.TypeOf((*Marshaller)(nil)).Elem(),
@@ -25,6 +43,15 @@ var (
 	test = oxygen.New[tag](&engine{}, cfg)
 )
 
+func init() {
+	// Durations are encoded as the time.Duration.String does.
+	oxygen.RegisterType(test, func(_ string, _ *tag, d time.Duration) ([]byte, error) {
+		return []byte(d.String()), nil
+	}, func(_ string, _ *tag, data []byte) (time.Duration, error) {
+		return time.ParseDuration(string(data))
+	})
+}
+
 // Marshal encodes the value v and returns the encoded data.
 func Marshal(v any) ([]byte, error) {
 	return test.Marshal(v)
@@ -35,6 +62,38 @@ func Unmarshal(b []byte, v any) error {
 	return test.Unmarshal(b, v)
 }
 
+// NewEncoder returns a new encoder that writes records to w.
+func NewEncoder(w io.Writer) oxygen.Encoder {
+	return test.NewEncoder(w)
+}
+
+// NewDecoder returns a new decoder that reads records from r.
+func NewDecoder(r io.Reader) oxygen.Decoder {
+	return test.NewDecoder(r)
+}
+
+// NewRecordSet returns a new empty set of record types told apart by a leading code.
+func NewRecordSet() oxygen.RecordSet {
+	return test.NewRecordSet()
+}
+
+// Register records the type of the value v under the discriminator,
+// so that it can be decoded into nil interface values.
+func Register(discriminator string, v any) {
+	test.Register(discriminator, v)
+}
+
+// RegisterPredicate registers a function deciding the presence of fields of the struct type V,
+// fields select it by its name.
+func RegisterPredicate[V any](name string, fn func(v *V) bool) {
+	oxygen.RegisterPredicate(test, name, fn)
+}
+
+// Describe returns the layout of the struct type of the value v, or of the struct v points to.
+func Describe(v any) (oxygen.Layout, error) {
+	return test.Describe(v)
+}
+
 type engine struct {
 	oxygen.Default[tag]
 }
@@ -43,6 +102,13 @@ type tag struct {
 	Len    int
 	Filler byte
 	Align  byte
+	Format *oxygen.NumberFormat
+	Order  binary.ByteOrder
+	Count  string
+	If     *oxygen.Presence
+	Req    bool
+	Def    *string
+	PutDef bool
 }
 
 // Parse gets a tagValue string, parses the tagValue into tag *tag,
@@ -53,6 +119,10 @@ func (e *engine) Parse(tagValue string, tag *tag) (omit bool, err error) {
 	for i, v := range tagParts {
 		switch i {
 		case 0:
+			// A length starting with ! marks a required field.
+			if strings.HasPrefix(v, "!") {
+				tag.Req, v = true, v[1:]
+			}
 			if tag.Len, err = strconv.Atoi(v); err != nil {
 				return
 			}
@@ -66,12 +136,146 @@ func (e *engine) Parse(tagValue string, tag *tag) (omit bool, err error) {
 				return
 			}
 			tag.Align = v[0]
+		case 3:
+			switch v {
+			case "":
+			case "be":
+				tag.Order = binary.BigEndian
+			case "le":
+				tag.Order = binary.LittleEndian
+			default:
+				if tag.Format, err = parseFormat(v); err != nil {
+					return
+				}
+			}
+		case 4:
+			tag.Count = v
+		case 5:
+			if v != "" {
+				tag.If = parsePresence(v)
+			}
+		case 6:
+			// A default value starting with * is also written instead of the zero value.
+			if strings.HasPrefix(v, "*") {
+				tag.PutDef, v = true, v[1:]
+			}
+			tag.Def = &v
 		}
 	}
 
 	return
 }
 
+// parseFormat parses the format of numbers: xN for the base N of integers,
+// a float verb followed by a precision, iN for N implied decimals,
+// or pN.D and zN.D for packed and zoned decimals of N digits with D implied decimals.
+func parseFormat(v string) (*oxygen.NumberFormat, error) {
+	if len(v) < 2 {
+		return nil, fmt.Errorf("invalid number format %q", v)
+	}
+
+	if v[0] == 'p' || v[0] == 'z' {
+		f := &oxygen.NumberFormat{Encoding: oxygen.PackedDecimal}
+		if v[0] == 'z' {
+			f.Encoding = oxygen.ZonedDecimal
+		}
+		digits, decimals, _ := strings.Cut(v[1:], ".")
+		var err error
+		if f.Digits, err = strconv.Atoi(digits); err != nil {
+			return nil, err
+		}
+		if decimals != "" {
+			if f.ImpliedDecimals, err = strconv.Atoi(decimals); err != nil {
+				return nil, err
+			}
+		}
+		return f, nil
+	}
+
+	n, err := strconv.Atoi(v[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	switch v[0] {
+	case 'x':
+		return &oxygen.NumberFormat{Base: n}, nil
+	case 'i':
+		return &oxygen.NumberFormat{ImpliedDecimals: n}, nil
+	default:
+		return &oxygen.NumberFormat{Verb: v[0], Precision: n}, nil
+	}
+}
+
+// parsePresence parses the condition of the presence of a field:
+// @name for a registered predicate or Field=V1|V2 for the values of a field.
+func parsePresence(v string) *oxygen.Presence {
+	if strings.HasPrefix(v, "@") {
+		return &oxygen.Presence{Func: v[1:]}
+	}
+	field, values, _ := strings.Cut(v, "=")
+	return &oxygen.Presence{Field: field, Values: strings.Split(values, "|")}
+}
+
+// NumberFormat returns the format of numbers set in the tag.
+func (e *engine) NumberFormat(_ string, tag *tag) (oxygen.NumberFormat, bool) {
+	if tag.Format == nil {
+		return oxygen.NumberFormat{}, false
+	}
+	return *tag.Format, true
+}
+
+// Primitives returns binary primitives if the tag sets a byte order: be or le.
+func (e *engine) Primitives(_ string, tag *tag) (oxygen.Primitives, binary.ByteOrder, bool) {
+	if tag.Order == nil {
+		return oxygen.Text, nil, false
+	}
+	return oxygen.Binary, tag.Order, true
+}
+
+// CountField returns the name of the field holding the number of elements of a slice if the tag sets it.
+func (e *engine) CountField(_ string, tag *tag) (string, bool) {
+	return tag.Count, tag.Count != ""
+}
+
+// Presence returns the condition of the presence of a field if the tag sets it.
+func (e *engine) Presence(_ string, tag *tag) (oxygen.Presence, bool) {
+	if tag.If == nil {
+		return oxygen.Presence{}, false
+	}
+	return *tag.If, true
+}
+
+// Required reports whether the tag marks a required field.
+func (e *engine) Required(_ string, tag *tag) bool {
+	return tag.Req
+}
+
+// DefaultValue returns the default value of the field if the tag sets it.
+func (e *engine) DefaultValue(_ string, tag *tag) (string, bool, bool) {
+	if tag.Def == nil {
+		return "", false, false
+	}
+	return *tag.Def, tag.PutDef, true
+}
+
+// Measure returns the length set in the tag as the width of the field.
+func (e *engine) Measure(_ string, tag *tag) (int, int, bool) {
+	return -1, tag.Len, tag.Len != 0
+}
+
+// Padding returns the alignment and the filler set in the tag if it sets the length.
+func (e *engine) Padding(_ string, tag *tag) (oxygen.Alignment, byte, bool) {
+	switch {
+	case tag.Len == 0:
+		return oxygen.NoAlignment, 0, false
+	case tag.Align == 'l':
+		return oxygen.LeftAlignment, tag.Filler, true
+	default:
+		return oxygen.RightAlignment, tag.Filler, true
+	}
+}
+
 // Encode takes encoded data and performs secondary encoding to TEST format.
 func (e *engine) Encode(_ string, tag *tag, in []byte, out oxygen.Writer) (err error) {
 	if tag == nil || len(in) == tag.Len || tag.Len == 0 {
@@ -105,22 +309,34 @@ func (e *engine) Encode(_ string, tag *tag, in []byte, out oxygen.Writer) (err e
 }
 
 // Decode takes the raw encoded data and performs a primary decode from TEST format.
-func (e *engine) Decode(_ string, tag *tag, in []byte, out oxygen.Writer) (err error) {
+func (e *engine) Decode(_ string, tag *tag, in []byte) (value []byte, n int, err error) {
 	if tag == nil || tag.Len == 0 {
-		_, err = out.Write(in)
-		return
+		return in, len(in), nil
+	}
+
+	if len(in) < tag.Len {
+		return nil, 0, fmt.Errorf("data for decoding [%d] less than field length [%d]", len(in), tag.Len)
 	}
 
 	if tag.Align == 'l' {
-		_, err = out.Write(bytes.TrimRight(in[:tag.Len], string(tag.Filler)))
+		value = bytes.TrimRight(in[:tag.Len], string(tag.Filler))
 	} else {
-		_, err = out.Write(bytes.TrimLeft(in[:tag.Len], string(tag.Filler)))
+		value = bytes.TrimLeft(in[:tag.Len], string(tag.Filler))
 	}
-	if err != nil {
-		return
+
+	return value, tag.Len, nil
+}
+
+// Discriminate returns the first value of a structure as the discriminator of its type.
+func (e *engine) Discriminate(_ string, _ *tag, in []byte) (string, int, error) {
+	if !bytes.HasPrefix(in, cfg.StructOpener) {
+		return "", 0, oxygen.ErrInvalidFormat
 	}
 
-	copy(in, in[tag.Len:])
+	in = in[len(cfg.StructOpener):]
+	if i := bytes.IndexAny(in, string(cfg.ValueSeparator)+string(cfg.StructCloser)); i >= 0 {
+		return string(in[:i]), 0, nil
+	}
 
-	return
+	return "", 0, oxygen.ErrInvalidFormat
 }
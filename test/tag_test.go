@@ -1,11 +1,21 @@
 package test_test
 
 import (
+	"bytes"
+	"encoding/binary"
 	"errors"
+	"io"
+	"math"
+	"net/netip"
 	"reflect"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/gromey/oxygen"
 	"github.com/gromey/oxygen/test"
+	"github.com/gromey/oxygen/test/records"
 )
 
 func equal(t *testing.T, exp, got interface{}) {
@@ -181,6 +191,41 @@ var npt = nestedPtrType{
 	I: 7,
 }
 
+type listTypes struct {
+	Ints  []int     `test:"4,0,r"`
+	Arr   [3]string `test:"3,_,l"`
+	Bytes [][]byte  `test:"2, ,l"`
+	Subs  []sub
+	Empty []int `test:"4,0,r"`
+}
+
+var lt = listTypes{
+	Ints:  []int{1, 2},
+	Arr:   [3]string{"a", "bc", ""},
+	Bytes: [][]byte{[]byte("ab"), []byte("cd")},
+	Subs: []sub{
+		{Str: "Sub test", PStr: &Str},
+		{Str: "Sub", PStr: &Str},
+	},
+}
+
+type mapTypes struct {
+	Str   map[string]int     `test:"4,0,r"`
+	Int   map[int]string     `test:"3,_,l"`
+	Text  map[netip.Addr]int `test:"4,0,r"`
+	Empty map[string]int     `test:"4,0,r"`
+}
+
+var mt = mapTypes{
+	Str:  map[string]int{"b": 2, "a": 1},
+	Int:  map[int]string{2: "two", 1: "one"},
+	Text: map[netip.Addr]int{netip.MustParseAddr("10.0.0.1"): 1},
+}
+
+type badMapType struct {
+	M map[float64]int `test:"4,0,r"`
+}
+
 func TestMarshal(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -233,6 +278,21 @@ func TestMarshal(t *testing.T) {
 			input:  npt,
 			expect: []byte("{Sub test??,------test,0007}"),
 		},
+		{
+			name:   "struct with slices and arrays",
+			input:  lt,
+			expect: []byte("{[0001;0002],[a__;bc_;___],[ab;cd],[{Sub test??,------test};{Sub???????,------test}],[]}"),
+		},
+		{
+			name:   "struct with maps",
+			input:  mt,
+			expect: []byte("{(a:0001;b:0002),(1:one;2:two),(10.0.0.1:0001),()}"),
+		},
+		{
+			name:  "map with unsupported key type",
+			input: badMapType{M: map[float64]int{1: 1}},
+			err:   errors.New("test: cannot encode data from Go struct field badMapType.M of type map[float64]int: cannot support type"),
+		},
 	}
 
 	for _, tt := range tests {
@@ -316,6 +376,36 @@ func TestUnmarshal(t *testing.T) {
 			output: &nestedPtrType{sub: &sub{}},
 			expect: &npt,
 		},
+		{
+			name:   "struct with slices and arrays",
+			input:  []byte("{[0001;0002],[a__;bc_;___],[ab;cd],[{Sub test??,------test};{Sub???????,------test}],[]}"),
+			output: new(listTypes),
+			expect: &lt,
+		},
+		{
+			name:   "array with fewer elements",
+			input:  []byte("{[],[a__]}"),
+			output: &listTypes{Arr: [3]string{"x", "y", "z"}},
+			expect: &listTypes{Arr: [3]string{"a", "", ""}},
+		},
+		{
+			name:   "array with more elements",
+			input:  []byte("{[],[a__;b__;c__;d__]}"),
+			output: new(listTypes),
+			err:    errors.New("test: syntax error at offset 16 in Go struct field listTypes.Arr: the raw data has an invalid format for an object value"),
+		},
+		{
+			name:   "struct with maps",
+			input:  []byte("{(a:0001;b:0002),(1:one;2:two),(10.0.0.1:0001),()}"),
+			output: new(mapTypes),
+			expect: &mt,
+		},
+		{
+			name:   "map key: invalid syntax",
+			input:  []byte("{(),(x:one)}"),
+			output: new(mapTypes),
+			err:    errors.New("test: cannot decode data into Go struct field mapTypes.Int of type map[int]string: invalid syntax"),
+		},
 		{
 			name:   "Unmarshal(non-pointer struct)",
 			input:  []byte("{Sub test??,------test,0007}"),
@@ -338,13 +428,13 @@ func TestUnmarshal(t *testing.T) {
 			name:   "invalid format for an object value",
 			input:  []byte("{Sub test??,------test_,0007}"),
 			output: &nestedType{},
-			err:    errors.New("test: the raw data has an invalid format for an object value"),
+			err:    errors.New("test: syntax error at offset 22 in Go struct field nestedType.I: the raw data has an invalid format for an object value"),
 		},
 		{
 			name:   "invalid format for an object value 2",
 			input:  []byte("Sub test??,------test,0007}"),
 			output: &nestedType{},
-			err:    errors.New("test: the raw data has an invalid format for an object value"),
+			err:    errors.New("test: syntax error at offset 0: the raw data has an invalid format for an object value"),
 		},
 	}
 
@@ -361,6 +451,1420 @@ func TestUnmarshal(t *testing.T) {
 	}
 }
 
+type shape interface {
+	Area() int
+}
+
+type square struct {
+	Kind string `test:"1"`
+	Side int    `test:"2,0,r"`
+}
+
+func (s square) Area() int {
+	return s.Side * s.Side
+}
+
+type rect struct {
+	Kind string `test:"1"`
+	W    int    `test:"2,0,r"`
+	H    int    `test:"2,0,r"`
+}
+
+func (r *rect) Area() int {
+	return r.W * r.H
+}
+
+type shapes struct {
+	Shapes []shape
+	Any    any
+}
+
+func init() {
+	test.Register("S", square{})
+	test.Register("R", &rect{})
+}
+
+func TestRegister(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []byte
+		expect *shapes
+		err    error
+	}{
+		{
+			name:  "registered types",
+			input: []byte("{[{S,02};{R,02,03}],{S,04}}"),
+			expect: &shapes{
+				Shapes: []shape{square{Kind: "S", Side: 2}, &rect{Kind: "R", W: 2, H: 3}},
+				Any:    square{Kind: "S", Side: 4},
+			},
+		},
+		{
+			name:  "unknown discriminator",
+			input: []byte("{[{C,02}]}"),
+			err:   errors.New("test: cannot decode data into Go struct field shapes.Shapes of type []test_test.shape: no type registered for discriminator \"C\""),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := new(shapes)
+			err := test.Unmarshal(tt.input, output)
+			if tt.err != nil {
+				equal(t, tt.err.Error(), err.Error())
+				equal(t, true, errors.Is(err, oxygen.ErrUnknownType))
+				return
+			}
+			equal(t, nil, err)
+			equal(t, tt.expect, output)
+
+			data, err := test.Marshal(output)
+			equal(t, nil, err)
+			equal(t, tt.input, data)
+		})
+	}
+}
+
+type textTypes struct {
+	Time time.Time   `test:"20, ,l"`
+	Addr netip.Addr  `test:"15, ,l"`
+	PIP  *netip.Addr `test:"8, ,l"`
+}
+
+func TestTextMarshaler(t *testing.T) {
+	addr := netip.MustParseAddr("10.0.0.1")
+	input := textTypes{
+		Time: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Addr: netip.MustParseAddr("192.168.0.1"),
+		PIP:  &addr,
+	}
+
+	data, err := test.Marshal(input)
+	equal(t, nil, err)
+	equal(t, "{2024-01-02T03:04:05Z,192.168.0.1    ,10.0.0.1}", string(data))
+
+	output := new(textTypes)
+	equal(t, nil, test.Unmarshal(data, output))
+	equal(t, &input, output)
+
+	data, err = noText.Marshal(input)
+	equal(t, nil, err)
+	equal(t, ",,", string(data))
+}
+
+type durationTypes struct {
+	D  time.Duration  `test:"8, ,l"`
+	PD *time.Duration `test:"5, ,l"`
+}
+
+func TestRegisterType(t *testing.T) {
+	d := 90 * time.Second
+	input := durationTypes{D: time.Hour + 2*time.Minute + 3*time.Second, PD: &d}
+
+	data, err := test.Marshal(input)
+	equal(t, nil, err)
+	equal(t, "{1h2m3s  ,1m30s}", string(data))
+
+	output := new(durationTypes)
+	pd := 63 * time.Second
+	equal(t, nil, test.Unmarshal([]byte("{1h2m3s  ,1m3s }"), output))
+	equal(t, &durationTypes{D: input.D, PD: &pd}, output)
+
+	err = test.Unmarshal([]byte("{1h2m3x  }"), output)
+	equal(t, "test: cannot decode data into Go struct field durationTypes.D of type time.Duration: time: unknown unit \"x\" in duration \"1h2m3x\"", err.Error())
+
+	best, laps := 42*time.Second, []time.Duration{time.Minute, 5 * time.Second}
+	codecParity(t, test.MarshalTimer, test.UnmarshalTimer, []records.Timer{
+		{},
+		{Took: input.D, Best: &best, Laps: laps},
+		{Took: time.Millisecond, Laps: laps, Next: &[]time.Duration{time.Second, 2 * time.Hour}},
+	}, []string{
+		"{1h2m3x  }",
+		"{1h2m3s  ,42s  ,[1m0s;5x  ]}",
+	})
+}
+
+type line struct {
+	Qty int `test:"2,0,r"`
+}
+
+type order struct {
+	ID    int `test:"2,0,r"`
+	Lines []line
+}
+
+type badTag struct {
+	A int `test:"x"`
+}
+
+func TestErrors(t *testing.T) {
+	t.Run("unmarshal type error", func(t *testing.T) {
+		err := test.Unmarshal([]byte("{01,[{02};{0x}]}"), new(order))
+
+		var e *oxygen.UnmarshalTypeError
+		equal(t, true, errors.As(err, &e))
+		equal(t, "order", e.Struct)
+		equal(t, "Lines.Qty", e.Field)
+		equal(t, reflect.TypeOf(0), e.Type)
+		equal(t, int64(11), e.Offset)
+		equal(t, "test: cannot decode data into Go struct field order.Lines.Qty of type int: invalid syntax", err.Error())
+	})
+
+	t.Run("syntax error", func(t *testing.T) {
+		err := test.Unmarshal([]byte("{Sub test??,------test_,0007}"), new(nestedType))
+
+		var e *oxygen.SyntaxError
+		equal(t, true, errors.As(err, &e))
+		equal(t, true, errors.Is(err, oxygen.ErrInvalidFormat))
+		equal(t, "I", e.Field)
+		equal(t, int64(22), e.Offset)
+	})
+
+	t.Run("syntax error in a stream", func(t *testing.T) {
+		dec := test.NewDecoder(bytes.NewBufferString("{Sub test??,------test,0007}\n{Sub test??,------test_,0007}\n"))
+		equal(t, nil, dec.Decode(new(nestedType)))
+
+		var e *oxygen.SyntaxError
+		equal(t, true, errors.As(dec.Decode(new(nestedType)), &e))
+		equal(t, int64(51), e.Offset)
+	})
+
+	t.Run("tag error", func(t *testing.T) {
+		_, err := test.Marshal(badTag{A: 1})
+
+		var e *oxygen.TagError
+		equal(t, true, errors.As(err, &e))
+		equal(t, "x", e.Tag)
+		equal(t, "badTag", e.Struct)
+		equal(t, "A", e.Field)
+	})
+}
+
+type numbers struct {
+	Hex    int     `test:"4,0,r,x16"`
+	Amount float64 `test:"8,0,r,i2"`
+	Small  float64 `test:"4,0,r,i3"`
+	Rate   float32 `test:"6,0,r,f2"`
+	Plain  float64 `test:"6,0,r"`
+}
+
+type badFormat struct {
+	A int `test:"4,0,r,x99"`
+}
+
+func TestNumberFormat(t *testing.T) {
+	input := numbers{Hex: 255, Amount: 1234.5, Small: 0.007, Rate: 1.005, Plain: 0.25}
+
+	data, err := test.Marshal(input)
+	equal(t, nil, err)
+	equal(t, "{00ff,00123450,0007,001.00,000.25}", string(data))
+
+	output := new(numbers)
+	equal(t, nil, test.Unmarshal(data, output))
+	equal(t, &numbers{Hex: 255, Amount: 1234.5, Small: 0.007, Rate: 1, Plain: 0.25}, output)
+
+	err = test.Unmarshal([]byte("{00ff,0012.450}"), output)
+	equal(t, "test: cannot decode data into Go struct field numbers.Amount of type float64: invalid syntax", err.Error())
+
+	var e *oxygen.TagError
+	_, err = test.Marshal(badFormat{A: 1})
+	equal(t, true, errors.As(err, &e))
+	equal(t, "invalid base 99", e.Err.Error())
+
+	hex, err := oxygen.NewWithOptions[csvTag](&csvEngine{sep: ','},
+		oxygen.WithConfig(csvConfig("hex", ",")),
+		oxygen.WithNumberFormat(oxygen.NumberFormat{Base: 16, Verb: 'f', Precision: 1}),
+	)
+	equal(t, nil, err)
+
+	data, err = hex.Marshal(struct {
+		I int
+		F float64
+	}{I: 26, F: 2.25})
+	equal(t, nil, err)
+	equal(t, "1a,2.2", string(data))
+
+	_, err = oxygen.NewWithOptions[csvTag](&csvEngine{sep: ','},
+		oxygen.WithConfig(csvConfig("hex", ",")),
+		oxygen.WithNumberFormat(oxygen.NumberFormat{Verb: 'q'}),
+	)
+	equal(t, true, errors.Is(err, oxygen.ErrInvalidConfig))
+
+	cfg := csvConfig("base", ",")
+	cfg.NumberFormat = oxygen.NumberFormat{Base: 99}
+	invalid := oxygen.New[csvTag](&csvEngine{sep: ','}, cfg)
+	_, err = invalid.Marshal(struct{ I int }{I: 26})
+	equal(t, "base: invalid configuration: invalid base 99", err.Error())
+	err = invalid.Unmarshal([]byte("1a"), new(struct{ I int }))
+	equal(t, true, errors.Is(err, oxygen.ErrInvalidConfig))
+}
+
+func TestDecimals(t *testing.T) {
+	packed := oxygen.NumberFormat{Encoding: oxygen.PackedDecimal, Digits: 5}
+	zoned := oxygen.NumberFormat{Encoding: oxygen.ZonedDecimal, Digits: 4, ImpliedDecimals: 2}
+
+	tests := []struct {
+		name   string
+		data   []byte
+		expect string
+	}{
+		{name: "packed int", data: packed.AppendInt(nil, -12345), expect: "\x12\x34\x5d"},
+		{name: "packed positive int", data: packed.AppendInt(nil, 42), expect: "\x00\x04\x2c"},
+		{name: "packed uint", data: packed.AppendUint(nil, 1234567), expect: "\x12\x34\x56\x7f"},
+		{name: "packed decimal", data: packed.AppendDecimal(nil, oxygen.Decimal{Value: 125, Scale: 1}), expect: "\x00\x01\x3c"},
+		{name: "zoned int", data: zoned.AppendInt(nil, -120), expect: "012}"},
+		{name: "zoned uint", data: zoned.AppendUint(nil, 7), expect: "0007"},
+		{name: "zoned float", data: zoned.AppendFloat(nil, 1.5, 64), expect: "015{"},
+		{name: "zoned decimal", data: zoned.AppendDecimal(nil, oxygen.Decimal{Value: 12345, Scale: 3}), expect: "123E"},
+		{name: "text decimal", data: oxygen.NumberFormat{}.AppendDecimal(nil, oxygen.Decimal{Value: -5, Scale: 2}), expect: "-0.05"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			equal(t, tt.expect, string(tt.data))
+		})
+	}
+
+	i, err := packed.ParseInt("\x12\x34\x5d", 64)
+	equal(t, nil, err)
+	equal(t, int64(-12345), i)
+
+	u, err := packed.ParseUint("\x12\x34\x56\x7f", 64)
+	equal(t, nil, err)
+	equal(t, uint64(1234567), u)
+
+	d, err := zoned.ParseDecimal("012}")
+	equal(t, nil, err)
+	equal(t, oxygen.Decimal{Value: -120, Scale: 2}, d)
+	equal(t, "-1.20", d.String())
+
+	d, err = oxygen.NumberFormat{}.ParseDecimal("-12.50")
+	equal(t, nil, err)
+	equal(t, oxygen.Decimal{Value: -1250, Scale: 2}, d)
+
+	for _, in := range []string{"\x12\x34", "\x1a\x3c", "\x12\x3d\x4c"} {
+		_, err = packed.ParseInt(in, 64)
+		equal(t, true, errors.Is(err, strconv.ErrSyntax))
+	}
+
+	_, err = packed.ParseInt("\x12\x8c", 8)
+	equal(t, true, errors.Is(err, strconv.ErrRange))
+
+	_, err = packed.ParseUint("\x1d", 64)
+	equal(t, true, errors.Is(err, strconv.ErrSyntax))
+
+	_, err = zoned.ParseInt("1X", 64)
+	equal(t, true, errors.Is(err, strconv.ErrSyntax))
+
+	equal(t, "invalid number encoding 9", oxygen.NumberFormat{Encoding: 9}.Validate().Error())
+
+	for _, v := range []float64{math.NaN(), math.Inf(-1), 1e30, 123456} {
+		equal(t, true, errors.Is(packed.CheckFloat(v), strconv.ErrRange))
+	}
+	equal(t, nil, packed.CheckFloat(-12345))
+	equal(t, nil, oxygen.NumberFormat{}.CheckFloat(math.NaN()))
+
+	_, err = test.Marshal(records.Reading{Value: 1234.5})
+	var me *oxygen.MarshalTypeError
+	equal(t, true, errors.As(err, &me))
+	equal(t, "Value", me.Field)
+	equal(t, "cannot write 1234.5 as a decimal of 5 digits: value out of range", me.Err.Error())
+
+	// Integers and decimals wider than Digits would shift the fields after them.
+	for _, in := range []records.Reading{
+		{Count: -123456},
+		{Units: 100000},
+		{Total: oxygen.Decimal{Value: 1234567, Scale: 3}},
+		{Total: oxygen.Decimal{Value: 1 << 62}},
+	} {
+		_, err = test.Marshal(in)
+		equal(t, true, errors.As(err, &me))
+		equal(t, true, errors.Is(err, strconv.ErrRange))
+	}
+	equal(t, "cannot write 100000 as a decimal of 5 digits: value out of range", packed.CheckUint(100000).Error())
+	equal(t, nil, packed.CheckInt(-99999))
+	equal(t, "cannot rescale 4611686018427387904 to 2 decimals: value out of range",
+		oxygen.NumberFormat{ImpliedDecimals: 2}.CheckDecimal(oxygen.Decimal{Value: 1 << 62}).Error())
+
+	data, err := test.Marshal(records.Reading{Value: 1, Count: -12345, Units: 99999, Total: oxygen.Decimal{Value: 12345, Scale: 3}})
+	equal(t, nil, err)
+	equal(t, "{\x00\x10\x0c,\x12\x34\x5d,\x99\x99\x9f,\x01\x23\x5c}", string(data))
+
+	// Implied decimals are written in base 10 only.
+	equal(t, "implied decimals cannot be written in base 16", oxygen.NumberFormat{Base: 16, ImpliedDecimals: 2}.Validate().Error())
+	equal(t, nil, oxygen.NumberFormat{Base: 16, ImpliedDecimals: 2, Encoding: oxygen.PackedDecimal}.Validate())
+
+	codecParity(t, test.MarshalReading, test.UnmarshalReading, []records.Reading{
+		{Value: -12.5}, {Value: math.NaN()}, {Value: math.Inf(1)}, {Value: 1234.5},
+		{Count: -99999, Units: 99999, Total: oxygen.Decimal{Value: -12345, Scale: 3}},
+		{Count: 100000}, {Units: 123456}, {Total: oxygen.Decimal{Value: 1000, Scale: 0}}, {Total: oxygen.Decimal{Value: 1 << 62}},
+	}, nil)
+}
+
+func TestCodec(t *testing.T) {
+	note, price := "memo", uint(250)
+	orders := []records.Order{
+		{},
+		{
+			Header:  records.Header{Kind: "A", Rev: 3},
+			ID:      42,
+			Paid:    true,
+			Amount:  12.5,
+			Code:    "XY",
+			Note:    &note,
+			Raw:     []byte("raw"),
+			Items:   []records.Line{{Qty: 1}, {Qty: 2, Price: &price}},
+			Pins:    [2]uint16{7, 8},
+			Next:    &records.Line{Qty: 9},
+			State:   records.Done,
+			Created: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			Tags:    []string{"a", "bc"},
+			Extra:   "z",
+			Cents:   -12.05,
+			Hex:     0xbeef,
+			Sizes:   []int16{5, -1, 12},
+			Port:    -2,
+			Ratio:   0.75,
+			Total:   oxygen.Decimal{Value: -12345, Scale: 2},
+			Count:   -12,
+		},
+	}
+
+	for _, o := range orders {
+		exp, err := test.Marshal(o)
+		equal(t, nil, err)
+
+		data, err := test.MarshalOrder(&o)
+		equal(t, nil, err)
+		equal(t, string(exp), string(data))
+
+		want, got := new(records.Order), new(records.Order)
+		equal(t, nil, test.Unmarshal(data, want))
+		equal(t, nil, test.UnmarshalOrder(data, got))
+		equal(t, want, got)
+	}
+
+	full, err := test.MarshalOrder(&orders[1])
+	equal(t, nil, err)
+
+	_, err = test.MarshalOrder(&records.Order{State: 7})
+	_, exp := test.Marshal(records.Order{State: 7})
+	equal(t, exp, err)
+
+	_, err = test.MarshalOrder(&records.Order{Sizes: make([]int16, 256)})
+	_, exp = test.Marshal(records.Order{Sizes: make([]int16, 256)})
+	equal(t, exp, err)
+
+	for _, data := range []string{
+		"{A,03,0042,true ,0012.5,XY_,memo__,raw,[{01,000};{02,2x0}]}",
+		"{A,03,0042,maybe}",
+		"{A,03;0042}",
+		"{A,03,0042,true ,0012.5,XY_,memo__,raw,[{01,000}{02,250}]}",
+		string(full[:len(full)-16]),
+		string(full[:len(full)-9]) + "\x12\x3f\x00,0000}",
+		strings.Replace(string(full), ",3,[05;-1;12],", ",4,[05;-1;12],", 1),
+		strings.Replace(string(full), ",3,[05;-1;12],", ",2,[05;-1;12],", 1),
+		"{A,03,0042,true ,0012.5,XY_,memo__,raw,[{01,000};{}]}",
+		"{A,03,0042,true ,0012.5,XY_,memo__,raw,[{};{}],[000;000],{}}",
+	} {
+		exp := test.Unmarshal([]byte(data), new(records.Order))
+		err := test.UnmarshalOrder([]byte(data), new(records.Order))
+		equal(t, exp, err)
+	}
+}
+
+// codecParity checks that the generated codec encodes the values and decodes the data, the encoded values included,
+// as Marshal and Unmarshal do, errors included.
+func codecParity[V any](t *testing.T, marshal func(*V) ([]byte, error), unmarshal func([]byte, *V) error, values []V, data []string) {
+	t.Helper()
+
+	for _, v := range values {
+		exp, expErr := test.Marshal(v)
+		got, err := marshal(&v)
+		equal(t, expErr, err)
+		equal(t, string(exp), string(got))
+		if err == nil {
+			data = append(data, string(got))
+		}
+	}
+
+	for _, d := range data {
+		want, got := new(V), new(V)
+		err := unmarshal([]byte(d), got)
+		equal(t, test.Unmarshal([]byte(d), want), err)
+		if err == nil {
+			equal(t, want, got)
+		}
+	}
+}
+
+type csvTag struct{}
+
+type csvEngine struct {
+	oxygen.Default[csvTag]
+	sep byte
+}
+
+func (e *csvEngine) Decode(_ string, _ *csvTag, in []byte) ([]byte, int, error) {
+	i := bytes.IndexByte(in, e.sep)
+	if i < 0 {
+		i = len(in)
+	}
+	return in[:i], i, nil
+}
+
+func (e *csvEngine) IsMarshaller(reflect.Value) (func() ([]byte, error), bool) {
+	return nil, false
+}
+
+func (e *csvEngine) IsUnmarshaler(reflect.Value) (func([]byte) error, bool) {
+	return nil, false
+}
+
+func csvConfig(name, sep string) oxygen.Config {
+	return oxygen.Config{
+		ValueSeparator:              []byte(sep),
+		RemoveSeparatorWhenDecoding: true,
+		Name:                        name,
+		Marshaller:                  reflect.TypeOf((*test.Marshaller)(nil)).Elem(),
+		Unmarshaler:                 reflect.TypeOf((*test.Unmarshaler)(nil)).Elem(),
+	}
+}
+
+var (
+	csv = oxygen.New[csvTag](&csvEngine{sep: ','}, csvConfig("csv", ","))
+	tsv = oxygen.New[csvTag](&csvEngine{sep: '\t'}, csvConfig("tsv", "\t"))
+
+	noText = oxygen.New[csvTag](&csvEngine{sep: ','}, func() oxygen.Config {
+		cfg := csvConfig("noText", ",")
+		cfg.DisableTextMarshaler = true
+		return cfg
+	}())
+)
+
+type multiFormat struct {
+	A string `test:"3,_,l" csv:"-"`
+	B int    `test:"4,0,r"`
+	C string `test:"3,_,l" tsv:"-"`
+}
+
+func TestEngines(t *testing.T) {
+	tests := []struct {
+		name    string
+		marshal func(any) ([]byte, error)
+		decode  func([]byte, any) error
+		expect  []byte
+		output  *multiFormat
+	}{
+		{
+			name:    "test",
+			marshal: test.Marshal,
+			decode:  test.Unmarshal,
+			expect:  []byte("{ab_,0001,cd_}"),
+			output:  &multiFormat{A: "ab", B: 1, C: "cd"},
+		},
+		{
+			name:    "csv",
+			marshal: csv.Marshal,
+			decode:  csv.Unmarshal,
+			expect:  []byte("1,cd"),
+			output:  &multiFormat{B: 1, C: "cd"},
+		},
+		{
+			name:    "tsv",
+			marshal: tsv.Marshal,
+			decode:  tsv.Unmarshal,
+			expect:  []byte("ab\t1"),
+			output:  &multiFormat{A: "ab", B: 1},
+		},
+	}
+
+	input := multiFormat{A: "ab", B: 1, C: "cd"}
+
+	// Run twice so that the second pass works with the cached coders and fields.
+	for i := 0; i < 2; i++ {
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				data, err := tt.marshal(input)
+				equal(t, nil, err)
+				equal(t, tt.expect, data)
+
+				output := new(multiFormat)
+				equal(t, nil, tt.decode(data, output))
+				equal(t, tt.output, output)
+			})
+		}
+	}
+}
+
+type csvList struct {
+	L []string
+	A [3]string
+	N int
+}
+
+func TestListWithoutSeparators(t *testing.T) {
+	data, err := csv.Marshal(csvList{L: []string{"a"}, A: [3]string{"b"}, N: 2})
+	equal(t, nil, err)
+	equal(t, "a,b,2", string(data))
+
+	// The elements of a list aren't separated, the first element that consumes no input ends it.
+	output := new(csvList)
+	equal(t, nil, csv.Unmarshal(data, output))
+	equal(t, &csvList{L: []string{"a"}, A: [3]string{"b"}, N: 2}, output)
+}
+
+func TestNewWithOptions(t *testing.T) {
+	marshaler := oxygen.WithMarshaler[test.Marshaller]()
+	unmarshaler := oxygen.WithUnmarshaler[test.Unmarshaler]()
+
+	psv, err := oxygen.NewWithOptions[csvTag](&csvEngine{sep: '|'},
+		oxygen.WithName("psv"),
+		oxygen.WithSeparator([]byte("|"), true),
+		marshaler,
+		unmarshaler,
+	)
+	equal(t, nil, err)
+
+	data, err := psv.Marshal(multiFormat{A: "ab", B: 1, C: "cd"})
+	equal(t, nil, err)
+	equal(t, "ab|1|cd", string(data))
+
+	tests := []struct {
+		name string
+		opts []oxygen.Option
+	}{
+		{
+			name: "no name",
+			opts: []oxygen.Option{marshaler, unmarshaler},
+		},
+		{
+			name: "no marshaler",
+			opts: []oxygen.Option{oxygen.WithName("psv"), unmarshaler},
+		},
+		{
+			name: "non-interface marshaler",
+			opts: []oxygen.Option{oxygen.WithName("psv"), oxygen.WithMarshaler[int](), unmarshaler},
+		},
+		{
+			name: "unwrap without delimiters",
+			opts: []oxygen.Option{oxygen.WithName("psv"), marshaler, unmarshaler, oxygen.WithStructDelimiters(nil, nil, true)},
+		},
+		{
+			name: "remove an empty separator",
+			opts: []oxygen.Option{oxygen.WithName("psv"), marshaler, unmarshaler, oxygen.WithElementSeparator(nil, true)},
+		},
+		{
+			name: "separator equals closer",
+			opts: []oxygen.Option{oxygen.WithName("psv"), marshaler, unmarshaler, oxygen.WithListDelimiters([]byte("["), []byte("]"), true), oxygen.WithElementSeparator([]byte("]"), true)},
+		},
+		{
+			name: "byte order of text primitives",
+			opts: []oxygen.Option{oxygen.WithName("psv"), marshaler, unmarshaler, oxygen.WithPrimitives(oxygen.Text, binary.LittleEndian)},
+		},
+		{
+			name: "negative max depth",
+			opts: []oxygen.Option{oxygen.WithName("psv"), marshaler, unmarshaler, oxygen.WithMaxDepth(-1)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := oxygen.NewWithOptions[csvTag](&csvEngine{sep: '|'}, tt.opts...)
+			equal(t, nil, e)
+			equal(t, true, errors.Is(err, oxygen.ErrInvalidConfig))
+		})
+	}
+}
+
+type frame struct {
+	I int16
+	U uint32
+	F float32
+	D float64
+	B bool
+	S string
+}
+
+func TestPrimitives(t *testing.T) {
+	le, err := oxygen.NewWithOptions[csvTag](&csvEngine{sep: ','},
+		oxygen.WithName("le"),
+		oxygen.WithMarshaler[test.Marshaller](),
+		oxygen.WithUnmarshaler[test.Unmarshaler](),
+		oxygen.WithPrimitives(oxygen.Binary, binary.LittleEndian),
+	)
+	equal(t, nil, err)
+
+	input := frame{I: -2, U: 0x01020304, F: 1.5, D: -0.25, B: true, S: "ok"}
+	data, err := le.Marshal(input)
+	equal(t, nil, err)
+	equal(t, []byte{
+		0xfe, 0xff,
+		0x04, 0x03, 0x02, 0x01,
+		0x00, 0x00, 0xc0, 0x3f,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xd0, 0xbf,
+		0x01,
+		'o', 'k',
+	}, data)
+
+	output := new(frame)
+	equal(t, nil, le.Unmarshal(data, output))
+	equal(t, &input, output)
+
+	var e *oxygen.UnmarshalTypeError
+	err = le.Unmarshal(data[:3], new(frame))
+	equal(t, true, errors.As(err, &e))
+	equal(t, "U", e.Field)
+	equal(t, io.ErrUnexpectedEOF, e.Err)
+
+	err = le.Unmarshal([]byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 2}, new(frame))
+	equal(t, true, errors.As(err, &e))
+	equal(t, "B", e.Field)
+
+	be, err := oxygen.NewWithOptions[csvTag](&csvEngine{sep: ','},
+		oxygen.WithName("be"),
+		oxygen.WithMarshaler[test.Marshaller](),
+		oxygen.WithUnmarshaler[test.Unmarshaler](),
+		oxygen.WithPrimitives(oxygen.Binary, nil),
+	)
+	equal(t, nil, err)
+
+	data, err = be.Marshal(struct{ I int16 }{I: 258})
+	equal(t, nil, err)
+	equal(t, []byte{0x01, 0x02}, data)
+
+	cfg := csvConfig("primitives", ",")
+	cfg.Primitives = 7
+	_, err = oxygen.New[csvTag](&csvEngine{sep: ','}, cfg).Marshal(frame{})
+	equal(t, "primitives: invalid configuration: invalid primitives 7", err.Error())
+
+	// The test format overrides the primitives per field.
+	data, err = test.Marshal(records.Order{Port: 1})
+	equal(t, nil, err)
+	equal(t, true, bytes.Contains(data, []byte{',', 0x01, 0x00, ','}))
+}
+
+type mainframe struct {
+	Name string
+	Note string
+	Qty  int
+}
+
+func TestCharset(t *testing.T) {
+	ebcdic, err := oxygen.NewWithOptions[csvTag](&csvEngine{sep: ','},
+		oxygen.WithConfig(csvConfig("ebcdic", ",")),
+		oxygen.WithNumberFormat(oxygen.NumberFormat{Encoding: oxygen.PackedDecimal}),
+		oxygen.WithCharset(oxygen.CP037),
+	)
+	equal(t, nil, err)
+
+	input := mainframe{Name: "Hé", Note: "x", Qty: 345}
+	data, err := ebcdic.Marshal(input)
+	equal(t, nil, err)
+	equal(t, []byte{0xc8, 0x51, 0x6b, 0xa7, 0x6b, 0x34, 0x5c}, data)
+
+	output := new(mainframe)
+	equal(t, nil, ebcdic.Unmarshal(data, output))
+	equal(t, &input, output)
+
+	var e *oxygen.MarshalTypeError
+	_, err = ebcdic.Marshal(mainframe{Note: "5 €"})
+	equal(t, true, errors.As(err, &e))
+	equal(t, "Note", e.Field)
+	equal(t, true, errors.Is(err, oxygen.ErrUnmappableRune))
+
+	data, err = oxygen.Windows1252.Encode(nil, []byte("5 €"))
+	equal(t, nil, err)
+	equal(t, []byte{'5', ' ', 0x80}, data)
+	equal(t, "Hé", string(oxygen.CP500.Decode(nil, []byte{0xc8, 0x51})))
+
+	_, err = oxygen.NewWithOptions[csvTag](&csvEngine{sep: ','},
+		oxygen.WithConfig(csvConfig("latin", "€")),
+		oxygen.WithCharset(oxygen.ISO88591),
+	)
+	equal(t, true, errors.Is(err, oxygen.ErrInvalidConfig))
+
+	// New reports the delimiter on every call.
+	cfg := csvConfig("latin", "€")
+	cfg.Charset = oxygen.ISO88591
+	latin := oxygen.New[csvTag](&csvEngine{sep: ','}, cfg)
+	_, err = latin.Marshal(mainframe{})
+	equal(t, true, errors.Is(err, oxygen.ErrInvalidConfig))
+	err = latin.Unmarshal([]byte("a"), new(mainframe))
+	equal(t, true, errors.Is(err, oxygen.ErrInvalidConfig))
+}
+
+type (
+	fileHeader struct {
+		Date string `test:"8"`
+	}
+	fileDetail struct {
+		ID   int    `test:"4,0,r"`
+		Name string `test:"5,_,l"`
+	}
+	fileNote struct {
+		Text string `test:"3,_,l"`
+	}
+	fileTrailer struct {
+		Count int `test:"3,0,r"`
+	}
+)
+
+func TestRecordSet(t *testing.T) {
+	rs := test.NewRecordSet()
+	rs.Register("H", fileHeader{})
+	rs.Register("D", fileDetail{})
+	rs.Register("DN", fileNote{})
+	rs.Register("T", &fileTrailer{})
+
+	records := []any{
+		fileHeader{Date: "20240102"},
+		fileDetail{ID: 1, Name: "ab"},
+		fileNote{Text: "x"},
+		fileDetail{ID: 2, Name: "cd"},
+		&fileTrailer{Count: 2},
+	}
+	expect := "H{20240102}\nD{0001,ab___}\nDN{x__}\nD{0002,cd___}\nT{002}\n"
+
+	data, err := rs.Marshal(records...)
+	equal(t, nil, err)
+	equal(t, expect, string(data))
+
+	output, err := rs.Unmarshal(data)
+	equal(t, nil, err)
+	equal(t, records, output)
+
+	buf := new(bytes.Buffer)
+	enc := rs.NewEncoder(buf)
+	for _, r := range records {
+		equal(t, nil, enc.Encode(r))
+	}
+	equal(t, expect, buf.String())
+
+	dec := rs.NewDecoder(strings.NewReader(expect + "\n"))
+	for _, r := range records {
+		v, err := dec.Decode()
+		equal(t, nil, err)
+		equal(t, r, v)
+	}
+	_, err = dec.Decode()
+	equal(t, io.EOF, err)
+
+	var se *oxygen.SyntaxError
+	_, err = rs.Unmarshal([]byte("H{20240102}\nX{1}\n"))
+	equal(t, true, errors.As(err, &se))
+	equal(t, int64(12), se.Offset)
+	equal(t, true, errors.Is(err, oxygen.ErrUnknownRecord))
+
+	_, err = rs.Marshal(fileHeader{}, tree{})
+	equal(t, true, errors.Is(err, oxygen.ErrUnknownRecord))
+}
+
+type occurs struct {
+	N     int8   `test:"2,0,r"`
+	Items []int  `test:"2,0,r,,N"`
+	Tail  string `test:"2,_,l"`
+}
+
+type sharedCount struct {
+	N uint8    `test:"1,0,r"`
+	A []string `test:"1, ,l,,N"`
+	B []string `test:"1, ,l,,N"`
+}
+
+type countAfter struct {
+	Items []int `test:"2,0,r,,N"`
+	N     int
+}
+
+type countOfString struct {
+	N int
+	S string `test:"2, ,l,,N"`
+}
+
+func TestCountField(t *testing.T) {
+	data, err := test.Marshal(occurs{N: 7, Items: []int{1, 2, 3}, Tail: "x"})
+	equal(t, nil, err)
+	equal(t, "{03,[01;02;03],x_}", string(data))
+
+	out := new(occurs)
+	equal(t, nil, test.Unmarshal(data, out))
+	equal(t, &occurs{N: 3, Items: []int{1, 2, 3}, Tail: "x"}, out)
+
+	out = &occurs{Items: []int{9}}
+	equal(t, nil, test.Unmarshal([]byte("{00,[],x_}"), out))
+	equal(t, &occurs{Tail: "x"}, out)
+
+	var ute *oxygen.UnmarshalTypeError
+	err = test.Unmarshal([]byte("{04,[01;02;03],x_}"), new(occurs))
+	equal(t, true, errors.As(err, &ute))
+	equal(t, "Items", ute.Field)
+	equal(t, int64(14), ute.Offset)
+	equal(t, "found 3 elements out of 4", ute.Err.Error())
+
+	err = test.Unmarshal([]byte("{-1,[],x_}"), new(occurs))
+	equal(t, true, errors.As(err, &ute))
+	equal(t, int64(4), ute.Offset)
+	equal(t, "negative count -1 of elements", ute.Err.Error())
+
+	var mte *oxygen.MarshalTypeError
+	_, err = test.Marshal(occurs{Items: make([]int, 128)})
+	equal(t, true, errors.As(err, &mte))
+	equal(t, "N", mte.Field)
+	equal(t, "length 128 of the slice overflows the count", mte.Err.Error())
+
+	data, err = test.Marshal(sharedCount{A: []string{"a", "b"}, B: []string{"c", "d"}})
+	equal(t, nil, err)
+	equal(t, "{2,[a;b],[c;d]}", string(data))
+
+	_, err = test.Marshal(sharedCount{A: []string{"a", "b"}, B: []string{"c"}})
+	equal(t, true, errors.As(err, &mte))
+	equal(t, "B", mte.Field)
+	equal(t, "length 1 of the slice differs from the count 2", mte.Err.Error())
+
+	var te *oxygen.TagError
+	_, err = test.Marshal(countAfter{})
+	equal(t, true, errors.As(err, &te))
+	equal(t, "no integer field N precedes the field", te.Err.Error())
+
+	_, err = test.Marshal(countOfString{})
+	equal(t, true, errors.As(err, &te))
+	equal(t, "only the length of a slice can be bound", te.Err.Error())
+}
+
+type payment struct {
+	Foreign  string `test:"1"`
+	Currency string `test:"3,_,l,,,Foreign=Y|y"`
+	Amount   int    `test:"4,0,r"`
+	Fee      int    `test:"2,0,r,,,@fee"`
+}
+
+type currencyAfter struct {
+	Currency string `test:"3,_,l,,,Foreign=Y"`
+	Foreign  string `test:"1"`
+}
+
+type unknownPredicate struct {
+	Fee int `test:"2,0,r,,,@unknown"`
+}
+
+type otherPredicate struct {
+	Fee int `test:"2,0,r,,,@fee"`
+}
+
+func TestPresence(t *testing.T) {
+	test.RegisterPredicate("fee", func(p *payment) bool {
+		return p.Amount > 100
+	})
+	test.RegisterPredicate("rated", func(p *records.Payment) bool {
+		return p.Foreign == "Y" && p.Currency != "EUR"
+	})
+
+	tests := []struct {
+		name   string
+		input  payment
+		expect string
+	}{
+		{name: "present", input: payment{Foreign: "Y", Currency: "USD", Amount: 150, Fee: 3}, expect: "{Y,USD,0150,03}"},
+		{name: "another value", input: payment{Foreign: "y", Currency: "EUR", Amount: 5}, expect: "{y,EUR,0005}"},
+		{name: "absent", input: payment{Foreign: "N", Currency: "USD", Amount: 5, Fee: 3}, expect: "{N,0005}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := test.Marshal(tt.input)
+			equal(t, nil, err)
+			equal(t, tt.expect, string(data))
+
+			out := new(payment)
+			equal(t, nil, test.Unmarshal(data, out))
+			data, err = test.Marshal(out)
+			equal(t, nil, err)
+			equal(t, tt.expect, string(data))
+		})
+	}
+
+	out := payment{Currency: "GBP"}
+	equal(t, nil, test.Unmarshal([]byte("{N,0500,07}"), &out))
+	equal(t, payment{Foreign: "N", Currency: "GBP", Amount: 500, Fee: 7}, out)
+
+	var te *oxygen.TagError
+	for _, v := range []any{currencyAfter{}, unknownPredicate{}, otherPredicate{}} {
+		_, err := test.Marshal(v)
+		equal(t, true, errors.As(err, &te))
+	}
+	equal(t, "predicate fee is registered for test_test.payment", te.Err.Error())
+
+	codecParity(t, test.MarshalPayment, test.UnmarshalPayment, []records.Payment{
+		{Amount: 5, Foreign: "Y", Currency: "USD", Rate: 1234},
+		{Amount: 5, Foreign: "Y", Currency: "EUR", Rate: 1234},
+		{Amount: 5, Foreign: "N", Currency: "USD", Rate: 1234},
+	}, []string{"{0005,N,USD,1234}", "{0005,N,1234}", "{0005,Y,USD}", "{0005,Y}"})
+}
+
+type account struct {
+	ID     string `test:"!4"`
+	Name   string `test:"3,_,l"`
+	Limits limits
+}
+
+type limits struct {
+	Daily   int `test:"!3,0,r"`
+	Monthly int `test:"!4,0,r"`
+}
+
+func TestRequired(t *testing.T) {
+	output := new(account)
+	equal(t, nil, test.Unmarshal([]byte("{0001,ab_,{100,1000}}"), output))
+	equal(t, &account{ID: "0001", Name: "ab", Limits: limits{Daily: 100, Monthly: 1000}}, output)
+
+	tests := []struct {
+		name   string
+		input  string
+		fields []string
+	}{
+		{name: "nested", input: "{0001,ab_,{100}}", fields: []string{"Limits.Monthly"}},
+		{name: "no nested data", input: "{0001}", fields: []string{"Limits.Daily", "Limits.Monthly"}},
+		{name: "empty nested", input: "{0001,ab_,{}}", fields: []string{"Limits.Daily", "Limits.Monthly"}},
+		{name: "all", input: "{}", fields: []string{"ID", "Limits.Daily", "Limits.Monthly"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := test.Unmarshal([]byte(tt.input), new(account))
+
+			var re *oxygen.RequiredFieldError
+			equal(t, true, errors.As(err, &re))
+			equal(t, true, errors.Is(err, oxygen.ErrRequired))
+			equal(t, "account", re.Struct)
+			equal(t, tt.fields, re.Fields)
+		})
+	}
+
+	// The paths of the fields of list elements hold the index of the element.
+	err := test.Unmarshal([]byte("{0001,{100,1000},[{01,ab};{}]}"), new(records.Batch))
+	var re *oxygen.RequiredFieldError
+	equal(t, true, errors.As(err, &re))
+	equal(t, []string{"Items[1].Qty"}, re.Fields)
+
+	codecParity(t, test.MarshalBatch, test.UnmarshalBatch, []records.Batch{
+		{},
+		{ID: 1, Limits: records.Limits{Daily: 100, Monthly: 1000}, Items: []records.Item{{Qty: 1, Note: "ab"}, {Qty: 2}}},
+	}, []string{
+		"{0001}",
+		"{}",
+		"{0001,{100}}",
+		"{0001,{100,1000},[{01,ab};{}]}",
+		"{0001,{100,1000},[{01,ab};{02}]}",
+	})
+}
+
+type shipment struct {
+	Country string    `test:"2, ,l,,,,US"`
+	Boxes   int       `test:"3,0,r,,,,*1"`
+	Due     time.Time `test:"20, ,l,,,,2024-01-01T00:00:00Z"`
+}
+
+func TestDefault(t *testing.T) {
+	data, err := test.Marshal(shipment{Due: time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)})
+	equal(t, nil, err)
+	equal(t, "{  ,001,2024-05-06T00:00:00Z}", string(data))
+
+	output := new(shipment)
+	equal(t, nil, test.Unmarshal([]byte("{  ,000,                    }"), output))
+	equal(t, &shipment{Country: "US", Boxes: 1, Due: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}, output)
+
+	output = new(shipment)
+	equal(t, nil, test.Unmarshal([]byte("{CA,005,2024-05-06T00:00:00Z}"), output))
+	equal(t, &shipment{Country: "CA", Boxes: 5, Due: time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)}, output)
+
+	t.Run("invalid", func(t *testing.T) {
+		err := test.Unmarshal([]byte("{abc}"), new(struct {
+			Boxes int `test:"3,0,r,,,,many"`
+		}))
+		var te *oxygen.TagError
+		equal(t, true, errors.As(err, &te))
+		equal(t, true, errors.Is(err, strconv.ErrSyntax))
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := test.Marshal(struct {
+			Tags []string `test:"2, ,l,,,,a"`
+		}{})
+		var te *oxygen.TagError
+		equal(t, true, errors.As(err, &te))
+		equal(t, "cannot set a default value of type []string", te.Err.Error())
+	})
+
+	floor := uint8(3)
+	codecParity(t, test.MarshalAddress, test.UnmarshalAddress, []records.Address{
+		{},
+		{Street: "Main", Country: "CA", Floor: &floor},
+	}, []string{"{Main  ,  ,00}", "{Main  ,  }", "{Main  }"})
+
+	// A pointer field with a default value points to a copy of it.
+	first, second := new(records.Address), new(records.Address)
+	equal(t, nil, test.Unmarshal([]byte("{Main  ,  ,00}"), first))
+	equal(t, nil, test.UnmarshalAddress([]byte("{Main  ,  ,00}"), second))
+	floor = 1
+	equal(t, &records.Address{Street: "Main", Country: "US", Floor: &floor}, first)
+	equal(t, first, second)
+	*first.Floor = 2
+	equal(t, nil, test.Unmarshal([]byte("{Main  ,  ,00}"), second))
+	equal(t, uint8(1), *second.Floor)
+}
+
+type span struct {
+	From int `test:"2,0,r"`
+	To   int `test:"2,0,r"`
+}
+
+// BeforeMarshal orders the ends of the span.
+func (s *span) BeforeMarshal() error {
+	if s.From > s.To {
+		s.From, s.To = s.To, s.From
+	}
+	if s.From < 0 {
+		return errors.New("negative span")
+	}
+	return nil
+}
+
+func (s *span) AfterUnmarshal() error {
+	s.To += s.From
+	return nil
+}
+
+func (s *span) Validate() error {
+	if s.To > 99 {
+		return errors.New("span too long")
+	}
+	return nil
+}
+
+type route struct {
+	Name string `test:"2"`
+	Span span
+}
+
+func TestHooks(t *testing.T) {
+	input := span{From: 9, To: 2}
+	data, err := test.Marshal(input)
+	equal(t, nil, err)
+	equal(t, "{02,09}", string(data))
+	equal(t, span{From: 9, To: 2}, input)
+
+	output := new(span)
+	equal(t, nil, test.Unmarshal(data, output))
+	equal(t, &span{From: 2, To: 11}, output)
+
+	_, err = test.Marshal(route{Name: "ab", Span: span{From: -1}})
+	var me *oxygen.MarshalTypeError
+	equal(t, true, errors.As(err, &me))
+	equal(t, "test: cannot encode data from Go struct field route.Span of type test_test.span: BeforeMarshal: negative span", err.Error())
+
+	err = test.Unmarshal([]byte("{ab,{50,50}}"), new(route))
+	var ue *oxygen.UnmarshalTypeError
+	equal(t, true, errors.As(err, &ue))
+	equal(t, int64(4), ue.Offset)
+	equal(t, "test: cannot decode data into Go struct field route.Span of type test_test.span: Validate: span too long", err.Error())
+
+	codecParity(t, test.MarshalTicket, test.UnmarshalTicket, []records.Ticket{
+		{},
+		{Code: "xy", Legs: []records.Leg{{From: 1, To: 2}, {From: 2, To: 5}}},
+	}, []string{"{ab_,[{01,02}]}", "{AB_,[{01,02};{05,02}]}"})
+}
+
+type header struct {
+	Kind string `test:"1"`
+	Rev  uint8  `test:"2,0,r"`
+}
+
+type record struct {
+	header
+	ID   int       `test:"4,0,r"`
+	Port int16     `test:"0, ,l,le"`
+	Pins [2]uint16 `test:"3,0,r"`
+	Span span
+	Due  time.Time `test:"20, ,l"`
+	Tags []string  `test:"2"`
+	Note string    `test:"2"`
+}
+
+func TestDescribe(t *testing.T) {
+	layout, err := test.Describe(&record{})
+	equal(t, nil, err)
+	equal(t, reflect.TypeOf(record{}), layout.Type)
+	equal(t, -1, layout.Width)
+
+	type column struct {
+		path          string
+		offset, width int
+		promoted      bool
+		encoder       oxygen.Coder
+	}
+	var columns []column
+	var walk func(fs []oxygen.FieldLayout)
+	walk = func(fs []oxygen.FieldLayout) {
+		for _, f := range fs {
+			columns = append(columns, column{f.Path, f.Offset, f.Width, f.Promoted, f.Encoder})
+			walk(f.Fields)
+		}
+	}
+	walk(layout.Fields)
+
+	equal(t, []column{
+		{"header.Kind", 1, 1, true, oxygen.KindCoder},
+		{"header.Rev", 3, 2, true, oxygen.KindCoder},
+		{"ID", 6, 4, false, oxygen.KindCoder},
+		{"Port", 11, 2, false, oxygen.KindCoder},
+		{"Pins", 14, 9, false, oxygen.KindCoder},
+		{"Span", 24, 7, false, oxygen.KindCoder},
+		{"Span.From", 25, 2, false, oxygen.KindCoder},
+		{"Span.To", 28, 2, false, oxygen.KindCoder},
+		{"Due", 32, 20, false, oxygen.TextCoder},
+		{"Tags", 53, -1, false, oxygen.KindCoder},
+		{"Note", -1, 2, false, oxygen.KindCoder},
+	}, columns)
+
+	id := layout.Fields[2]
+	equal(t, oxygen.RightAlignment, id.Align)
+	equal(t, byte('0'), id.Filler)
+
+	data, err := test.Marshal(record{})
+	equal(t, nil, err)
+	equal(t, "{", string(data[:1]))
+	equal(t, "0001-01-01T00:00:00Z", string(data[32:52]))
+
+	_, err = test.Describe(1)
+	equal(t, "test: Describe(non-struct int)", err.Error())
+
+	_, err = test.Describe(struct {
+		ID int `test:"x"`
+	}{})
+	var te *oxygen.TagError
+	equal(t, true, errors.As(err, &te))
+	equal(t, "ID", te.Field)
+
+	// A field with a predicate that isn't registered is described as conditional.
+	type charge struct {
+		Kind string `test:"1"`
+		Fee  int    `test:"2,0,r,,,@unregistered"`
+		Note string `test:"2"`
+	}
+	layout, err = test.Describe(charge{})
+	equal(t, nil, err)
+	equal(t, 3, len(layout.Fields))
+	equal(t, 3, layout.Fields[1].Offset)
+	equal(t, -1, layout.Fields[2].Offset)
+
+	_, err = test.Marshal(charge{})
+	equal(t, true, errors.As(err, &te))
+	equal(t, "Fee", te.Field)
+	equal(t, "no predicate unregistered registered", te.Err.Error())
+}
+
+// TestSchema checks the types generated from records/shipment.cpy.
+func TestSchema(t *testing.T) {
+	input := records.Shipment{
+		ShipId:      42,
+		Weight:      oxygen.Decimal{Value: 1250, Scale: 2},
+		Balance:     oxygen.Decimal{Value: -325, Scale: 2},
+		Delta:       -7,
+		Seq:         9,
+		ParcelCount: 2,
+		Parcels:     []records.Parcels{{ParcelId: "A1", ParcelKg: 3}, {ParcelId: "B2", ParcelKg: 12}},
+		Codes:       [3]string{"x", "yy", "z"},
+		Status:      "D",
+	}
+
+	data, err := test.Marshal(input)
+	equal(t, nil, err)
+	equal(t, "{000042,    ,  ,001250,\x00\x002],00P,\x00\t,02,[{A1 ,003};{B2 ,012}],[x ;yy;z ],D}", string(data))
+
+	output := new(records.Shipment)
+	equal(t, nil, test.Unmarshal(data, output))
+	input.Carrier = "UPS"
+	equal(t, &input, output)
+
+	// The first byte of the packed decimal is the byte of the digit 0, it isn't taken for a filler.
+	input.Balance = oxygen.Decimal{Value: 3000000, Scale: 2}
+	data, err = test.Marshal(input)
+	equal(t, nil, err)
+	equal(t, "0\x00\x00\x0c", string(data[23:27]))
+
+	output = new(records.Shipment)
+	equal(t, nil, test.Unmarshal(data, output))
+	equal(t, &input, output)
+}
+
+type tree struct {
+	V    int
+	Kids []tree
+}
+
+func TestMaxDepth(t *testing.T) {
+	shallow, err := oxygen.NewWithOptions[csvTag](&csvEngine{sep: ','},
+		oxygen.WithConfig(csvConfig("shallow", ",")),
+		oxygen.WithStructDelimiters([]byte("{"), []byte("}"), true),
+		oxygen.WithListDelimiters([]byte("["), []byte("]"), true),
+		oxygen.WithElementSeparator([]byte(";"), true),
+		oxygen.WithMaxDepth(4),
+	)
+	equal(t, nil, err)
+
+	data, err := shallow.Marshal(tree{V: 1, Kids: []tree{{V: 2}, {V: 3}}})
+	equal(t, nil, err)
+	equal(t, "{1,[{2,[]};{3,[]}]}", string(data))
+
+	output := new(tree)
+	equal(t, nil, shallow.Unmarshal(data, output))
+	equal(t, &tree{V: 1, Kids: []tree{{V: 2}, {V: 3}}}, output)
+
+	_, err = shallow.Marshal(tree{V: 1, Kids: []tree{{V: 2, Kids: []tree{{V: 3}}}}})
+	equal(t, true, errors.Is(err, oxygen.ErrMaxDepth))
+
+	err = shallow.Unmarshal([]byte("{1,[{2,[{3,[]}]}]}"), new(tree))
+	equal(t, true, errors.Is(err, oxygen.ErrMaxDepth))
+
+	// The test formatter limits the depth to 8, a node and its children take two levels.
+	deep := records.Node{ID: 1}
+	for i := 2; i <= 5; i++ {
+		deep = records.Node{ID: i, Kids: []records.Node{deep}}
+	}
+	codecParity(t, test.MarshalNode, test.UnmarshalNode, []records.Node{deep.Kids[0], deep}, []string{
+		"{05,[{04,[{03,[{02,[{01,[]}]}]}]}]}",
+	})
+
+	_, err = test.MarshalNode(&deep)
+	equal(t, true, errors.Is(err, oxygen.ErrMaxDepth))
+	err = test.UnmarshalNode([]byte("{05,[{04,[{03,[{02,[{01,[]}]}]}]}]}"), new(records.Node))
+	equal(t, true, errors.Is(err, oxygen.ErrMaxDepth))
+}
+
+type point struct {
+	X, Y  int
+	Label string
+}
+
+// braceEngine reads values up to the separator or the closer of a struct.
+type braceEngine struct {
+	csvEngine
+}
+
+func (e *braceEngine) Decode(_ string, _ *csvTag, in []byte) ([]byte, int, error) {
+	i := bytes.IndexAny(in, ",}")
+	if i < 0 {
+		i = len(in)
+	}
+	return in[:i], i, nil
+}
+
+func TestStrict(t *testing.T) {
+	opts := []oxygen.Option{
+		oxygen.WithConfig(csvConfig("strict", ",")),
+		oxygen.WithStructDelimiters([]byte("{"), []byte("}"), true),
+	}
+	lenient, err := oxygen.NewWithOptions[csvTag](&braceEngine{}, opts...)
+	equal(t, nil, err)
+	strict, err := oxygen.NewWithOptions[csvTag](&braceEngine{}, append(opts, oxygen.WithStrict())...)
+	equal(t, nil, err)
+
+	output := new(point)
+	equal(t, nil, strict.Unmarshal([]byte("{1,2,a}"), output))
+	equal(t, &point{X: 1, Y: 2, Label: "a"}, output)
+
+	tests := []struct {
+		name   string
+		input  string
+		err    error
+		field  string
+		offset int64
+	}{
+		{name: "trailing data", input: "{1,2,a}x", err: oxygen.ErrTrailingData, offset: 7},
+		{name: "unknown field", input: "{1,2,a,b}", err: oxygen.ErrTrailingData, offset: 6},
+		{name: "missing field", input: "{1,2}", err: oxygen.ErrTruncated, field: "Label", offset: 4},
+		{name: "no data", input: "{}", err: oxygen.ErrTruncated, field: "X", offset: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			equal(t, nil, lenient.Unmarshal([]byte(tt.input), new(point)))
+
+			var se *oxygen.SyntaxError
+			err := strict.Unmarshal([]byte(tt.input), new(point))
+			equal(t, true, errors.As(err, &se))
+			equal(t, true, errors.Is(err, tt.err))
+			equal(t, "point", se.Struct)
+			equal(t, tt.field, se.Field)
+			equal(t, tt.offset, se.Offset)
+		})
+	}
+}
+
+func TestEncoder(t *testing.T) {
+	buf := new(bytes.Buffer)
+	enc := test.NewEncoder(buf)
+
+	equal(t, nil, enc.Encode(nt))
+	equal(t, nil, enc.Encode(&nestedType{sub: sub{Str: "Second", PStr: &Str}, I: 8}))
+	equal(t, "{Sub test??,------test,0007}\n{Second????,------test,0008}\n", buf.String())
+}
+
+func TestDecoder(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		expect []nestedType
+		err    error
+	}{
+		{
+			name:  "records with a trailing terminator",
+			input: "{Sub test??,------test,0007}\n{Second????,------test,0008}\n",
+			expect: []nestedType{
+				nt,
+				{sub: sub{Str: "Second", PStr: &Str}, I: 8},
+			},
+		},
+		{
+			name:  "records without a trailing terminator",
+			input: "{Sub test??,------test,0007}\n{Second????,------test,0008}",
+			expect: []nestedType{
+				nt,
+				{sub: sub{Str: "Second", PStr: &Str}, I: 8},
+			},
+		},
+		{
+			name:   "invalid record",
+			input:  "{Sub test??,------test,0007}\n{Second????,------test,00d8}\n",
+			expect: []nestedType{nt},
+			err:    errors.New("test: cannot decode data into Go struct field nestedType.I of type int: invalid syntax"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dec := test.NewDecoder(bytes.NewBufferString(tt.input))
+
+			var got []nestedType
+			for {
+				var v nestedType
+				err := dec.Decode(&v)
+				if err == io.EOF {
+					break
+				}
+				if tt.err != nil && err != nil {
+					equal(t, tt.err.Error(), err.Error())
+					break
+				}
+				equal(t, nil, err)
+				got = append(got, v)
+			}
+			equal(t, tt.expect, got)
+		})
+	}
+}
+
 func BenchmarkPrimeNumbers(b *testing.B) {
 	input := []byte("{{Sub test??,------test},{Sub test??,------test}}")
 	output := new(structFields)